@@ -0,0 +1,12 @@
+package api
+
+import "github.com/OpenNMS/onmsctl/model"
+
+// AlarmsAPI the API to manipulate Alarms
+type AlarmsAPI interface {
+	GetAlarms(fiqlFilter string, limit int) (*model.OnmsAlarmList, error)
+	GetAlarm(id int) (*model.OnmsAlarm, error)
+	UpdateAlarm(id int, action string) error
+	SetMemo(id int, memoType string, body string) error
+	DeleteMemo(id int, memoType string) error
+}
@@ -0,0 +1,10 @@
+package api
+
+import "github.com/OpenNMS/onmsctl/model"
+
+// DaemonsAPI the API to obtain the daemons running on the OpenNMS server
+type DaemonsAPI interface {
+	GetDaemons() ([]model.OnmsDaemon, error)
+	GetDaemonStatus(name string) (*model.OnmsDaemonStatus, error)
+	GetHealth() (*model.OnmsHealth, error)
+}
@@ -0,0 +1,9 @@
+package api
+
+import "github.com/OpenNMS/onmsctl/model"
+
+// EventTemplatesAPI the API to manage event templates
+type EventTemplatesAPI interface {
+	GetTemplates() ([]model.EventTemplate, error)
+	GetTemplate(name string) (*model.EventTemplate, error)
+}
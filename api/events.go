@@ -5,4 +5,5 @@ import "github.com/OpenNMS/onmsctl/model"
 // EventsAPI the API to manipulate Events
 type EventsAPI interface {
 	SendEvent(event model.Event) error
+	GetEvents(fiqlFilter string, limit int) (*model.OnmsEventList, error)
 }
@@ -0,0 +1,8 @@
+package api
+
+import "github.com/OpenNMS/onmsctl/model"
+
+// InfoAPI the API to obtain information about the OpenNMS server
+type InfoAPI interface {
+	GetInfo() (*model.OnmsInfo, error)
+}
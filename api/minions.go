@@ -0,0 +1,10 @@
+package api
+
+import "github.com/OpenNMS/onmsctl/model"
+
+// MinionsAPI the API to manipulate Minions
+type MinionsAPI interface {
+	GetMinions() (*model.OnmsMinionList, error)
+	GetMinion(id string) (*model.OnmsMinion, error)
+	DeleteMinion(id string) error
+}
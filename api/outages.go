@@ -0,0 +1,8 @@
+package api
+
+import "github.com/OpenNMS/onmsctl/model"
+
+// OutagesAPI the API to obtain outages
+type OutagesAPI interface {
+	GetOutages(fiqlFilter string, limit int) (*model.OnmsOutageList, error)
+}
@@ -0,0 +1,13 @@
+package api
+
+import "github.com/OpenNMS/onmsctl/model"
+
+// ScheduledOutagesAPI the API to manipulate Scheduled Outages
+type ScheduledOutagesAPI interface {
+	GetScheduledOutages() (*model.ScheduledOutageList, error)
+	GetScheduledOutage(name string) (*model.ScheduledOutage, error)
+	SetScheduledOutage(outage model.ScheduledOutage) error
+	DeleteScheduledOutage(name string) error
+	AddToPackage(name string, target string, packageName string) error
+	RemoveFromPackage(name string, target string, packageName string) error
+}
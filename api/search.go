@@ -0,0 +1,10 @@
+package api
+
+import "github.com/OpenNMS/onmsctl/model"
+
+// SearchAPI the API to search entities through the ReST API v2
+type SearchAPI interface {
+	Search(entity string, query model.SearchQuery) (model.SearchList, error)
+	SearchAll(entity string, query model.SearchQuery) (model.SearchList, error)
+	GetProperties(entity string) (*model.SearchPropertyList, error)
+}
@@ -0,0 +1,8 @@
+package api
+
+import "github.com/OpenNMS/onmsctl/model"
+
+// SyslogAPI the API to send syslog messages
+type SyslogAPI interface {
+	SendMessage(address string, protocol string, format string, message model.SyslogMessage) error
+}
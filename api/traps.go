@@ -0,0 +1,8 @@
+package api
+
+import "github.com/OpenNMS/onmsctl/model"
+
+// TrapsAPI the API to send SNMP traps
+type TrapsAPI interface {
+	SendTrap(address string, trap model.Trap) error
+}
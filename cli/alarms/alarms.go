@@ -0,0 +1,259 @@
+package alarms
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/OpenNMS/onmsctl/api"
+	"github.com/OpenNMS/onmsctl/common"
+	"github.com/OpenNMS/onmsctl/model"
+	"github.com/OpenNMS/onmsctl/rest"
+	"github.com/OpenNMS/onmsctl/services"
+	"github.com/urfave/cli"
+)
+
+// CliCommand the CLI command to manage alarms
+var CliCommand = cli.Command{
+	Name:  "alarms",
+	Usage: "Manage alarms",
+	Subcommands: []cli.Command{
+		{
+			Name:   "list",
+			Usage:  "List the most recent alarms",
+			Action: listAlarms,
+			Flags: append(filterFlags(), cli.IntFlag{
+				Name:  "limit, l",
+				Usage: "Maximum number of alarms to display (0 for all)",
+				Value: 10,
+			}),
+		},
+		{
+			Name:      "get",
+			Usage:     "Gets an alarm",
+			ArgsUsage: "<id>",
+			Action:    showAlarm,
+		},
+		actionCommand("ack", "Acknowledges alarms", "ack"),
+		actionCommand("unack", "Unacknowledges alarms", "unack"),
+		actionCommand("escalate", "Escalates alarms", "escalate"),
+		actionCommand("clear", "Clears alarms", "clear"),
+		{
+			Name:  "ticket",
+			Usage: "Manage trouble tickets for alarms",
+			Subcommands: []cli.Command{
+				actionCommand("create", "Creates trouble tickets for alarms", "ticketCreate"),
+				actionCommand("update", "Updates the trouble tickets of alarms", "ticketUpdate"),
+				actionCommand("close", "Closes the trouble tickets of alarms", "ticketClose"),
+			},
+		},
+		{
+			Name:  "memo",
+			Usage: "Manage sticky and journal memos for alarms",
+			Subcommands: []cli.Command{
+				{
+					Name:      "set",
+					Usage:     "Adds or updates a memo on an alarm",
+					ArgsUsage: "<id> <body>",
+					Action:    setMemo,
+					Flags:     memoFlags(),
+				},
+				{
+					Name:      "delete",
+					ShortName: "del",
+					Usage:     "Removes a memo from an alarm",
+					ArgsUsage: "<id>",
+					Action:    deleteMemo,
+					Flags:     memoFlags(),
+				},
+			},
+		},
+	},
+}
+
+// actionCommand builds a command that performs an action on a list of alarms, or on all the alarms matching a filter
+func actionCommand(name string, usage string, action string) cli.Command {
+	return cli.Command{
+		Name:        name,
+		Usage:       usage,
+		ArgsUsage:   "[id ...]",
+		Description: "Either a list of alarm IDs or a filter is required. With a filter, the action applies to every matching alarm.",
+		Flags:       filterFlags(),
+		Action: func(c *cli.Context) error {
+			return updateAlarms(c, action)
+		},
+	}
+}
+
+func filterFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "filter, f",
+			Usage: "The filter to apply in FIQL format",
+		},
+		cli.GenericFlag{
+			Name:  "severity, s",
+			Value: &model.EnumValue{Enum: model.Severities.Enum},
+			Usage: "The severity of the alarms: " + model.Severities.EnumAsString(),
+		},
+		cli.StringFlag{
+			Name:  "node, n",
+			Usage: "The node ID or node label of the alarms",
+		},
+		cli.StringFlag{
+			Name:  "uei",
+			Usage: "The UEI of the alarms",
+		},
+	}
+}
+
+func memoFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.GenericFlag{
+			Name: "type, t",
+			Value: &model.EnumValue{
+				Enum:    model.AlarmMemoTypes.Enum,
+				Default: "sticky",
+			},
+			Usage: "The type of memo: sticky (for the alarm), journal (for all the alarms with the same reduction key)",
+		},
+	}
+}
+
+func listAlarms(c *cli.Context) error {
+	list, err := getAPI().GetAlarms(buildFilter(c), c.Int("limit"))
+	if err != nil {
+		return err
+	}
+	table := &common.Table{
+		Headers:     []string{"ID", "Severity", "Node", "Count", "Last Event", "Ack User"},
+		WideHeaders: []string{"UEI", "Reduction Key", "Ticket", "Log Message"},
+		Empty:       "There are no alarms",
+	}
+	for _, a := range list.Alarms {
+		table.AddRow(a.ID, a.Severity, getNodeLabel(a), a.Count, common.GetDisplayTime(a.LastEventTime), a.AckUser, a.UEI, a.ReductionKey, a.TroubleTicketID, a.LogMessage)
+	}
+	return common.Print(list, table)
+}
+
+func showAlarm(c *cli.Context) error {
+	id, err := parseAlarmID(c.Args().First())
+	if err != nil {
+		return err
+	}
+	alarm, err := getAPI().GetAlarm(id)
+	if err != nil {
+		return err
+	}
+	return common.Print(alarm, nil)
+}
+
+func updateAlarms(c *cli.Context, action string) error {
+	ids, err := getTargetAlarms(c)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		fmt.Println("There are no alarms matching the filter")
+		return nil
+	}
+	failed := 0
+	for _, id := range ids {
+		if err := getAPI().UpdateAlarm(id, action); err != nil {
+			fmt.Printf("Alarm %d: %v\n", id, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%s failed on %d of %d alarms", action, failed, len(ids))
+	}
+	fmt.Printf("%s applied to %d alarms\n", action, len(ids))
+	return nil
+}
+
+func setMemo(c *cli.Context) error {
+	id, err := parseAlarmID(c.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	return getAPI().SetMemo(id, c.String("type"), c.Args().Get(1))
+}
+
+func deleteMemo(c *cli.Context) error {
+	id, err := parseAlarmID(c.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	return getAPI().DeleteMemo(id, c.String("type"))
+}
+
+// getTargetAlarms gets the IDs of the alarms to update, either from the arguments or from the filter
+func getTargetAlarms(c *cli.Context) ([]int, error) {
+	filter := buildFilter(c)
+	if c.NArg() > 0 {
+		if filter != "" {
+			return nil, fmt.Errorf("either a list of alarm IDs or a filter is required, but not both")
+		}
+		ids := make([]int, 0, c.NArg())
+		for _, arg := range c.Args() {
+			id, err := parseAlarmID(arg)
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, id)
+		}
+		return ids, nil
+	}
+	if filter == "" {
+		return nil, fmt.Errorf("either a list of alarm IDs or a filter is required")
+	}
+	list, err := getAPI().GetAlarms(filter, 0)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, len(list.Alarms))
+	for i, a := range list.Alarms {
+		ids[i] = a.ID
+	}
+	return ids, nil
+}
+
+// buildFilter builds a FIQL filter combining the filter flags
+func buildFilter(c *cli.Context) string {
+	filters := []string{c.String("filter")}
+	if severity := c.String("severity"); severity != "" {
+		filters = append(filters, model.FiqlConstraint{Selector: "alarm.severity", Operator: "==", Value: strings.ToUpper(severity)}.String())
+	}
+	if node := c.String("node"); node != "" {
+		filters = append(filters, model.NodeFiqlConstraint(node).String())
+	}
+	if uei := c.String("uei"); uei != "" {
+		filters = append(filters, model.FiqlConstraint{Selector: "alarm.uei", Operator: "==", Value: uei}.String())
+	}
+	return model.AndFiql(filters...)
+}
+
+func parseAlarmID(arg string) (int, error) {
+	if arg == "" {
+		return 0, fmt.Errorf("alarm ID required")
+	}
+	id, err := strconv.Atoi(arg)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid alarm ID %s", arg)
+	}
+	return id, nil
+}
+
+func getNodeLabel(alarm model.OnmsAlarm) string {
+	if alarm.NodeLabel != "" {
+		return alarm.NodeLabel
+	}
+	if alarm.NodeID > 0 {
+		return strconv.Itoa(alarm.NodeID)
+	}
+	return "-"
+}
+
+func getAPI() api.AlarmsAPI {
+	return services.GetAlarmsAPI(rest.Instance)
+}
@@ -0,0 +1,115 @@
+package alarms
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/OpenNMS/onmsctl/rest"
+	"github.com/OpenNMS/onmsctl/test"
+	"gotest.tools/assert"
+)
+
+const mockAlarmsJSON = `{"count":2,"totalCount":2,"offset":0,"alarm":[
+{"id":2,"uei":"uei.opennms.org/nodes/nodeDown","severity":"MAJOR","nodeId":1,"nodeLabel":"srv01","count":1,"lastEventTime":1567526173532},
+{"id":1,"uei":"uei.opennms.org/nodes/nodeDown","severity":"MAJOR","nodeId":1,"nodeLabel":"srv01","count":3}
+]}`
+
+func createMockServer(t *testing.T) (*httptest.Server, *[]string) {
+	var mutex sync.Mutex
+	updates := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		switch req.Method + " " + req.URL.Path {
+		case "GET /api/v2/alarms":
+			if req.URL.Query().Get("_s") == "(alarm.severity==MAJOR);(node.label==srv01)" {
+				res.Write([]byte(mockAlarmsJSON))
+			} else {
+				res.WriteHeader(http.StatusNoContent)
+			}
+		case "GET /api/v2/alarms/1":
+			res.Write([]byte(`{"id":1,"uei":"uei.opennms.org/nodes/nodeDown","severity":"MAJOR"}`))
+		case "PUT /api/v2/alarms/1", "PUT /api/v2/alarms/2", "PUT /rest/alarms/1/memo", "DELETE /rest/alarms/1/memo":
+			data, _ := ioutil.ReadAll(req.Body)
+			mutex.Lock()
+			updates = append(updates, req.URL.Path+" "+string(data))
+			mutex.Unlock()
+		default:
+			res.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	rest.Instance.URL = server.URL
+	return server, &updates
+}
+
+func TestListAlarms(t *testing.T) {
+	app := test.CreateCli(CliCommand)
+	server, _ := createMockServer(t)
+	defer server.Close()
+
+	err := app.Run([]string{app.Name, "alarms", "list", "-s", "Major", "-n", "srv01"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "alarms", "list"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "alarms", "get", "1"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "alarms", "get", "10"})
+	assert.ErrorContains(t, err, "alarm 10 not found")
+}
+
+func TestAlarmActions(t *testing.T) {
+	app := test.CreateCli(CliCommand)
+	server, updates := createMockServer(t)
+	defer server.Close()
+
+	err := app.Run([]string{app.Name, "alarms", "ack"})
+	assert.Error(t, err, "either a list of alarm IDs or a filter is required")
+
+	err = app.Run([]string{app.Name, "alarms", "ack", "-n", "srv01", "1"})
+	assert.ErrorContains(t, err, "but not both")
+
+	err = app.Run([]string{app.Name, "alarms", "ack", "one"})
+	assert.Error(t, err, "invalid alarm ID one")
+
+	err = app.Run([]string{app.Name, "alarms", "ack", "1"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "alarms", "ticket", "create", "-s", "Major", "-n", "srv01"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "alarms", "clear", "-n", "unknown"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "alarms", "escalate", "3"})
+	assert.ErrorContains(t, err, "escalate failed on 1 of 1 alarms")
+
+	assert.DeepEqual(t, []string{
+		"/api/v2/alarms/1 ack=true",
+		"/api/v2/alarms/2 ticketCreate=true",
+		"/api/v2/alarms/1 ticketCreate=true",
+	}, *updates)
+}
+
+func TestAlarmMemos(t *testing.T) {
+	app := test.CreateCli(CliCommand)
+	server, updates := createMockServer(t)
+	defer server.Close()
+
+	err := app.Run([]string{app.Name, "alarms", "memo", "set", "1"})
+	assert.Error(t, err, "memo body required")
+
+	err = app.Run([]string{app.Name, "alarms", "memo", "set", "1", "Working on it"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "alarms", "memo", "delete", "1"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "alarms", "memo", "delete", "-t", "journal", "1"})
+	assert.ErrorContains(t, err, "alarm 1 not found")
+
+	assert.DeepEqual(t, []string{"/rest/alarms/1/memo body=Working+on+it", "/rest/alarms/1/memo "}, *updates)
+}
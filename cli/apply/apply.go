@@ -0,0 +1,292 @@
+package apply
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/OpenNMS/onmsctl/common"
+	"github.com/OpenNMS/onmsctl/model"
+	"github.com/OpenNMS/onmsctl/rest"
+	"github.com/OpenNMS/onmsctl/services"
+	"github.com/urfave/cli"
+)
+
+// The kinds that can be pruned, as the server offers a way to enumerate them
+var prunableKinds = []string{"MonitoringLocation", "Requisition", "ScheduledOutage"}
+
+// CliCommand the CLI command to apply declarative manifests
+var CliCommand = cli.Command{
+	Name:  "apply",
+	Usage: "Creates or updates OpenNMS objects from a directory of YAML manifests",
+	Description: "Creates or updates OpenNMS objects from a directory of YAML manifests\n" +
+		"   Each document must declare a 'kind' (" + model.ManifestKinds.EnumAsString() + ") and a 'spec' with the object.\n" +
+		"   SnmpConfig manifests also require an 'ipAddress' at the root level.",
+	Action: applyManifests,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:     "file, f",
+			Usage:    "Directory with YAML manifests (*.yaml, *.yml), or a single manifest file",
+			Required: true,
+		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Show the execution plan without applying any change",
+		},
+		cli.BoolFlag{
+			Name:  "prune",
+			Usage: "Delete objects from the server missing from the manifests (only for " + strings.Join(prunableKinds, ", ") + ")",
+		},
+	},
+}
+
+// planStep a single action of the execution plan
+type planStep struct {
+	Action   string
+	Manifest model.Manifest
+}
+
+// Name gets the name of the object affected by the step
+func (s planStep) Name() string {
+	return s.Manifest.Name()
+}
+
+func applyManifests(c *cli.Context) error {
+	manifests, err := readManifests(c.String("file"))
+	if err != nil {
+		return err
+	}
+	if len(manifests) == 0 {
+		fmt.Println("There are no manifests to apply")
+		return nil
+	}
+	plan, err := buildPlan(manifests, c.Bool("prune"))
+	if err != nil {
+		return err
+	}
+	printPlan(plan)
+	if c.Bool("dry-run") {
+		return nil
+	}
+	failed := 0
+	for _, step := range plan {
+		if err := executeStep(step); err != nil {
+			fmt.Printf("ERROR: cannot %s %s %s: %v\n", step.Action, step.Manifest.Kind, step.Name(), err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d steps failed", failed, len(plan))
+	}
+	fmt.Printf("%d steps applied successfully\n", len(plan))
+	return nil
+}
+
+func readManifests(path string) ([]model.Manifest, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{path}
+	if info.IsDir() {
+		files = make([]string, 0)
+		entries, err := ioutil.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			ext := strings.ToLower(filepath.Ext(entry.Name()))
+			if !entry.IsDir() && (ext == ".yaml" || ext == ".yml") {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+		sort.Strings(files)
+	}
+	manifests := make([]model.Manifest, 0)
+	names := make(map[string]string)
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		list, err := model.ParseManifests(data, file)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list {
+			m := &list[i]
+			if err := m.Validate(); err != nil {
+				return nil, fmt.Errorf("invalid %s on %s: %s", m.Kind, m.Source, err)
+			}
+			key := m.Kind + "/" + m.Name()
+			if source, ok := names[key]; ok {
+				return nil, fmt.Errorf("%s %s is defined on both %s and %s", m.Kind, m.Name(), source, m.Source)
+			}
+			names[key] = m.Source
+			manifests = append(manifests, *m)
+		}
+	}
+	// Dependencies first; for instance, requisitions must exist before their foreign source definitions
+	sort.SliceStable(manifests, func(i, j int) bool {
+		return kindOrder(manifests[i].Kind) < kindOrder(manifests[j].Kind)
+	})
+	return manifests, nil
+}
+
+func buildPlan(manifests []model.Manifest, prune bool) ([]planStep, error) {
+	declared := make(map[string]map[string]bool)
+	for _, m := range manifests {
+		if declared[m.Kind] == nil {
+			declared[m.Kind] = make(map[string]bool)
+		}
+		declared[m.Kind][m.Name()] = true
+	}
+	existing, err := getExistingObjects(declared)
+	if err != nil {
+		return nil, err
+	}
+	plan := make([]planStep, 0)
+	for _, m := range manifests {
+		action := "create"
+		switch m.Kind {
+		case "ForeignSource":
+			if m.Name() == "default" || existing["Requisition"][m.Name()] {
+				action = "update"
+			}
+		case "SnmpConfig":
+			action = "update"
+		default:
+			if existing[m.Kind][m.Name()] {
+				action = "update"
+			}
+		}
+		plan = append(plan, planStep{Action: action, Manifest: m})
+	}
+	if !prune {
+		return plan, nil
+	}
+	// Only kinds present on the manifests are pruned, in reverse dependency order
+	for i := len(prunableKinds) - 1; i >= 0; i-- {
+		kind := prunableKinds[i]
+		if declared[kind] == nil {
+			continue
+		}
+		names := make([]string, 0)
+		for name := range existing[kind] {
+			if !declared[kind][name] && !(kind == "MonitoringLocation" && name == "Default") {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			plan = append(plan, planStep{Action: "delete", Manifest: newEmptyManifest(kind, name)})
+		}
+	}
+	return plan, nil
+}
+
+func getExistingObjects(declared map[string]map[string]bool) (map[string]map[string]bool, error) {
+	existing := map[string]map[string]bool{
+		"Requisition":        make(map[string]bool),
+		"ScheduledOutage":    make(map[string]bool),
+		"MonitoringLocation": make(map[string]bool),
+	}
+	if declared["Requisition"] != nil || declared["ForeignSource"] != nil {
+		requisitions, err := services.GetProvisioningUtilsAPI(rest.Instance).GetRequisitionNames()
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range requisitions.ForeignSources {
+			existing["Requisition"][name] = true
+		}
+	}
+	if declared["ScheduledOutage"] != nil {
+		outages, err := services.GetScheduledOutagesAPI(rest.Instance).GetScheduledOutages()
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range outages.Outages {
+			existing["ScheduledOutage"][o.Name] = true
+		}
+	}
+	if declared["MonitoringLocation"] != nil {
+		locations, err := services.GetMonitoringLocationsAPI(rest.Instance).GetLocations()
+		if err != nil {
+			return nil, err
+		}
+		for _, loc := range locations.Locations {
+			existing["MonitoringLocation"][loc.LocationName] = true
+		}
+	}
+	return existing, nil
+}
+
+func executeStep(step planStep) error {
+	m := step.Manifest
+	if step.Action == "delete" {
+		switch m.Kind {
+		case "Requisition":
+			return services.GetRequisitionsAPI(rest.Instance).DeleteRequisition(m.Name())
+		case "ScheduledOutage":
+			return services.GetScheduledOutagesAPI(rest.Instance).DeleteScheduledOutage(m.Name())
+		case "MonitoringLocation":
+			return services.GetMonitoringLocationsAPI(rest.Instance).DeleteLocation(m.Name())
+		}
+		return fmt.Errorf("%s cannot be deleted", m.Kind)
+	}
+	switch m.Kind {
+	case "Requisition":
+		return services.GetRequisitionsAPI(rest.Instance).SetRequisition(*m.Requisition)
+	case "ForeignSource":
+		fsAPI := services.GetForeignSourcesAPI(rest.Instance)
+		if err := fsAPI.IsForeignSourceValid(*m.ForeignSource); err != nil {
+			return err
+		}
+		return fsAPI.SetForeignSourceDef(*m.ForeignSource)
+	case "SnmpConfig":
+		return services.GetSnmpAPI(rest.Instance).SetConfig(m.IPAddress, *m.SnmpConfig)
+	case "ScheduledOutage":
+		return services.GetScheduledOutagesAPI(rest.Instance).SetScheduledOutage(*m.ScheduledOutage)
+	case "MonitoringLocation":
+		return services.GetMonitoringLocationsAPI(rest.Instance).SetLocation(*m.MonitoringLocation)
+	}
+	return fmt.Errorf("invalid kind %s", m.Kind)
+}
+
+func printPlan(plan []planStep) {
+	writer := common.NewTableWriter()
+	fmt.Fprintln(writer, "Action\tKind\tName\tSource")
+	for _, step := range plan {
+		source := step.Manifest.Source
+		if source == "" {
+			source = "(server)"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", step.Action, step.Manifest.Kind, step.Name(), source)
+	}
+	writer.Flush()
+}
+
+func newEmptyManifest(kind string, name string) model.Manifest {
+	m := model.Manifest{Kind: kind}
+	switch kind {
+	case "Requisition":
+		m.Requisition = &model.Requisition{Name: name}
+	case "ScheduledOutage":
+		m.ScheduledOutage = &model.ScheduledOutage{Name: name}
+	case "MonitoringLocation":
+		m.MonitoringLocation = &model.MonitoringLocation{LocationName: name}
+	}
+	return m
+}
+
+func kindOrder(kind string) int {
+	for i, k := range model.ManifestKinds.Enum {
+		if k == kind {
+			return i
+		}
+	}
+	return len(model.ManifestKinds.Enum)
+}
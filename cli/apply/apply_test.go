@@ -0,0 +1,214 @@
+package apply
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/OpenNMS/onmsctl/model"
+	"github.com/OpenNMS/onmsctl/rest"
+	"github.com/OpenNMS/onmsctl/test"
+	"gotest.tools/assert"
+)
+
+var testManifests = map[string]string{
+	"requisition.yaml": `
+kind: Requisition
+spec:
+  name: Routers
+  nodes:
+  - foreignID: r1
+    interfaces:
+    - ipAddress: 10.0.0.1
+---
+kind: ForeignSource
+spec:
+  name: Routers
+  scanInterval: 1d
+`,
+	"snmp.yml": `
+kind: SnmpConfig
+ipAddress: 10.0.0.1
+spec:
+  version: v2c
+  community: public
+`,
+	"outages.yaml": `
+kind: ScheduledOutage
+spec:
+  name: Weekend
+  type: weekly
+  times:
+  - day: saturday
+    begins: "00:00:00"
+    ends: "23:59:59"
+`,
+	"locations.yaml": `
+kind: MonitoringLocation
+spec:
+  name: Apex
+`,
+	"README.md": "Ignored",
+}
+
+type requestLog struct {
+	sync.Mutex
+	requests []string
+}
+
+func (l *requestLog) add(req *http.Request) {
+	l.Lock()
+	defer l.Unlock()
+	l.requests = append(l.requests, req.Method+" "+req.URL.Path)
+}
+
+func (l *requestLog) contains(request string) bool {
+	for _, r := range l.requests {
+		if r == request {
+			return true
+		}
+	}
+	return false
+}
+
+func createTestServer(t *testing.T, log *requestLog) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		log.add(req)
+		switch req.URL.Path {
+		case "/rest/requisitionNames":
+			sendData(res, model.RequisitionsList{Count: 2, ForeignSources: []string{"Routers", "Old"}})
+		case "/rest/foreignSourcesConfig/policies":
+			res.Write([]byte(test.PoliciesJSON))
+		case "/rest/foreignSourcesConfig/detectors":
+			res.Write([]byte(test.DetectorsJSON))
+		case "/rest/sched-outages":
+			if req.Method == http.MethodGet {
+				res.Write([]byte(`{"outage":[{"name":"Weekend","type":"weekly"},{"name":"Old Outage","type":"daily"}]}`))
+			}
+		case "/api/v2/monitoringLocations":
+			if req.Method == http.MethodGet {
+				sendData(res, model.MonitoringLocationList{
+					Count:     2,
+					Locations: []model.MonitoringLocation{{LocationName: "Default"}, {LocationName: "Cary"}},
+				})
+			}
+		case "/rest/requisitions", "/rest/foreignSources", "/rest/snmpConfig/10.0.0.1",
+			"/rest/requisitions/Old/import", "/rest/requisitions/deployed/Old", "/rest/requisitions/Old",
+			"/rest/foreignSources/deployed/Old", "/rest/foreignSources/Old",
+			"/rest/sched-outages/Old Outage", "/api/v2/monitoringLocations/Cary":
+			res.WriteHeader(http.StatusOK)
+		default:
+			res.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	rest.Instance.URL = server.URL
+	return server
+}
+
+func createManifestsDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "onmsctl-apply-")
+	assert.NilError(t, err)
+	for name, content := range testManifests {
+		err = ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+		assert.NilError(t, err)
+	}
+	return dir
+}
+
+func sendData(res http.ResponseWriter, data interface{}) {
+	bytes, _ := json.Marshal(data)
+	res.WriteHeader(http.StatusOK)
+	res.Write(bytes)
+}
+
+func TestReadManifests(t *testing.T) {
+	dir := createManifestsDir(t)
+	defer os.RemoveAll(dir)
+
+	manifests, err := readManifests(dir)
+	assert.NilError(t, err)
+	assert.Equal(t, 5, len(manifests))
+	kinds := make([]string, 0)
+	for _, m := range manifests {
+		kinds = append(kinds, m.Kind)
+	}
+	assert.DeepEqual(t, []string{"MonitoringLocation", "Requisition", "ForeignSource", "SnmpConfig", "ScheduledOutage"}, kinds)
+
+	ioutil.WriteFile(filepath.Join(dir, "duplicate.yaml"), []byte(testManifests["locations.yaml"]), 0644)
+	_, err = readManifests(dir)
+	assert.ErrorContains(t, err, "MonitoringLocation Apex is defined on both")
+}
+
+func TestApplyDryRun(t *testing.T) {
+	dir := createManifestsDir(t)
+	defer os.RemoveAll(dir)
+	log := &requestLog{}
+	server := createTestServer(t, log)
+	defer server.Close()
+
+	app := test.CreateCli(CliCommand)
+	err := app.Run([]string{app.Name, "apply", "--dry-run", "--prune", "-f", dir})
+	assert.NilError(t, err)
+	for _, r := range log.requests {
+		assert.Assert(t, r[:4] == "GET ", "unexpected request on dry-run: %s", r)
+	}
+}
+
+func TestApplyWithPrune(t *testing.T) {
+	dir := createManifestsDir(t)
+	defer os.RemoveAll(dir)
+	log := &requestLog{}
+	server := createTestServer(t, log)
+	defer server.Close()
+
+	manifests, err := readManifests(dir)
+	assert.NilError(t, err)
+	plan, err := buildPlan(manifests, true)
+	assert.NilError(t, err)
+	actions := make([]string, 0)
+	for _, step := range plan {
+		actions = append(actions, step.Action+" "+step.Manifest.Kind+" "+step.Name())
+	}
+	assert.DeepEqual(t, []string{
+		"create MonitoringLocation Apex",
+		"update Requisition Routers",
+		"update ForeignSource Routers",
+		"update SnmpConfig 10.0.0.1",
+		"update ScheduledOutage Weekend",
+		"delete ScheduledOutage Old Outage",
+		"delete Requisition Old",
+		"delete MonitoringLocation Cary",
+	}, actions)
+
+	app := test.CreateCli(CliCommand)
+	err = app.Run([]string{app.Name, "apply", "--prune", "-f", dir})
+	assert.NilError(t, err)
+	assert.Assert(t, log.contains("POST /api/v2/monitoringLocations"))
+	assert.Assert(t, log.contains("POST /rest/requisitions"))
+	assert.Assert(t, log.contains("POST /rest/foreignSources"))
+	assert.Assert(t, log.contains("PUT /rest/snmpConfig/10.0.0.1"))
+	assert.Assert(t, log.contains("POST /rest/sched-outages"))
+	assert.Assert(t, log.contains("DELETE /rest/sched-outages/Old Outage"))
+	assert.Assert(t, log.contains("DELETE /rest/requisitions/Old"))
+	assert.Assert(t, log.contains("DELETE /api/v2/monitoringLocations/Cary"))
+	assert.Assert(t, !log.contains("DELETE /api/v2/monitoringLocations/Default"))
+}
+
+func TestApplyWithoutPrune(t *testing.T) {
+	dir := createManifestsDir(t)
+	defer os.RemoveAll(dir)
+	log := &requestLog{}
+	server := createTestServer(t, log)
+	defer server.Close()
+
+	app := test.CreateCli(CliCommand)
+	err := app.Run([]string{app.Name, "apply", "-f", filepath.Join(dir, "outages.yaml")})
+	assert.NilError(t, err)
+	assert.Assert(t, log.contains("POST /rest/sched-outages"))
+	assert.Assert(t, !log.contains("DELETE /rest/sched-outages/Old Outage"))
+}
@@ -0,0 +1,300 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/OpenNMS/onmsctl/api"
+	"github.com/OpenNMS/onmsctl/common"
+	"github.com/OpenNMS/onmsctl/model"
+	"github.com/OpenNMS/onmsctl/rest"
+	"github.com/OpenNMS/onmsctl/services"
+	"github.com/urfave/cli"
+)
+
+// Content of the tarball; objects are stored in JSON, the same format the ReST API uses
+const (
+	manifestFile      = "manifest.json"
+	requisitionsDir   = "requisitions/"
+	foreignSourcesDir = "foreign-sources/"
+)
+
+// CliCommand the CLI command to backup the provisioning configuration
+var CliCommand = cli.Command{
+	Name:   "backup",
+	Usage:  "Saves all the requisitions and foreign source definitions into a tarball",
+	Action: backupProvisioning,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "file, f",
+			Usage: "Target file (defaults to onmsctl-backup-<timestamp>.tar.gz)",
+		},
+	},
+}
+
+// RestoreCliCommand the CLI command to restore the provisioning configuration
+var RestoreCliCommand = cli.Command{
+	Name:   "restore",
+	Usage:  "Restores all the requisitions and foreign source definitions from a tarball created with backup",
+	Action: restoreProvisioning,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:     "file, f",
+			Usage:    "Tarball created with the backup command",
+			Required: true,
+		},
+		cli.BoolFlag{
+			Name:  "import, i",
+			Usage: "Import the requisitions after restoring them",
+		},
+		cli.GenericFlag{
+			Name: "rescanExisting, r",
+			Value: &model.EnumValue{
+				Enum:    []string{"true", "false", "dbonly"},
+				Default: "true",
+			},
+			Usage: "The rescanExisting option when importing requisitions: true, false, dbonly",
+		},
+	},
+}
+
+// backupContent the parsed content of a backup tarball
+type backupContent struct {
+	manifest       model.BackupManifest
+	requisitions   map[string]*model.Requisition
+	foreignSources map[string]*model.ForeignSourceDef
+}
+
+// restoreResult the outcome of restoring a single object
+type restoreResult struct {
+	kind string
+	name string
+	err  error
+}
+
+func backupProvisioning(c *cli.Context) error {
+	file := c.String("file")
+	if file == "" {
+		file = fmt.Sprintf("onmsctl-backup-%s.tar.gz", time.Now().Format("20060102-150405"))
+	}
+	info, err := services.GetInfoAPI(rest.Instance).GetInfo()
+	if err != nil {
+		return err
+	}
+	list, err := services.GetProvisioningUtilsAPI(rest.Instance).GetRequisitionNames()
+	if err != nil {
+		return err
+	}
+	content := &backupContent{
+		manifest: model.BackupManifest{
+			CreatedAt:    time.Now().Format(time.RFC3339),
+			ServerURL:    rest.Instance.URL,
+			ServerInfo:   info,
+			Requisitions: list.ForeignSources,
+		},
+		requisitions:   make(map[string]*model.Requisition),
+		foreignSources: make(map[string]*model.ForeignSourceDef),
+	}
+	reqAPI := services.GetRequisitionsAPI(rest.Instance)
+	fsAPI := services.GetForeignSourcesAPI(rest.Instance)
+	for _, name := range content.manifest.Requisitions {
+		if content.requisitions[name], err = reqAPI.GetRequisition(name); err != nil {
+			return fmt.Errorf("cannot get requisition %s: %v", name, err)
+		}
+		if content.foreignSources[name], err = fsAPI.GetForeignSourceDef(name); err != nil {
+			return fmt.Errorf("cannot get foreign source definition %s: %v", name, err)
+		}
+	}
+	if content.foreignSources["default"], err = fsAPI.GetForeignSourceDef("default"); err != nil {
+		return fmt.Errorf("cannot get the default foreign source definition: %v", err)
+	}
+	if err := writeBackup(file, content); err != nil {
+		os.Remove(file)
+		return err
+	}
+	fmt.Printf("Backup of %d requisitions from %s %s saved on %s\n", len(content.requisitions), info.PackageDescription, info.DisplayVersion, file)
+	return nil
+}
+
+func restoreProvisioning(c *cli.Context) error {
+	content, err := readBackup(c.String("file"))
+	if err != nil {
+		return err
+	}
+	manifest := content.manifest
+	if manifest.ServerInfo != nil {
+		fmt.Printf("Restoring backup from %s (%s %s) created at %s\n", manifest.ServerURL, manifest.ServerInfo.PackageDescription, manifest.ServerInfo.DisplayVersion, manifest.CreatedAt)
+	}
+	reqAPI := services.GetRequisitionsAPI(rest.Instance)
+	fsAPI := services.GetForeignSourcesAPI(rest.Instance)
+	results := make([]restoreResult, 0)
+	restored := make([]string, 0)
+	// Requisitions must exist before their foreign source definitions
+	for _, name := range manifest.Requisitions {
+		result := restoreResult{kind: "Requisition", name: name}
+		if req, ok := content.requisitions[name]; ok {
+			result.err = reqAPI.SetRequisition(*req)
+		} else {
+			result.err = fmt.Errorf("missing from backup")
+		}
+		results = append(results, result)
+		if fs, ok := content.foreignSources[name]; ok {
+			if result.err == nil {
+				results = append(results, restoreForeignSource(fsAPI, fs))
+			} else {
+				results = append(results, restoreResult{kind: "ForeignSource", name: name, err: fmt.Errorf("skipped, requisition not restored")})
+			}
+		}
+		if result.err == nil {
+			restored = append(restored, name)
+		}
+	}
+	if fs, ok := content.foreignSources["default"]; ok {
+		results = append(results, restoreForeignSource(fsAPI, fs))
+	}
+	if c.Bool("import") {
+		for _, name := range restored {
+			results = append(results, restoreResult{kind: "Import", name: name, err: reqAPI.ImportRequisition(name, c.String("rescanExisting"))})
+		}
+	}
+	return printSummary(results)
+}
+
+func restoreForeignSource(fsAPI api.ForeignSourcesAPI, fs *model.ForeignSourceDef) restoreResult {
+	result := restoreResult{kind: "ForeignSource", name: fs.Name}
+	if result.err = fsAPI.IsForeignSourceValid(*fs); result.err == nil {
+		result.err = fsAPI.SetForeignSourceDef(*fs)
+	}
+	return result
+}
+
+func printSummary(results []restoreResult) error {
+	failed := 0
+	writer := common.NewTableWriter()
+	fmt.Fprintln(writer, "Kind\tName\tStatus")
+	for _, r := range results {
+		status := "OK"
+		if r.err != nil {
+			status = "ERROR: " + r.err.Error()
+			failed++
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", r.kind, r.name, status)
+	}
+	writer.Flush()
+	if failed > 0 {
+		return fmt.Errorf("%d of %d objects failed to restore", failed, len(results))
+	}
+	return nil
+}
+
+func writeBackup(file string, content *backupContent) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	if err := addToArchive(tw, manifestFile, content.manifest); err != nil {
+		return err
+	}
+	for _, name := range content.manifest.Requisitions {
+		// Names are escaped, as a requisition name can contain a slash
+		entry := url.PathEscape(name) + ".json"
+		if err := addToArchive(tw, requisitionsDir+entry, content.requisitions[name]); err != nil {
+			return err
+		}
+		if err := addToArchive(tw, foreignSourcesDir+entry, content.foreignSources[name]); err != nil {
+			return err
+		}
+	}
+	if err := addToArchive(tw, foreignSourcesDir+"default.json", content.foreignSources["default"]); err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func addToArchive(tw *tar.Writer, name string, object interface{}) error {
+	data, err := json.MarshalIndent(object, "", "  ")
+	if err != nil {
+		return err
+	}
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = tw.Write(data)
+	return err
+}
+
+func readBackup(file string) (*backupContent, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("invalid backup file %s: %v", file, err)
+	}
+	content := &backupContent{
+		requisitions:   make(map[string]*model.Requisition),
+		foreignSources: make(map[string]*model.ForeignSourceDef),
+	}
+	hasManifest := false
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid backup file %s: %v", file, err)
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		name, err := url.PathUnescape(strings.TrimSuffix(path.Base(header.Name), ".json"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid entry %s on backup file %s: %v", header.Name, file, err)
+		}
+		switch {
+		case header.Name == manifestFile:
+			hasManifest = true
+			err = json.Unmarshal(data, &content.manifest)
+		case strings.HasPrefix(header.Name, requisitionsDir):
+			req := &model.Requisition{}
+			content.requisitions[name] = req
+			err = json.Unmarshal(data, req)
+		case strings.HasPrefix(header.Name, foreignSourcesDir):
+			fs := &model.ForeignSourceDef{}
+			content.foreignSources[name] = fs
+			err = json.Unmarshal(data, fs)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse %s: %v", header.Name, err)
+		}
+	}
+	if !hasManifest {
+		return nil, fmt.Errorf("invalid backup file %s: %s not found", file, manifestFile)
+	}
+	return content, content.manifest.Validate()
+}
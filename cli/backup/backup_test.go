@@ -0,0 +1,149 @@
+package backup
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/OpenNMS/onmsctl/model"
+	"github.com/OpenNMS/onmsctl/rest"
+	"github.com/OpenNMS/onmsctl/test"
+	"gotest.tools/assert"
+)
+
+var testRequisition = model.Requisition{
+	Name: "Routers",
+	Nodes: []model.RequisitionNode{
+		{
+			ForeignID: "r1",
+			NodeLabel: "r1",
+			Interfaces: []model.RequisitionInterface{
+				{IPAddress: "10.0.0.1", SnmpPrimary: "P", Status: 1},
+			},
+		},
+	},
+}
+
+type requestLog struct {
+	sync.Mutex
+	requests []string
+}
+
+func (l *requestLog) add(req *http.Request) {
+	l.Lock()
+	defer l.Unlock()
+	l.requests = append(l.requests, req.Method+" "+req.URL.Path)
+}
+
+func (l *requestLog) contains(request string) bool {
+	for _, r := range l.requests {
+		if r == request {
+			return true
+		}
+	}
+	return false
+}
+
+func createTestServer(t *testing.T, log *requestLog) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		log.add(req)
+		switch req.URL.Path {
+		case "/rest/info":
+			sendData(res, model.OnmsInfo{DisplayVersion: "28.1.1", Version: "28.1.1", PackageName: "opennms", PackageDescription: "OpenNMS"})
+		case "/rest/requisitionNames":
+			sendData(res, model.RequisitionsList{Count: 2, ForeignSources: []string{"Routers", "Broken"}})
+		case "/rest/requisitions/Routers", "/rest/requisitions/Broken":
+			r := testRequisition
+			r.Name = filepath.Base(req.URL.Path)
+			sendData(res, r)
+		case "/rest/foreignSources/Routers", "/rest/foreignSources/Broken", "/rest/foreignSources/default":
+			sendData(res, model.ForeignSourceDef{Name: filepath.Base(req.URL.Path), ScanInterval: "1d"})
+		case "/rest/requisitions":
+			var r model.Requisition
+			bytes, _ := ioutil.ReadAll(req.Body)
+			json.Unmarshal(bytes, &r)
+			if r.Name == "Broken" {
+				res.WriteHeader(http.StatusInternalServerError)
+			}
+		case "/rest/foreignSources", "/rest/requisitions/Routers/import":
+			res.WriteHeader(http.StatusOK)
+		default:
+			res.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	rest.Instance.URL = server.URL
+	return server
+}
+
+func sendData(res http.ResponseWriter, data interface{}) {
+	bytes, _ := json.Marshal(data)
+	res.WriteHeader(http.StatusOK)
+	res.Write(bytes)
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "onmsctl-backup-")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "backup.tar.gz")
+	log := &requestLog{}
+	server := createTestServer(t, log)
+	defer server.Close()
+
+	app := test.CreateCli(CliCommand)
+	err = app.Run([]string{app.Name, "backup", "-f", file})
+	assert.NilError(t, err)
+
+	content, err := readBackup(file)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []string{"Routers", "Broken"}, content.manifest.Requisitions)
+	assert.Equal(t, "28.1.1", content.manifest.ServerInfo.Version)
+	assert.Equal(t, 2, len(content.requisitions))
+	assert.Equal(t, 3, len(content.foreignSources))
+	assert.Equal(t, "r1", content.requisitions["Routers"].Nodes[0].ForeignID)
+	assert.Equal(t, "default", content.foreignSources["default"].Name)
+
+	app = test.CreateCli(RestoreCliCommand)
+	err = app.Run([]string{app.Name, "restore", "--import", "-f", file})
+	assert.Error(t, err, "2 of 6 objects failed to restore")
+	assert.Assert(t, log.contains("POST /rest/foreignSources"))
+	assert.Assert(t, log.contains("PUT /rest/requisitions/Routers/import"))
+	assert.Assert(t, !log.contains("PUT /rest/requisitions/Broken/import"))
+}
+
+func TestBackupEscapedNames(t *testing.T) {
+	dir, err := ioutil.TempDir("", "onmsctl-backup-")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "backup.tar.gz")
+
+	name := "Sites/Remote 50%"
+	content := &backupContent{
+		manifest:       model.BackupManifest{Requisitions: []string{name}},
+		requisitions:   map[string]*model.Requisition{name: {Name: name}},
+		foreignSources: map[string]*model.ForeignSourceDef{name: {Name: name}, "default": {Name: "default"}},
+	}
+	assert.NilError(t, writeBackup(file, content))
+
+	restored, err := readBackup(file)
+	assert.NilError(t, err)
+	assert.Equal(t, name, restored.requisitions[name].Name)
+	assert.Equal(t, name, restored.foreignSources[name].Name)
+}
+
+func TestRestoreInvalidFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "onmsctl-backup-")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "backup.tar.gz")
+	ioutil.WriteFile(file, []byte("not a tarball"), 0644)
+
+	app := test.CreateCli(RestoreCliCommand)
+	err = app.Run([]string{app.Name, "restore", "-f", file})
+	assert.ErrorContains(t, err, "invalid backup file")
+}
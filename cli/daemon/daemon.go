@@ -1,10 +1,17 @@
 package daemon
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/OpenNMS/onmsctl/api"
+	"github.com/OpenNMS/onmsctl/common"
 	"github.com/OpenNMS/onmsctl/model"
 	"github.com/OpenNMS/onmsctl/rest"
 	"github.com/OpenNMS/onmsctl/services"
@@ -14,7 +21,13 @@ import (
 // The prefix for correlation engines
 const correlatorPrefix = "correlation"
 
-// A map with reloadable daemons
+// The UEIs of the events sent by OpenNMS after reloading the configuration of a daemon
+const (
+	reloadSuccessfulUEI = "uei.opennms.org/internal/reloadDaemonConfigSuccessful"
+	reloadFailedUEI     = "uei.opennms.org/internal/reloadDaemonConfigFailed"
+)
+
+// A map with reloadable daemons, used when the server cannot report its daemons
 var daemonMap = map[string]string{
 	"ackd":                               "Ackd",
 	"alarmd":                             "alarmd",
@@ -63,11 +76,25 @@ var CliCommand = cli.Command{
 					Name:  "configFile, f",
 					Usage: "Configuration File (used by a few daemons)",
 				},
+				cli.BoolFlag{
+					Name:  "wait, w",
+					Usage: "Wait for the event that confirms if the reload succeeded or failed",
+				},
+				cli.DurationFlag{
+					Name:  "timeout, t",
+					Usage: "Maximum time to wait for the confirmation",
+					Value: time.Minute,
+				},
+				cli.DurationFlag{
+					Name:  "interval, i",
+					Usage: "Time between polls while waiting for the confirmation",
+					Value: 2 * time.Second,
+				},
 			},
 		},
 		{
 			Name:   "list",
-			Usage:  "Show a list of reloadable daemons",
+			Usage:  "Show a list of reloadable daemons and their status",
 			Action: showReloadableDaemons,
 		},
 	},
@@ -77,41 +104,222 @@ func reloadDaemon(c *cli.Context) error {
 	if !c.Args().Present() {
 		return fmt.Errorf("daemon name required")
 	}
-	daemonName := c.Args().First()
-	if !isValidDaemon(daemonName) {
-		return fmt.Errorf("invalid daemon name %s", daemonName)
+	daemonName, err := resolveDaemonName(c.Args().First())
+	if err != nil {
+		return err
 	}
 	event := model.Event{
 		UEI:    "uei.opennms.org/internal/reloadDaemonConfig",
 		Source: "onmsctl",
 	}
-	event.AddParameter("daemonName", getDaemonName(daemonName))
+	event.AddParameter("daemonName", daemonName)
 	configFile := c.String("configFile")
 	if configFile != "" {
 		event.AddParameter("configFile", configFile)
 	}
-	return services.GetEventsAPI(rest.Instance).SendEvent(event)
+	if !c.Bool("wait") {
+		return getEventsAPI().SendEvent(event)
+	}
+	interval := c.Duration("interval")
+	if interval <= 0 {
+		return fmt.Errorf("interval must be greater than 0")
+	}
+	// The most recent event before the reload, to ignore old confirmations
+	list, err := getEventsAPI().GetEvents("", 1)
+	if err != nil {
+		return err
+	}
+	lastID := 0
+	if len(list.Events) > 0 {
+		lastID = list.Events[0].ID
+	}
+	if err := getEventsAPI().SendEvent(event); err != nil {
+		return err
+	}
+	return waitForReload(daemonName, lastID, interval, c.Duration("timeout"))
+}
+
+// waitForReload polls the events newer than a given ID, until finding the confirmation for a given daemon, or the timeout expires
+func waitForReload(daemonName string, lastID int, interval time.Duration, timeout time.Duration) error {
+	succeeded := model.FiqlConstraint{Selector: "eventUei", Operator: "==", Value: reloadSuccessfulUEI}
+	failed := model.FiqlConstraint{Selector: "eventUei", Operator: "==", Value: reloadFailedUEI}
+	newer := model.FiqlConstraint{Selector: "id", Operator: "=gt=", Value: strconv.Itoa(lastID)}
+	filter := model.AndFiql(succeeded.String()+","+failed.String(), newer.String())
+	deadline := time.After(timeout)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-deadline:
+			return fmt.Errorf("timeout waiting for the reload confirmation of %s", daemonName)
+		case <-ticker.C:
+			list, err := getEventsAPI().GetEvents(filter, 0)
+			if err != nil {
+				return err
+			}
+			// Events are sorted by ID in descending order, so the oldest confirmation is processed first
+			for i := len(list.Events) - 1; i >= 0; i-- {
+				e := list.Events[i]
+				if !strings.EqualFold(e.GetParameter("daemonName"), daemonName) {
+					continue
+				}
+				if e.UEI == reloadFailedUEI {
+					return fmt.Errorf("reload of %s failed: %s", daemonName, e.GetParameter("reason"))
+				}
+				fmt.Printf("Reload of %s succeeded\n", daemonName)
+				return nil
+			}
+		}
+	}
+}
+
+func getEventsAPI() api.EventsAPI {
+	return services.GetEventsAPI(rest.Instance)
 }
 
 func reloadBashComplete(c *cli.Context) {
 	if c.NArg() > 0 {
 		return
 	}
-	for k := range daemonMap {
-		fmt.Println(k)
+	daemons, err := getReloadableDaemons()
+	if err != nil {
+		daemons = getStaticDaemons()
+	}
+	for _, d := range daemons {
+		fmt.Println(d.ID)
 	}
 }
 
 func showReloadableDaemons(c *cli.Context) error {
-	keys := make([]string, 0, len(daemonMap))
-	for k := range daemonMap {
-		keys = append(keys, k)
+	daemons, err := getReloadableDaemons()
+	if err != nil {
+		return err
+	}
+	table := &common.Table{
+		Headers: []string{"ID", "Daemon Name", "Status"},
+		Empty:   "There are no reloadable daemons",
+	}
+	for _, d := range daemons {
+		table.AddRow(d.ID, d.Name, d.Status)
+	}
+	return common.Print(daemons, table)
+}
+
+// reloadableDaemon a daemon that can be reloaded, identified by the name used on the CLI
+type reloadableDaemon struct {
+	ID     string `json:"id" yaml:"id"`
+	Name   string `json:"name" yaml:"name"`
+	Status string `json:"status" yaml:"status"`
+}
+
+// getReloadableDaemons gets the reloadable daemons from the server, or from the static map when the server doesn't expose them
+// The status is the runtime status reported by the server when available; otherwise, it is based on the enabled flag and the health of the server.
+func getReloadableDaemons() ([]reloadableDaemon, error) {
+	daemonsAPI := services.GetDaemonsAPI(rest.Instance)
+	list, err := daemonsAPI.GetDaemons()
+	if err != nil {
+		if isUnsupported(err) {
+			return getStaticDaemons(), nil
+		}
+		return nil, err
 	}
-	sort.Strings(keys)
-	for _, k := range keys {
-		fmt.Println(k)
+	healthy := true
+	health, err := daemonsAPI.GetHealth()
+	if err == nil {
+		healthy = health.Healthy
+	} else if !isUnsupported(err) {
+		return nil, err
+	}
+	hasStatus := true // Whether the server exposes the runtime status of the daemons
+	daemons := make([]reloadableDaemon, 0, len(list))
+	for _, d := range list {
+		// Each correlation engine is reloaded independently, so they are always listed
+		if !d.Reloadable && !isCorrelationEngine(d.Name) {
+			continue
+		}
+		status := d.Status
+		if status == "" && d.Enabled && hasStatus {
+			s, err := daemonsAPI.GetDaemonStatus(d.Name)
+			switch {
+			case err == nil:
+				status = strings.ToLower(s.Status)
+			case isUnsupported(err):
+				hasStatus = false
+			default:
+				return nil, err
+			}
+		}
+		if status == "" {
+			status = "disabled"
+			if d.Enabled {
+				status = "enabled"
+				if !healthy {
+					status = "enabled (server unhealthy)"
+				}
+			}
+		}
+		daemons = append(daemons, reloadableDaemon{ID: getDaemonID(d.Name), Name: d.Name, Status: status})
+	}
+	sortDaemons(daemons)
+	return daemons, nil
+}
+
+// isUnsupported verifies if an error means that the server doesn't implement an endpoint
+// Old servers return 404, 405 or 501, or a page that is not JSON (e.x. the login page of the Web UI).
+func isUnsupported(err error) bool {
+	var syntaxErr *json.SyntaxError
+	return rest.HasStatus(err, http.StatusNotFound) || rest.HasStatus(err, http.StatusMethodNotAllowed) ||
+		rest.HasStatus(err, http.StatusNotImplemented) || errors.As(err, &syntaxErr)
+}
+
+func isCorrelationEngine(name string) bool {
+	return strings.HasPrefix(name, daemonMap[correlatorPrefix])
+}
+
+func getStaticDaemons() []reloadableDaemon {
+	daemons := make([]reloadableDaemon, 0, len(daemonMap))
+	for k, v := range daemonMap {
+		daemons = append(daemons, reloadableDaemon{ID: k, Name: v, Status: "unknown"})
+	}
+	sortDaemons(daemons)
+	return daemons
+}
+
+func sortDaemons(daemons []reloadableDaemon) {
+	sort.Slice(daemons, func(i, j int) bool {
+		return daemons[i].ID < daemons[j].ID
+	})
+}
+
+// getDaemonID gets the name used on the CLI for a daemon reported by the server
+func getDaemonID(name string) string {
+	if isCorrelationEngine(name) {
+		return correlatorPrefix + strings.TrimPrefix(name, daemonMap[correlatorPrefix])
+	}
+	for k, v := range daemonMap {
+		if strings.EqualFold(v, name) {
+			return k
+		}
+	}
+	return strings.ToLower(name)
+}
+
+// resolveDaemonName gets the name of a daemon expected by the reload event
+// Known daemons are resolved without contacting the server; otherwise, the daemons reported by the server are verified.
+func resolveDaemonName(id string) (string, error) {
+	if isValidDaemon(id) {
+		return getDaemonName(id), nil
+	}
+	daemons, err := getReloadableDaemons()
+	if err != nil {
+		return "", err
+	}
+	for _, d := range daemons {
+		if strings.EqualFold(d.ID, id) || strings.EqualFold(d.Name, id) {
+			return d.Name, nil
+		}
 	}
-	return nil
+	return "", fmt.Errorf("invalid daemon name %s", id)
 }
 
 func isValidDaemon(daemonName string) bool {
@@ -125,12 +333,12 @@ func isValidDaemon(daemonName string) bool {
 }
 
 func getDaemonName(id string) string {
-	if strings.HasPrefix(id, correlatorPrefix) {
+	if strings.HasPrefix(strings.ToLower(id), correlatorPrefix) {
 		data := strings.Split(id, ":")
 		if len(data) == 2 {
 			return daemonMap[correlatorPrefix] + ":" + data[1]
 		}
 		return daemonMap[correlatorPrefix]
 	}
-	return daemonMap[id]
+	return daemonMap[strings.ToLower(id)]
 }
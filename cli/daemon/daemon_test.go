@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/OpenNMS/onmsctl/model"
@@ -34,18 +35,126 @@ func TestDaemonMap(t *testing.T) {
 	assert.Equal(t, "EmailNBI", getDaemonName("nbi-email"))
 	assert.Equal(t, "DroolsCorrelationEngine:MyEngine", getDaemonName("correlation:MyEngine"))
 	assert.Equal(t, "DroolsCorrelationEngine", getDaemonName("correlation"))
+	assert.Equal(t, "Pollerd", getDaemonName("PollerD"))
+
+	assert.Equal(t, "pollerd", getDaemonID("Pollerd"))
+	assert.Equal(t, "nbi-email", getDaemonID("EmailNBI"))
+	assert.Equal(t, "correlation:MyEngine", getDaemonID("DroolsCorrelationEngine:MyEngine"))
 }
 
-func TestListDaemon(t *testing.T) {
+// createDaemonsMockServer simulates the daemons endpoints; a nil value makes the endpoint return the given unsupported status code
+func createDaemonsMockServer(t *testing.T, daemons []model.OnmsDaemon, health *model.OnmsHealth, statuses map[string]string, unsupported int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		var data interface{}
+		switch {
+		case req.URL.Path == "/api/v2/daemons" && daemons != nil:
+			data = daemons
+		case req.URL.Path == "/rest/health" && health != nil:
+			data = health
+		case strings.HasPrefix(req.URL.Path, "/api/v2/daemons/") && statuses != nil:
+			name := strings.TrimSuffix(strings.TrimPrefix(req.URL.Path, "/api/v2/daemons/"), "/status")
+			data = model.OnmsDaemonStatus{Name: name, Status: statuses[name]}
+		case unsupported == http.StatusOK:
+			res.Write([]byte("<html><body>Login</body></html>"))
+			return
+		default:
+			res.WriteHeader(unsupported)
+			return
+		}
+		bytes, _ := json.Marshal(data)
+		res.Write(bytes)
+	}))
+	rest.Instance.URL = server.URL
+	return server
+}
+
+var testDaemons = []model.OnmsDaemon{
+	{Name: "Pollerd", Enabled: true, Reloadable: true},
+	{Name: "Eventd", Internal: true, Enabled: true},
+	{Name: "Telemetryd", Reloadable: true},
+	{Name: "DroolsCorrelationEngine:MyEngine", Enabled: true, Reloadable: true},
+	{Name: "DroolsCorrelationEngine:Other", Enabled: true},
+	{Name: "NewDaemon", Enabled: true, Reloadable: true, Status: "running"},
+}
+
+func TestGetReloadableDaemons(t *testing.T) {
+	server := createDaemonsMockServer(t, testDaemons, nil, nil, http.StatusNotImplemented)
+	defer server.Close()
+
+	daemons, err := getReloadableDaemons()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []reloadableDaemon{
+		{ID: "correlation:MyEngine", Name: "DroolsCorrelationEngine:MyEngine", Status: "enabled"},
+		{ID: "correlation:Other", Name: "DroolsCorrelationEngine:Other", Status: "enabled"},
+		{ID: "newdaemon", Name: "NewDaemon", Status: "running"},
+		{ID: "pollerd", Name: "Pollerd", Status: "enabled"},
+		{ID: "telemetryd", Name: "Telemetryd", Status: "disabled"},
+	}, daemons)
+
+	name, err := resolveDaemonName("newdaemon")
+	assert.NilError(t, err)
+	assert.Equal(t, "NewDaemon", name)
+
+	_, err = resolveDaemonName("eventd")
+	assert.NilError(t, err)
+
+	_, err = resolveDaemonName("Weird")
+	assert.Error(t, err, "invalid daemon name Weird")
+
 	app := test.CreateCli(CliCommand)
-	err := app.Run([]string{app.Name, "daemon", "list"})
+	err = app.Run([]string{app.Name, "daemon", "list"})
 	assert.NilError(t, err)
 }
 
+func TestGetReloadableDaemonsRuntimeStatus(t *testing.T) {
+	health := &model.OnmsHealth{Healthy: false, Responses: []model.OnmsHealthResponse{{Description: "Connecting to ActiveMQ", Status: "Failure"}}}
+	statuses := map[string]string{"Pollerd": "RUNNING", "DroolsCorrelationEngine:MyEngine": "STOPPED"}
+	server := createDaemonsMockServer(t, testDaemons, health, statuses, http.StatusNotFound)
+	defer server.Close()
+
+	daemons, err := getReloadableDaemons()
+	assert.NilError(t, err)
+	assert.Equal(t, "stopped", daemons[0].Status)
+	assert.Equal(t, "running", daemons[3].Status)
+	assert.Equal(t, "disabled", daemons[4].Status)
+	server.Close()
+
+	server = createDaemonsMockServer(t, testDaemons, health, nil, http.StatusMethodNotAllowed)
+	daemons, err = getReloadableDaemons()
+	assert.NilError(t, err)
+	assert.Equal(t, "enabled (server unhealthy)", daemons[3].Status)
+}
+
+func TestGetReloadableDaemonsFallback(t *testing.T) {
+	for _, unsupported := range []int{http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented, http.StatusOK} {
+		server := createDaemonsMockServer(t, nil, nil, nil, unsupported)
+
+		daemons, err := getReloadableDaemons()
+		assert.NilError(t, err, "status %d", unsupported)
+		assert.Equal(t, len(daemonMap), len(daemons))
+		assert.Equal(t, "ackd", daemons[0].ID)
+		assert.Equal(t, "unknown", daemons[0].Status)
+
+		app := test.CreateCli(CliCommand)
+		err = app.Run([]string{app.Name, "daemon", "list"})
+		assert.NilError(t, err)
+		server.Close()
+	}
+
+	server := createDaemonsMockServer(t, nil, nil, nil, http.StatusInternalServerError)
+	defer server.Close()
+	_, err := getReloadableDaemons()
+	assert.ErrorContains(t, err, "500")
+}
+
 func TestReloadDaemon(t *testing.T) {
 	var err error
 	app := test.CreateCli(CliCommand)
 	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/api/v2/daemons" {
+			res.WriteHeader(http.StatusNotFound)
+			return
+		}
 		assert.Assert(t, strings.HasPrefix(req.URL.Path, "/rest/events"))
 		assert.Equal(t, http.MethodPost, req.Method)
 		event := &model.Event{}
@@ -67,3 +176,62 @@ func TestReloadDaemon(t *testing.T) {
 	err = app.Run([]string{app.Name, "daemon", "reload", "pollerd"})
 	assert.NilError(t, err)
 }
+
+func createReloadMockServer(t *testing.T, confirmations []model.OnmsEvent) *httptest.Server {
+	var mutex sync.Mutex
+	sent := false
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		switch {
+		case req.Method == http.MethodPost && req.URL.Path == "/rest/events":
+			sent = true
+			res.WriteHeader(http.StatusOK)
+		case req.Method == http.MethodGet && req.URL.Path == "/api/v2/events":
+			list := model.OnmsEventList{}
+			filter := req.URL.Query().Get("_s")
+			if filter == "" {
+				sent = false
+				list.Events = []model.OnmsEvent{{ID: 100}}
+			} else {
+				assert.Equal(t, "(eventUei=="+reloadSuccessfulUEI+",eventUei=="+reloadFailedUEI+");(id=gt=100)", filter)
+				assert.Equal(t, true, sent)
+				list.Events = confirmations
+			}
+			bytes, _ := json.Marshal(list)
+			res.Write(bytes)
+		default:
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+	}))
+	rest.Instance.URL = server.URL
+	return server
+}
+
+func TestReloadDaemonWithWait(t *testing.T) {
+	var err error
+	app := test.CreateCli(CliCommand)
+	server := createReloadMockServer(t, []model.OnmsEvent{
+		{ID: 103, UEI: reloadSuccessfulUEI, Parameters: []model.OnmsEventParam{{Name: "daemonName", Value: "Pollerd"}}},
+		{ID: 102, UEI: reloadFailedUEI, Parameters: []model.OnmsEventParam{{Name: "daemonName", Value: "Collectd"}, {Name: "reason", Value: "Invalid XML"}}},
+	})
+	defer server.Close()
+
+	err = app.Run([]string{app.Name, "daemon", "reload", "-w", "-i", "0s", "pollerd"})
+	assert.Error(t, err, "interval must be greater than 0")
+
+	err = app.Run([]string{app.Name, "daemon", "reload", "-w", "-i", "10ms", "pollerd"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "daemon", "reload", "-w", "-i", "10ms", "collectd"})
+	assert.Error(t, err, "reload of Collectd failed: Invalid XML")
+}
+
+func TestReloadDaemonWithWaitTimeout(t *testing.T) {
+	app := test.CreateCli(CliCommand)
+	server := createReloadMockServer(t, []model.OnmsEvent{})
+	defer server.Close()
+
+	err := app.Run([]string{app.Name, "daemon", "reload", "-w", "-i", "10ms", "-t", "50ms", "pollerd"})
+	assert.Error(t, err, "timeout waiting for the reload confirmation of Pollerd")
+}
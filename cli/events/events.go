@@ -2,7 +2,11 @@ package events
 
 import (
 	"fmt"
+	"net"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/OpenNMS/onmsctl/api"
 	"github.com/OpenNMS/onmsctl/common"
@@ -14,6 +18,12 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
+// Formats the supported file formats for events
+var Formats = []string{"yaml", "xml"}
+
+// Transports the supported transports to send events
+var Transports = []string{"rest", "tcp"}
+
 var severities = &model.EnumValue{
 	Enum: model.Severities.Enum,
 }
@@ -30,10 +40,10 @@ var CliCommand = cli.Command{
 	Subcommands: []cli.Command{
 		{
 			Name:      "send",
-			Usage:     "Sends an event to OpenNMS",
-			ArgsUsage: "<uei>",
+			Usage:     "Sends an event to OpenNMS, optionally based on an event template",
+			ArgsUsage: "[uei]",
 			Action:    sendEvent,
-			Flags: []cli.Flag{
+			Flags: append([]cli.Flag{
 				cli.StringFlag{
 					Name:  "host, H",
 					Usage: "IP address or FQDN of the host that sends the event",
@@ -72,38 +82,111 @@ var CliCommand = cli.Command{
 					Name:  "parm, p",
 					Usage: "An event parameter (e.x. --parm 'url=http://www.google.com/')",
 				},
-			},
+				cli.StringFlag{
+					Name:  "template, T",
+					Usage: "The name of an event template to use as the base of the event (the UEI becomes optional)",
+				},
+				cli.StringSliceFlag{
+					Name:  "var, V",
+					Usage: "A variable for the event template (e.x. --var 'node=42')",
+				},
+			}, transportFlags()...),
 		},
 		{
 			Name:      "apply",
-			Usage:     "Sends an event to OpenNMS in YAML format",
+			Usage:     "Sends an event to OpenNMS in YAML or XML format, or multiple events from a stream",
 			Action:    applyEvent,
-			ArgsUsage: "<yaml>",
-			Flags: []cli.Flag{
-				cli.StringFlag{
-					Name:  "file, f",
-					Usage: "External YAML file (use '-' for STDIN Pipe)",
-				},
-			},
+			ArgsUsage: "<content>",
+			Flags:     applyFlags(),
 		},
+		ExportCliCommand,
+		TailCliCommand,
+		TemplatesCliCommand,
+		TrapCliCommand,
+		SyslogCliCommand,
 	},
 }
 
+func applyFlags() []cli.Flag {
+	return append([]cli.Flag{
+		cli.GenericFlag{
+			Name: "format, x",
+			Value: &model.EnumValue{
+				Enum:    Formats,
+				Default: "yaml",
+			},
+			Usage: "File Format: " + strings.Join(Formats, ", "),
+		},
+		cli.StringFlag{
+			Name:  "file, f",
+			Usage: "External YAML, JSON Lines or XML file (use '-' for STDIN Pipe)",
+		},
+	}, append(streamFlags(), transportFlags()...)...)
+}
+
+// transportFlags builds the flags to choose how events are sent to OpenNMS
+func transportFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.GenericFlag{
+			Name: "transport, t",
+			Value: &model.EnumValue{
+				Enum:    Transports,
+				Default: Transports[0],
+			},
+			Usage: "How to send events: " + strings.Join(Transports, ", ") + " (tcp uses the Eventd TCP listener, without credentials)",
+		},
+		cli.StringFlag{
+			Name:  "address, a",
+			Usage: fmt.Sprintf("Address of the Eventd TCP listener (defaults to the OpenNMS server host on port %d)", services.DefaultEventdPort),
+		},
+	}
+}
+
 func sendEvent(c *cli.Context) error {
-	if !c.Args().Present() {
-		return fmt.Errorf("UEI required")
-	}
-	uei := c.Args().First()
-	event := model.Event{
-		UEI:         uei,
-		NodeID:      c.Int64("nodeid"),
-		Interface:   c.String("interface"),
-		Service:     c.String("service"),
-		IfIndex:     c.Int("ifindex"),
-		Description: c.String("descr"),
-		Severity:    c.String("severity"),
-		Host:        c.String("host"),
-		Source:      "onmsctl",
+	event, err := buildEvent(c)
+	if err != nil {
+		return err
+	}
+	return getSendAPI(c).SendEvent(*event)
+}
+
+// buildEvent builds an event from a template or from the UEI argument, and then applies the flags with non-empty values
+func buildEvent(c *cli.Context) (*model.Event, error) {
+	event := &model.Event{Source: "onmsctl"}
+	if name := c.String("template"); name != "" {
+		t, err := getTemplatesAPI().GetTemplate(name)
+		if err != nil {
+			return nil, err
+		}
+		vars, err := model.ParseTemplateVars(c.StringSlice("var"))
+		if err != nil {
+			return nil, err
+		}
+		if event, err = t.Render(vars); err != nil {
+			return nil, err
+		}
+	} else if !c.Args().Present() {
+		return nil, fmt.Errorf("UEI required")
+	}
+	if uei := c.Args().First(); uei != "" {
+		event.UEI = uei
+	}
+	if nodeID := c.Int64("nodeid"); nodeID != 0 {
+		event.NodeID = nodeID
+	}
+	if ifIndex := c.Int("ifindex"); ifIndex != 0 {
+		event.IfIndex = ifIndex
+	}
+	for flag, field := range map[string]*string{
+		"interface": &event.Interface,
+		"service":   &event.Service,
+		"descr":     &event.Description,
+		"severity":  &event.Severity,
+		"host":      &event.Host,
+	} {
+		if value := c.String(flag); value != "" {
+			*field = value
+		}
 	}
 	params := c.StringSlice("parm")
 	for _, p := range params {
@@ -118,14 +201,20 @@ func sendEvent(c *cli.Context) error {
 			Message: logmsg,
 		}
 	}
-	return getAPI().SendEvent(event)
+	return event, nil
 }
 
 func applyEvent(c *cli.Context) error {
+	if c.Bool("stream") {
+		return applyEventStream(c)
+	}
 	data, err := common.ReadInput(c, 0)
 	if err != nil {
 		return err
 	}
+	if c.String("format") == "xml" {
+		return applyEventsXML(getSendAPI(c), data)
+	}
 	event := model.Event{}
 	if err := yaml.Unmarshal(data, &event); err != nil {
 		return err
@@ -133,9 +222,63 @@ func applyEvent(c *cli.Context) error {
 	if err := event.Validate(); err != nil {
 		return err
 	}
-	return getAPI().SendEvent(event)
+	return getSendAPI(c).SendEvent(event)
+}
+
+// applyEventsXML sends all the events from an XML content, either a single event or a log with multiple events
+func applyEventsXML(eventsAPI api.EventsAPI, data []byte) error {
+	events, err := model.ParseEventsXML(data)
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		return fmt.Errorf("there are no events on the XML content")
+	}
+	for i, event := range events {
+		if err := event.Validate(); err != nil {
+			return fmt.Errorf("event %d: %v", i+1, err)
+		}
+	}
+	for i, event := range events {
+		if err := eventsAPI.SendEvent(event); err != nil {
+			return fmt.Errorf("event %d: %v", i+1, err)
+		}
+	}
+	return nil
+}
+
+func getTemplatesAPI() api.EventTemplatesAPI {
+	return services.GetEventTemplatesAPI(services.GetEventTemplatesDir())
 }
 
 func getAPI() api.EventsAPI {
 	return services.GetEventsAPI(rest.Instance)
 }
+
+// getSendAPI gets the Events API for the transport chosen with the transport flags
+func getSendAPI(c *cli.Context) api.EventsAPI {
+	if c.String("transport") != "tcp" {
+		return getAPI()
+	}
+	address := c.String("address")
+	if address == "" {
+		address = net.JoinHostPort(getServerHost(), strconv.Itoa(services.DefaultEventdPort))
+	}
+	return services.GetEventsTCPAPI(address, time.Duration(rest.Instance.Timeout)*time.Second)
+}
+
+// getDestinationHost gets the host where traps or syslog messages are sent, from the host flag or the OpenNMS server URL
+func getDestinationHost(c *cli.Context) string {
+	if host := c.String("host"); host != "" {
+		return host
+	}
+	return getServerHost()
+}
+
+// getServerHost gets the host of the OpenNMS server, based on the URL of the ReST API
+func getServerHost() string {
+	if u, err := url.Parse(rest.Instance.URL); err == nil && u.Hostname() != "" {
+		return u.Hostname()
+	}
+	return "localhost"
+}
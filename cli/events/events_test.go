@@ -2,16 +2,23 @@ package events
 
 import (
 	"encoding/json"
+	"encoding/xml"
+	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/OpenNMS/onmsctl/model"
 	"github.com/OpenNMS/onmsctl/rest"
 	"github.com/OpenNMS/onmsctl/test"
 	"github.com/google/go-cmp/cmp"
+	"github.com/urfave/cli"
 
 	"gopkg.in/yaml.v2"
 	"gotest.tools/assert"
@@ -66,3 +73,367 @@ func TestApplyEvent(t *testing.T) {
 	err = app.Run([]string{app.Name, "events", "apply", string(yamlBytes)})
 	assert.NilError(t, err)
 }
+
+func createTailMockServer(t *testing.T) (*httptest.Server, *[]string) {
+	var mutex sync.Mutex
+	filters := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/api/v2/events", req.URL.Path)
+		filter := req.URL.Query().Get("_s")
+		mutex.Lock()
+		filters = append(filters, req.URL.Query().Get("limit")+" "+filter)
+		polls := len(filters)
+		mutex.Unlock()
+		list := model.OnmsEventList{}
+		switch {
+		case filter == "eventUei==uei.opennms.org/test":
+			list.Events = []model.OnmsEvent{{ID: 5, UEI: "uei.opennms.org/test", Severity: "NORMAL"}}
+		case filter == "(eventUei==uei.opennms.org/test);(id=gt=5)" && polls == 3:
+			list.Events = []model.OnmsEvent{
+				{ID: 7, UEI: "uei.opennms.org/test", NodeLabel: "srv01", LogMessage: "Second\nevent"},
+				{ID: 6, UEI: "uei.opennms.org/test", NodeID: 1},
+			}
+		}
+		if len(list.Events) == 0 {
+			res.WriteHeader(http.StatusNoContent)
+			return
+		}
+		bytes, _ := json.Marshal(list)
+		res.Write(bytes)
+	}))
+	rest.Instance.URL = server.URL
+	return server, &filters
+}
+
+func TestTailEvents(t *testing.T) {
+	var err error
+	app := test.CreateCli(CliCommand)
+	server, filters := createTailMockServer(t)
+	defer server.Close()
+
+	err = app.Run([]string{app.Name, "events", "tail", "-i", "0s"})
+	assert.Error(t, err, "interval must be greater than 0")
+
+	err = app.Run([]string{app.Name, "events", "tail", "-u", "uei.opennms.org/test", "-l", "1", "-i", "20ms", "-d", "200ms"})
+	assert.NilError(t, err)
+	assert.Assert(t, len(*filters) >= 4)
+	assert.Equal(t, "1 eventUei==uei.opennms.org/test", (*filters)[0])
+	assert.Equal(t, "0 (eventUei==uei.opennms.org/test);(id=gt=5)", (*filters)[1])
+	assert.Equal(t, "0 (eventUei==uei.opennms.org/test);(id=gt=7)", (*filters)[3])
+}
+
+func TestBuildEventsFilter(t *testing.T) {
+	app := test.CreateCli(cli.Command{
+		Name:  "test",
+		Flags: tailFlags(),
+		Action: func(c *cli.Context) error {
+			filter, err := buildEventsFilter(c)
+			assert.NilError(t, err)
+			assert.Equal(t, "(ipAddr==10.0.0.1);(node.label==srv01);(eventSeverity==6)", filter)
+			return nil
+		},
+	})
+	err := app.Run([]string{app.Name, "test", "-f", "ipAddr==10.0.0.1", "-n", "srv01", "-s", "Major"})
+	assert.NilError(t, err)
+}
+
+func TestBuildEventsFilterEscapesValues(t *testing.T) {
+	app := test.CreateCli(cli.Command{
+		Name:  "test",
+		Flags: tailFlags(),
+		Action: func(c *cli.Context) error {
+			filter, err := buildEventsFilter(c)
+			assert.NilError(t, err)
+			assert.Equal(t, "(eventUei==uei.opennms.org/test*);(node.label==srv%2C01%3B%28a%29)", filter)
+			return nil
+		},
+	})
+	err := app.Run([]string{app.Name, "test", "-u", "uei.opennms.org/test*", "-n", "srv,01;(a)"})
+	assert.NilError(t, err)
+}
+
+func createStreamMockServer(t *testing.T) (*httptest.Server, *[]string) {
+	var mutex sync.Mutex
+	received := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/rest/events", req.URL.Path)
+		assert.Equal(t, http.MethodPost, req.Method)
+		event := &model.Event{}
+		bytes, err := ioutil.ReadAll(req.Body)
+		assert.NilError(t, err)
+		json.Unmarshal(bytes, event)
+		mutex.Lock()
+		received = append(received, event.UEI)
+		mutex.Unlock()
+		if event.UEI == "uei.opennms.org/reject" {
+			res.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		res.WriteHeader(http.StatusOK)
+	}))
+	rest.Instance.URL = server.URL
+	return server, &received
+}
+
+func TestApplyEventStreamYAML(t *testing.T) {
+	app := test.CreateCli(CliCommand)
+	server, received := createStreamMockServer(t)
+	defer server.Close()
+
+	stream := `
+uei: uei.opennms.org/test/1
+source: onmsctl
+---
+uei: uei.opennms.org/test/2
+interface: 10.0.0.1
+---
+uei: uei.opennms.org/test/3
+parameters:
+- name: owner
+  value: agalue
+`
+	err := app.Run([]string{app.Name, "events", "apply", "--stream", "-c", "2", stream})
+	assert.NilError(t, err)
+	assert.Equal(t, 3, len(*received))
+}
+
+func TestApplyEventStreamJSONLines(t *testing.T) {
+	app := test.CreateCli(CliCommand)
+	server, received := createStreamMockServer(t)
+	defer server.Close()
+
+	stream := `{"uei": "uei.opennms.org/test/1", "nodeID": 1}
+{"uei": "uei.opennms.org/test/2", "interface": "10.0.0.300"}
+
+{"source": "onmsctl"}
+{"uei": "uei.opennms.org/reject"}
+{"uei": "uei.opennms.org/test/3", "severity": "Major"}
+`
+	err := app.Run([]string{app.Name, "events", "apply", "--stream", "-r", "100", stream})
+	assert.Error(t, err, "3 of 5 events failed")
+	assert.Equal(t, 3, len(*received))
+}
+
+func TestApplyEventStreamConcurrency(t *testing.T) {
+	app := test.CreateCli(CliCommand)
+	server, received := createStreamMockServer(t)
+	defer server.Close()
+
+	stream := ""
+	for i := 0; i < 20; i++ {
+		stream += fmt.Sprintf("{\"uei\": \"uei.opennms.org/test/%d\", \"severity\": \"%s\"}\n", i, model.Severities.Enum[i%len(model.Severities.Enum)])
+	}
+	err := app.Run([]string{app.Name, "events", "apply", "--stream", "--concurrency", "4", stream})
+	assert.NilError(t, err)
+	assert.Equal(t, 20, len(*received))
+}
+
+func TestReadEventStreamJSONLines(t *testing.T) {
+	events := make(chan streamedEvent)
+	go readEventStream([]byte(`{"uei": "uei.opennms.org/test/1", "nodeid": 1, "parms": [{"parmName": "owner", "value": "agalue"}]}
+{"uei": "uei.opennms.org/test/2", "parameters": [{"name": "owner", "value": "agalue"}]}
+`), "yaml", events)
+	list := make([]streamedEvent, 0)
+	for e := range events {
+		list = append(list, e)
+	}
+	assert.Equal(t, 2, len(list))
+	assert.NilError(t, list[0].err)
+	assert.Equal(t, int64(1), list[0].event.NodeID)
+	assert.DeepEqual(t, []model.EventParam{{Name: "owner", Value: "agalue"}}, list[0].event.Parameters)
+	assert.ErrorContains(t, list[1].err, `unknown field "parameters"`)
+}
+
+func TestReadEventStream(t *testing.T) {
+	events := make(chan streamedEvent)
+	go readEventStream([]byte("uei: uei.opennms.org/test/1\n---\nuei: [broken\n---\nuei: uei.opennms.org/test/3\n"), "yaml", events)
+	positions := make([]string, 0)
+	for e := range events {
+		positions = append(positions, e.position)
+		if e.position == "document 2" {
+			assert.Assert(t, e.err != nil)
+		} else {
+			assert.NilError(t, e.err)
+		}
+	}
+	assert.DeepEqual(t, []string{"document 1", "document 2"}, positions)
+}
+
+func TestApplyEventXML(t *testing.T) {
+	var err error
+	app := test.CreateCli(cli.Command{
+		Name:        "events",
+		Subcommands: []cli.Command{{Name: "apply", Action: applyEvent, Flags: applyFlags()}},
+	})
+	server := createMockServer(t)
+	defer server.Close()
+
+	xmlBytes, _ := xml.Marshal(model.EventLog{Events: []model.Event{*mockData}})
+	err = app.Run([]string{app.Name, "events", "apply", "-x", "xml", string(xmlBytes)})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "events", "apply", "-x", "xml", "<log><events/></log>"})
+	assert.Error(t, err, "there are no events on the XML content")
+
+	err = app.Run([]string{app.Name, "events", "apply", "-x", "xml", "<event><interface>10.0.0.1</interface></event>"})
+	assert.Error(t, err, "event 1: UEI cannot be null")
+}
+
+func TestExportEvents(t *testing.T) {
+	var err error
+	app := test.CreateCli(cli.Command{
+		Name:        "events",
+		Subcommands: []cli.Command{{Name: "export", Action: exportEvents, Flags: exportFlags()}},
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/api/v2/events", req.URL.Path)
+		assert.Equal(t, "5", req.URL.Query().Get("limit"))
+		assert.Equal(t, "eventUei==uei.opennms.org/test", req.URL.Query().Get("_s"))
+		list := model.OnmsEventList{
+			Events: []model.OnmsEvent{
+				{ID: 2, UEI: "uei.opennms.org/test", Severity: "MAJOR", NodeID: 1},
+				{ID: 1, UEI: "uei.opennms.org/test", Severity: "NORMAL", LogMessage: "Test", Log: "Y", Display: "Y"},
+			},
+		}
+		bytes, _ := json.Marshal(list)
+		res.Write(bytes)
+	}))
+	defer server.Close()
+	rest.Instance.URL = server.URL
+
+	err = app.Run([]string{app.Name, "events", "export", "-u", "uei.opennms.org/test", "-l", "5"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "events", "export", "-u", "uei.opennms.org/test", "-l", "5", "-x", "yaml"})
+	assert.NilError(t, err)
+}
+
+func TestApplyEventOverTCP(t *testing.T) {
+	var err error
+	app := test.CreateCli(cli.Command{
+		Name:        "events",
+		Subcommands: []cli.Command{{Name: "apply", Action: applyEvent, Flags: applyFlags()}},
+	})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		log := &model.EventLog{}
+		assert.NilError(t, xml.NewDecoder(conn).Decode(log))
+		assert.Equal(t, mockData.UEI, log.Events[0].UEI)
+		data, _ := xml.Marshal(model.EventReceipt{UUIDs: []string{log.Events[0].UUID}})
+		conn.Write(data)
+	}()
+
+	yamlBytes, _ := yaml.Marshal(mockData)
+	err = app.Run([]string{app.Name, "events", "apply", "-t", "tcp", "-a", listener.Addr().String(), string(yamlBytes)})
+	assert.NilError(t, err)
+}
+
+func TestSendTrap(t *testing.T) {
+	var err error
+	app := test.CreateCli(cli.Command{
+		Name:        "events",
+		Subcommands: []cli.Command{{Name: "trap", Action: sendTrap, Flags: trapFlags()}},
+	})
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	defer conn.Close()
+	_, port, _ := net.SplitHostPort(conn.LocalAddr().String())
+
+	err = app.Run([]string{app.Name, "events", "trap", "-H", "127.0.0.1", "-p", port, "-b", ".1.3.6.1.2.1.1.5.0=srv01"})
+	assert.Error(t, err, "a valid trap OID is required for SNMPv2c traps")
+
+	err = app.Run([]string{app.Name, "events", "trap", "-H", "127.0.0.1", "-p", port, "--oid", ".1.3.6.1.6.3.1.1.5.3", "-b", ".1.3.6.1.2.1.2.2.1.1.1=integer:1"})
+	assert.NilError(t, err)
+
+	trapYAML := `
+snmp:
+  version: v1
+  community: public
+enterprise: .1.3.6.1.4.1.5813
+generic: 6
+specific: 1
+varbinds:
+- oid: .1.3.6.1.2.1.1.5.0
+  type: string
+  value: srv01
+`
+	file, err := ioutil.TempFile("", "trap*.yaml")
+	assert.NilError(t, err)
+	defer os.Remove(file.Name())
+	file.WriteString(trapYAML)
+	file.Close()
+	err = app.Run([]string{app.Name, "events", "trap", "-H", "127.0.0.1", "-p", port, "-f", file.Name()})
+	assert.NilError(t, err)
+
+	buffer := make([]byte, 4096)
+	for i := 0; i < 2; i++ {
+		conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		_, _, err = conn.ReadFrom(buffer)
+		assert.NilError(t, err)
+	}
+}
+
+func TestSendSyslogMessage(t *testing.T) {
+	var err error
+	app := test.CreateCli(cli.Command{
+		Name:        "events",
+		Subcommands: []cli.Command{{Name: "syslog", Action: sendSyslogMessage, Flags: syslogFlags()}},
+	})
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	defer conn.Close()
+	_, port, _ := net.SplitHostPort(conn.LocalAddr().String())
+
+	err = app.Run([]string{app.Name, "events", "syslog", "-H", "127.0.0.1", "-p", port})
+	assert.Error(t, err, "message required")
+
+	err = app.Run([]string{app.Name, "events", "syslog", "-H", "127.0.0.1", "-p", port, "-x", "rfc3164", "-F", "local1", "-s", "warning", "-n", "srv01", "--procid", "100", "Disk", "full"})
+	assert.NilError(t, err)
+
+	buffer := make([]byte, 1024)
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, _, err := conn.ReadFrom(buffer)
+	assert.NilError(t, err)
+	message := string(buffer[:n])
+	assert.Assert(t, strings.HasPrefix(message, "<140>"))
+	assert.Assert(t, strings.HasSuffix(message, " srv01 onmsctl[100]: Disk full"))
+}
+
+func TestSendEventFromTemplate(t *testing.T) {
+	var err error
+	app := test.CreateCli(CliCommand)
+	server := createMockServer(t)
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "onms")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+	os.Setenv("ONMSCONFIG", dir+"/config.yaml")
+	defer os.Unsetenv("ONMSCONFIG")
+	assert.NilError(t, os.MkdirAll(dir+"/event-templates", 0755))
+	tmpl := `uei: uei.opennms.org/test
+source: onmsctl
+nodeID: {{ required "node" .node }}
+interface: {{ .ip }}
+parameters:
+- name: owner
+  value: {{ default "agalue" .owner }}
+`
+	assert.NilError(t, ioutil.WriteFile(dir+"/event-templates/test.yaml", []byte(tmpl), 0644))
+
+	err = app.Run([]string{app.Name, "events", "send", "-T", "test", "-V", "ip=10.0.0.1"})
+	assert.ErrorContains(t, err, "variable node is required")
+
+	err = app.Run([]string{app.Name, "events", "send", "-T", "unknown"})
+	assert.Error(t, err, "template unknown not found")
+
+	err = app.Run([]string{app.Name, "events", "send", "-T", "test", "-V", "node=10", "-V", "ip=10.0.0.1", "-s", "SNMP"})
+	assert.NilError(t, err)
+}
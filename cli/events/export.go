@@ -0,0 +1,74 @@
+package events
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/OpenNMS/onmsctl/model"
+	"github.com/urfave/cli"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ExportCliCommand the CLI command to export existing events in a format that can be sent again to OpenNMS
+var ExportCliCommand = cli.Command{
+	Name:        "export",
+	Usage:       "Exports existing events in a format that can be replayed with the apply command",
+	Description: "Exports existing events, from the oldest to the newest, as an XML log, or as multi-document YAML (for apply --stream)",
+	Action:      exportEvents,
+	Flags:       exportFlags(),
+}
+
+func exportFlags() []cli.Flag {
+	return append(filterFlags(),
+		cli.IntFlag{
+			Name:  "limit, l",
+			Usage: "Maximum number of events to export (the most recent ones)",
+			Value: 10,
+		},
+		cli.GenericFlag{
+			Name: "format, x",
+			Value: &model.EnumValue{
+				Enum:    Formats,
+				Default: "xml",
+			},
+			Usage: "File Format: " + strings.Join(Formats, ", "),
+		},
+	)
+}
+
+func exportEvents(c *cli.Context) error {
+	filter, err := buildEventsFilter(c)
+	if err != nil {
+		return err
+	}
+	list, err := getAPI().GetEvents(filter, c.Int("limit"))
+	if err != nil {
+		return err
+	}
+	// Events are sorted by ID in descending order, so they are reversed to keep the original sequence when replaying them
+	log := model.EventLog{}
+	for i := len(list.Events) - 1; i >= 0; i-- {
+		log.Events = append(log.Events, list.Events[i].ToEvent())
+	}
+	if c.String("format") == "xml" {
+		data, err := xml.MarshalIndent(log, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(xml.Header + string(data))
+		return nil
+	}
+	for i, event := range log.Events {
+		data, err := yaml.Marshal(event)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			fmt.Println("---")
+		}
+		fmt.Print(string(data))
+	}
+	return nil
+}
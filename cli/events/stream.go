@@ -0,0 +1,188 @@
+package events
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/OpenNMS/onmsctl/common"
+	"github.com/OpenNMS/onmsctl/model"
+	"github.com/urfave/cli"
+
+	"gopkg.in/yaml.v2"
+)
+
+// The maximum size of a single line on a JSON Lines stream
+const maxStreamLineSize = 1024 * 1024
+
+// streamedEvent an event read from a stream, with its position for error reporting
+type streamedEvent struct {
+	position string
+	event    model.Event
+	err      error
+}
+
+// streamFlags builds the flags to send multiple events from a stream
+func streamFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.BoolFlag{
+			Name:  "stream",
+			Usage: "Reads multiple events from a multi-document YAML or a JSON Lines content",
+		},
+		cli.IntFlag{
+			Name:  "concurrency, c",
+			Usage: "Number of events sent in parallel when streaming",
+			Value: 1,
+		},
+		cli.IntFlag{
+			Name:  "rate, r",
+			Usage: "Maximum number of events sent per second when streaming (0 means unlimited)",
+		},
+	}
+}
+
+func applyEventStream(c *cli.Context) error {
+	concurrency := c.Int("concurrency")
+	if concurrency < 1 {
+		return fmt.Errorf("concurrency must be greater than 0")
+	}
+	rate := c.Int("rate")
+	if rate < 0 {
+		return fmt.Errorf("rate cannot be negative")
+	}
+	data, err := common.ReadInput(c, 0)
+	if err != nil {
+		return err
+	}
+
+	events := make(chan streamedEvent)
+	go readEventStream(data, c.String("format"), events)
+
+	var throttle <-chan time.Time
+	if rate > 0 {
+		ticker := time.NewTicker(time.Second / time.Duration(rate))
+		defer ticker.Stop()
+		throttle = ticker.C
+	}
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	sent, failed := 0, 0
+	report := func(position string, err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if err == nil {
+			sent++
+			return
+		}
+		failed++
+		fmt.Printf("%s: %v\n", position, err)
+	}
+
+	eventsAPI := getSendAPI(c)
+	queue := make(chan streamedEvent)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for e := range queue {
+				report(e.position, eventsAPI.SendEvent(e.event))
+			}
+		}()
+	}
+	for e := range events {
+		if e.err == nil {
+			e.err = e.event.Validate()
+		}
+		if e.err != nil {
+			report(e.position, e.err)
+			continue
+		}
+		if throttle != nil {
+			<-throttle
+		}
+		queue <- e
+	}
+	close(queue)
+	wg.Wait()
+
+	fmt.Printf("%d events sent, %d failed\n", sent, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d of %d events failed", failed, sent+failed)
+	}
+	return nil
+}
+
+// readEventStream parses the content as an XML log when requested, as JSON Lines when it starts with '{', or as multi-document YAML otherwise.
+// YAML uses the field names of the YAML representation of an event, and JSON Lines the ones of the JSON representation used by the ReST API.
+// The channel is closed when the content has been fully processed.
+func readEventStream(data []byte, format string, events chan<- streamedEvent) {
+	defer close(events)
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return
+	}
+	switch {
+	case format == "xml":
+		readXMLEvents(data, events)
+	case data[0] == '{':
+		readJSONLines(data, events)
+	default:
+		readYAMLDocuments(data, events)
+	}
+}
+
+func readJSONLines(data []byte, events chan<- streamedEvent) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxStreamLineSize)
+	line := 0
+	for scanner.Scan() {
+		line++
+		content := bytes.TrimSpace(scanner.Bytes())
+		if len(content) == 0 {
+			continue
+		}
+		e := streamedEvent{position: fmt.Sprintf("line %d", line)}
+		// Unknown fields are rejected, to avoid silently losing content (e.x. when using the YAML field names)
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.DisallowUnknownFields()
+		e.err = decoder.Decode(&e.event)
+		events <- e
+	}
+	if err := scanner.Err(); err != nil {
+		events <- streamedEvent{position: fmt.Sprintf("line %d", line+1), err: err}
+	}
+}
+
+func readYAMLDocuments(data []byte, events chan<- streamedEvent) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for document := 1; ; document++ {
+		e := streamedEvent{position: fmt.Sprintf("document %d", document)}
+		err := decoder.Decode(&e.event)
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			// The decoder cannot recover from a syntax error, so the rest of the stream is discarded
+			e.err = err
+			events <- e
+			return
+		}
+		events <- e
+	}
+}
+
+func readXMLEvents(data []byte, events chan<- streamedEvent) {
+	list, err := model.ParseEventsXML(data)
+	if err != nil {
+		events <- streamedEvent{position: "xml", err: err}
+		return
+	}
+	for i, event := range list {
+		events <- streamedEvent{position: fmt.Sprintf("event %d", i+1), event: event}
+	}
+}
@@ -0,0 +1,103 @@
+package events
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/OpenNMS/onmsctl/model"
+	"github.com/OpenNMS/onmsctl/rest"
+	"github.com/OpenNMS/onmsctl/services"
+	"github.com/urfave/cli"
+)
+
+// SyslogCliCommand the CLI command to send syslog messages
+var SyslogCliCommand = cli.Command{
+	Name:      "syslog",
+	Usage:     "Sends a syslog message, like logger (for testing syslogd)",
+	ArgsUsage: "<message>",
+	Action:    sendSyslogMessage,
+	Flags:     syslogFlags(),
+}
+
+func syslogFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "host, H",
+			Usage: "The destination host (defaults to the OpenNMS server host)",
+		},
+		cli.IntFlag{
+			Name:  "port, p",
+			Value: services.DefaultSyslogPort,
+			Usage: "The port of the syslog listener",
+		},
+		cli.GenericFlag{
+			Name:  "protocol, P",
+			Value: &model.EnumValue{Enum: []string{"udp", "tcp"}, Default: "udp"},
+			Usage: "The transport protocol: udp, tcp",
+		},
+		cli.GenericFlag{
+			Name:  "format, x",
+			Value: &model.EnumValue{Enum: model.SyslogFormats.Enum, Default: model.SyslogFormats.Default},
+			Usage: "The message format: " + model.SyslogFormats.EnumAsString(),
+		},
+		cli.GenericFlag{
+			Name:  "facility, F",
+			Value: &model.EnumValue{Enum: model.SyslogFacilities.Enum, Default: model.SyslogFacilities.Default},
+			Usage: "The facility of the message: " + model.SyslogFacilities.EnumAsString(),
+		},
+		cli.GenericFlag{
+			Name:  "severity, s",
+			Value: &model.EnumValue{Enum: model.SyslogSeverities.Enum, Default: model.SyslogSeverities.Default},
+			Usage: "The severity of the message: " + model.SyslogSeverities.EnumAsString(),
+		},
+		cli.StringFlag{
+			Name:  "hostname, n",
+			Usage: "The hostname of the sender (defaults to the local hostname)",
+		},
+		cli.StringFlag{
+			Name:  "app, a",
+			Value: "onmsctl",
+			Usage: "The application name (or tag for rfc3164)",
+		},
+		cli.StringFlag{
+			Name:  "procid",
+			Usage: "The process ID (defaults to the PID of onmsctl)",
+		},
+		cli.StringFlag{
+			Name:  "msgid, m",
+			Usage: "The message ID (for rfc5424)",
+		},
+	}
+}
+
+func sendSyslogMessage(c *cli.Context) error {
+	if !c.Args().Present() {
+		return fmt.Errorf("message required")
+	}
+	message := model.SyslogMessage{
+		Facility: c.String("facility"),
+		Severity: c.String("severity"),
+		Hostname: c.String("hostname"),
+		AppName:  c.String("app"),
+		ProcID:   c.String("procid"),
+		MsgID:    c.String("msgid"),
+		Message:  strings.Join(c.Args(), " "),
+	}
+	if message.Hostname == "" {
+		message.Hostname, _ = os.Hostname()
+	}
+	if message.ProcID == "" {
+		message.ProcID = strconv.Itoa(os.Getpid())
+	}
+	address := net.JoinHostPort(getDestinationHost(c), strconv.Itoa(c.Int("port")))
+	syslogAPI := services.GetSyslogAPI(time.Duration(rest.Instance.Timeout) * time.Second)
+	if err := syslogAPI.SendMessage(address, c.String("protocol"), c.String("format"), message); err != nil {
+		return err
+	}
+	fmt.Printf("Message sent to %s\n", address)
+	return nil
+}
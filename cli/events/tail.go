@@ -0,0 +1,181 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/OpenNMS/onmsctl/common"
+	"github.com/OpenNMS/onmsctl/model"
+	"github.com/urfave/cli"
+)
+
+// TailCliCommand the CLI command to follow new events
+var TailCliCommand = cli.Command{
+	Name:   "tail",
+	Usage:  "Follows new events, like tail -f (use Ctrl+C to stop)",
+	Action: tailEvents,
+	Flags:  tailFlags(),
+}
+
+func tailFlags() []cli.Flag {
+	return append(filterFlags(),
+		cli.IntFlag{
+			Name:  "lines, l",
+			Usage: "Number of existing events to display before following new ones",
+			Value: 10,
+		},
+		cli.DurationFlag{
+			Name:  "interval, i",
+			Usage: "Time between polls",
+			Value: 2 * time.Second,
+		},
+		cli.DurationFlag{
+			Name:  "duration, d",
+			Usage: "Stop following events after a given amount of time (0 means until interrupted)",
+		},
+	)
+}
+
+// filterFlags builds the flags used by buildEventsFilter
+func filterFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "filter, f",
+			Usage: "The filter to apply in FIQL format",
+		},
+		cli.StringFlag{
+			Name:  "uei, u",
+			Usage: "The UEI of the events",
+		},
+		cli.StringFlag{
+			Name:  "node, n",
+			Usage: "The node ID or node label of the events",
+		},
+		cli.GenericFlag{
+			Name:  "severity, s",
+			Value: &model.EnumValue{Enum: model.Severities.Enum},
+			Usage: "The severity of the events: " + model.Severities.EnumAsString(),
+		},
+	}
+}
+
+func tailEvents(c *cli.Context) error {
+	filter, err := buildEventsFilter(c)
+	if err != nil {
+		return err
+	}
+	interval := c.Duration("interval")
+	if interval <= 0 {
+		return fmt.Errorf("interval must be greater than 0")
+	}
+	// Initial position: the most recent matching events, or the newest event overall
+	lastID := 0
+	if lines := c.Int("lines"); lines > 0 {
+		list, err := getAPI().GetEvents(filter, lines)
+		if err != nil {
+			return err
+		}
+		if lastID, err = printEvents(list.Events); err != nil {
+			return err
+		}
+	}
+	if lastID == 0 {
+		list, err := getAPI().GetEvents("", 1)
+		if err != nil {
+			return err
+		}
+		if len(list.Events) > 0 {
+			lastID = list.Events[0].ID
+		}
+	}
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt)
+	defer signal.Stop(stop)
+	var timeout <-chan time.Time
+	if duration := c.Duration("duration"); duration > 0 {
+		timeout = time.After(duration)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-timeout:
+			return nil
+		case <-ticker.C:
+			list, err := getAPI().GetEvents(model.AndFiql(filter, model.FiqlConstraint{Selector: "id", Operator: "=gt=", Value: strconv.Itoa(lastID)}.String()), 0)
+			if err != nil {
+				return err
+			}
+			id, err := printEvents(list.Events)
+			if err != nil {
+				return err
+			}
+			if id > lastID {
+				lastID = id
+			}
+		}
+	}
+}
+
+// printEvents prints a list of events sorted by ID in descending order, from the oldest to the newest, and returns the highest ID
+func printEvents(events []model.OnmsEvent) (int, error) {
+	for i := len(events) - 1; i >= 0; i-- {
+		if err := printEvent(events[i]); err != nil {
+			return 0, err
+		}
+	}
+	if len(events) == 0 {
+		return 0, nil
+	}
+	return events[0].ID, nil
+}
+
+// printEvent prints an event in a single line, either as text or as JSON
+// The other output formats print the whole event.
+func printEvent(e model.OnmsEvent) error {
+	switch {
+	case common.Output.Name == common.OutputJSON:
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	case common.Output.IsTable():
+		node := "-"
+		if e.NodeLabel != "" {
+			node = e.NodeLabel
+		} else if e.NodeID > 0 {
+			node = strconv.Itoa(e.NodeID)
+		}
+		fmt.Printf("%s #%d %s node=%s %s %s\n", common.GetDisplayTime(e.EventTime), e.ID, e.Severity, node, e.UEI, strings.Join(strings.Fields(e.LogMessage), " "))
+	default:
+		return common.Print(e, nil)
+	}
+	return nil
+}
+
+// buildEventsFilter builds a FIQL filter combining the filter flags
+func buildEventsFilter(c *cli.Context) (string, error) {
+	filters := []string{c.String("filter")}
+	if uei := c.String("uei"); uei != "" {
+		filters = append(filters, model.FiqlConstraint{Selector: "eventUei", Operator: "==", Value: uei}.String())
+	}
+	if node := c.String("node"); node != "" {
+		filters = append(filters, model.NodeFiqlConstraint(node).String())
+	}
+	if severity := c.String("severity"); severity != "" {
+		id := model.GetSeverityID(severity)
+		if id == 0 {
+			return "", fmt.Errorf("invalid severity %s", severity)
+		}
+		filters = append(filters, model.FiqlConstraint{Selector: "eventSeverity", Operator: "==", Value: strconv.Itoa(id)}.String())
+	}
+	return model.AndFiql(filters...), nil
+}
@@ -0,0 +1,72 @@
+package events
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/OpenNMS/onmsctl/common"
+	"github.com/OpenNMS/onmsctl/services"
+	"github.com/urfave/cli"
+)
+
+// TemplatesCliCommand the CLI command to manage event templates
+var TemplatesCliCommand = cli.Command{
+	Name:      "templates",
+	ShortName: "tmpl",
+	Usage:     "Manage event templates for the send command",
+	Description: "Event templates are YAML events with Go-template placeholders, stored as <name>.yaml on " + services.GetEventTemplatesDir() + ".\n" +
+		"   Variables are referenced with {{ .name }}, and the functions required, default and quote are available (e.x. {{ default \"/\" .mount | quote }}).\n" +
+		"   Templates on that directory take precedence over the built-in templates with the same name.",
+	Subcommands: []cli.Command{
+		{
+			Name:   "list",
+			Usage:  "List all the available event templates",
+			Action: listTemplates,
+		},
+		{
+			Name:         "show",
+			Usage:        "Shows the content of an event template",
+			ArgsUsage:    "<name>",
+			Action:       showTemplate,
+			BashComplete: templateNameBashComplete,
+		},
+	},
+}
+
+func listTemplates(c *cli.Context) error {
+	templates, err := getTemplatesAPI().GetTemplates()
+	if err != nil {
+		return err
+	}
+	table := &common.Table{
+		Headers:     []string{"Name", "Variables", "Description"},
+		WideHeaders: []string{"Source"},
+		Empty:       "There are no event templates",
+	}
+	for _, t := range templates {
+		table.AddRow(t.Name, strings.Join(t.Variables(), ", "), t.Description, t.Source)
+	}
+	return common.Print(templates, table)
+}
+
+func showTemplate(c *cli.Context) error {
+	t, err := getTemplatesAPI().GetTemplate(c.Args().First())
+	if err != nil {
+		return err
+	}
+	fmt.Print(t.Content)
+	return nil
+}
+
+func templateNameBashComplete(c *cli.Context) {
+	if c.NArg() > 0 {
+		return
+	}
+	templates, err := getTemplatesAPI().GetTemplates()
+	if err != nil {
+		return
+	}
+	for _, t := range templates {
+		fmt.Println(t.Name)
+	}
+}
@@ -0,0 +1,172 @@
+package events
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/OpenNMS/onmsctl/common"
+	"github.com/OpenNMS/onmsctl/model"
+	"github.com/OpenNMS/onmsctl/services"
+	"github.com/urfave/cli"
+
+	"gopkg.in/yaml.v2"
+)
+
+// TrapCliCommand the CLI command to send SNMP traps
+var TrapCliCommand = cli.Command{
+	Name:  "trap",
+	Usage: "Sends an SNMP trap, like snmptrap (for testing trapd)",
+	Description: "Sends an SNMPv1, SNMPv2c or SNMPv3 trap built from the flags, or from a YAML file with the trap definition.\n" +
+		"   Varbinds use the format oid=type:value, where type is one of " + model.TrapVarbindTypes.EnumAsString() + " (string by default).",
+	Action: sendTrap,
+	Flags:  trapFlags(),
+}
+
+func trapFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "host, H",
+			Usage: "The destination host (defaults to the OpenNMS server host)",
+		},
+		cli.IntFlag{
+			Name:  "port, p",
+			Value: services.DefaultTrapPort,
+			Usage: "The UDP port of the trap listener",
+		},
+		cli.StringFlag{
+			Name:  "file, f",
+			Usage: "External YAML file with the trap definition, including the SNMP configuration (use '-' for STDIN Pipe)",
+		},
+		cli.GenericFlag{
+			Name:  "version, v",
+			Value: &model.EnumValue{Enum: model.SNMPVersions.Enum, Default: model.SNMPVersions.Default},
+			Usage: "SNMP Version: " + model.SNMPVersions.EnumAsString(),
+		},
+		cli.StringFlag{
+			Name:  "community, c",
+			Value: "public",
+			Usage: "Community String for SNMPv1 or SNMPv2c",
+		},
+		cli.StringFlag{
+			Name:  "oid",
+			Usage: "The trap OID for SNMPv2c or SNMPv3",
+		},
+		cli.StringFlag{
+			Name:  "enterprise, e",
+			Usage: "The enterprise OID for SNMPv1",
+		},
+		cli.IntFlag{
+			Name:  "generic, g",
+			Value: 6,
+			Usage: "The generic trap type for SNMPv1 (6 means enterprise specific)",
+		},
+		cli.IntFlag{
+			Name:  "specific, s",
+			Usage: "The specific trap type for SNMPv1",
+		},
+		cli.StringFlag{
+			Name:  "agent",
+			Usage: "The agent address for SNMPv1 (defaults to the local address)",
+		},
+		cli.IntFlag{
+			Name:  "uptime, u",
+			Usage: "The sysUpTime of the agent in hundredths of a second",
+		},
+		cli.StringSliceFlag{
+			Name:  "varbind, b",
+			Usage: "A variable binding (e.x. -b '.1.3.6.1.2.1.2.2.1.1.1=integer:1')",
+		},
+		cli.StringFlag{
+			Name:  "securityName, sn",
+			Usage: "SNMPv3 Security Name",
+		},
+		cli.IntFlag{
+			Name:  "securityLevel, sl",
+			Value: model.SnmpNoAuthNoPriv,
+			Usage: "SNMPv3 Security Level: 1 noAuthNoPriv, 2: authNoPriv, 3: authPriv",
+		},
+		cli.GenericFlag{
+			Name:  "authProtocol, ap",
+			Value: &model.EnumValue{Enum: model.SNMPAuthProtocols.Enum},
+			Usage: "SNMPv3 Authentication Protocol: " + model.SNMPAuthProtocols.EnumAsString(),
+		},
+		cli.StringFlag{
+			Name:  "authPassPhrase, app",
+			Usage: "SNMPv3 Password Phrase for Authentication Protocol",
+		},
+		cli.GenericFlag{
+			Name:  "privProtocol, pp",
+			Value: &model.EnumValue{Enum: model.SNMPPrivProtocols.Enum},
+			Usage: "SNMPv3 Privacy Protocol: " + model.SNMPPrivProtocols.EnumAsString(),
+		},
+		cli.StringFlag{
+			Name:  "privPassPhrase, ppp",
+			Usage: "SNMPv3 Password Phrase for Privacy Protocol",
+		},
+		cli.StringFlag{
+			Name:  "engineID, eid",
+			Usage: "SNMPv3 Engine ID of the sender in hexadecimal (defaults to " + services.DefaultTrapEngineID + ")",
+		},
+		cli.StringFlag{
+			Name:  "contextName, ctx",
+			Usage: "SNMPv3 Context Name",
+		},
+	}
+}
+
+func sendTrap(c *cli.Context) error {
+	trap, err := buildTrap(c)
+	if err != nil {
+		return err
+	}
+	port := c.Int("port")
+	if !c.IsSet("port") && trap.SNMP.Port > 0 {
+		port = trap.SNMP.Port
+	}
+	address := net.JoinHostPort(getDestinationHost(c), strconv.Itoa(port))
+	if err := services.GetTrapsAPI().SendTrap(address, trap); err != nil {
+		return err
+	}
+	fmt.Printf("Trap sent to %s\n", address)
+	return nil
+}
+
+// buildTrap builds the trap from the YAML file when provided, or from the flags otherwise
+func buildTrap(c *cli.Context) (model.Trap, error) {
+	trap := model.Trap{}
+	if c.String("file") != "" {
+		data, err := common.ReadInput(c, 0)
+		if err != nil {
+			return trap, err
+		}
+		err = yaml.Unmarshal(data, &trap)
+		return trap, err
+	}
+	trap.SNMP = model.SnmpInfo{
+		Version:        c.String("version"),
+		Community:      c.String("community"),
+		SecurityName:   c.String("securityName"),
+		SecurityLevel:  c.Int("securityLevel"),
+		AuthProtocol:   c.String("authProtocol"),
+		AuthPassPhrase: c.String("authPassPhrase"),
+		PrivProtocol:   c.String("privProtocol"),
+		PrivPassPhrase: c.String("privPassPhrase"),
+		EngineID:       c.String("engineID"),
+		ContextName:    c.String("contextName"),
+	}
+	trap.TrapOID = c.String("oid")
+	trap.Enterprise = c.String("enterprise")
+	trap.Generic = c.Int("generic")
+	trap.Specific = c.Int("specific")
+	trap.AgentAddress = c.String("agent")
+	trap.Uptime = uint32(c.Int("uptime"))
+	for _, text := range c.StringSlice("varbind") {
+		varbind, err := model.ParseTrapVarbind(text)
+		if err != nil {
+			return trap, err
+		}
+		trap.Varbinds = append(trap.Varbinds, varbind)
+	}
+	return trap, nil
+}
@@ -1,14 +1,10 @@
 package info
 
 import (
-	"encoding/json"
-	"fmt"
-
-	"github.com/OpenNMS/onmsctl/model"
+	"github.com/OpenNMS/onmsctl/common"
 	"github.com/OpenNMS/onmsctl/rest"
+	"github.com/OpenNMS/onmsctl/services"
 	"github.com/urfave/cli"
-
-	"gopkg.in/yaml.v2"
 )
 
 // CliCommand the CLI command to provide server information
@@ -16,20 +12,10 @@ var CliCommand = cli.Command{
 	Name:  "info",
 	Usage: "Shows version information about the OpenNMS server",
 	Action: func(c *cli.Context) error {
-		jsonInfo, err := rest.Instance.Get("/rest/info")
-		if err != nil {
-			return err
-		}
-		info := model.OnmsInfo{}
-		err = json.Unmarshal(jsonInfo, &info)
-		if err != nil {
-			return err
-		}
-		data, err := yaml.Marshal(&info)
+		info, err := services.GetInfoAPI(rest.Instance).GetInfo()
 		if err != nil {
 			return err
 		}
-		fmt.Println(string(data))
-		return nil
+		return common.Print(info, nil)
 	},
 }
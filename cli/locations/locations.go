@@ -0,0 +1,180 @@
+package locations
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/OpenNMS/onmsctl/api"
+	"github.com/OpenNMS/onmsctl/common"
+	"github.com/OpenNMS/onmsctl/model"
+	"github.com/OpenNMS/onmsctl/rest"
+	"github.com/OpenNMS/onmsctl/services"
+	"github.com/urfave/cli"
+
+	"gopkg.in/yaml.v2"
+)
+
+// CliCommand the CLI command to manage monitoring locations
+var CliCommand = cli.Command{
+	Name:      "locations",
+	ShortName: "loc",
+	Usage:     "Manage monitoring locations",
+	Subcommands: []cli.Command{
+		{
+			Name:   "list",
+			Usage:  "List all the monitoring locations",
+			Action: listLocations,
+		},
+		{
+			Name:         "get",
+			Usage:        "Gets a monitoring location",
+			ArgsUsage:    "<name>",
+			Action:       showLocation,
+			BashComplete: locationNameBashComplete,
+		},
+		{
+			Name:      "apply",
+			Usage:     "Creates or updates a monitoring location from YAML",
+			ArgsUsage: "<yaml>",
+			Action:    applyLocation,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "file, f",
+					Usage: "External YAML file (use '-' for STDIN Pipe)",
+				},
+			},
+		},
+		{
+			Name:         "delete",
+			ShortName:    "del",
+			Usage:        "Deletes a monitoring location that is not used by requisition nodes",
+			ArgsUsage:    "<name>",
+			Action:       deleteLocation,
+			BashComplete: locationNameBashComplete,
+		},
+		{
+			Name:   "export",
+			Usage:  "Exports all the monitoring locations as a GeoJSON feature collection",
+			Action: exportLocations,
+		},
+	},
+}
+
+func listLocations(c *cli.Context) error {
+	list, err := getAPI().GetLocations()
+	if err != nil {
+		return err
+	}
+	table := &common.Table{
+		Headers:     []string{"Name", "Monitoring Area", "Priority", "Geolocation", "Polling Packages", "Collection Packages"},
+		WideHeaders: []string{"Latitude", "Longitude", "Tags"},
+		Empty:       "There are no monitoring locations",
+	}
+	for _, loc := range list.Locations {
+		table.AddRow(loc.LocationName, loc.MonitoringArea, loc.Priority, loc.GeoLocation,
+			strings.Join(loc.PollingPackageNames, ", "), strings.Join(loc.CollectionPackageNames, ", "),
+			loc.Latitude, loc.Longitude, strings.Join(loc.Tags, ", "))
+	}
+	return common.Print(list, table)
+}
+
+func showLocation(c *cli.Context) error {
+	if !c.Args().Present() {
+		return fmt.Errorf("location name required")
+	}
+	loc, err := getAPI().GetLocation(c.Args().First())
+	if err != nil {
+		return err
+	}
+	return common.Print(loc, nil)
+}
+
+func applyLocation(c *cli.Context) error {
+	data, err := common.ReadInput(c, 0)
+	if err != nil {
+		return err
+	}
+	loc := model.MonitoringLocation{}
+	if err = yaml.Unmarshal(data, &loc); err != nil {
+		return err
+	}
+	if err = loc.Validate(); err != nil {
+		return err
+	}
+	return getAPI().SetLocation(loc)
+}
+
+func deleteLocation(c *cli.Context) error {
+	if !c.Args().Present() {
+		return fmt.Errorf("location name required")
+	}
+	location := c.Args().First()
+	nodes, err := findRequisitionNodes(location)
+	if err != nil {
+		return err
+	}
+	if len(nodes) > 0 {
+		return fmt.Errorf("location %s is used by %d requisition nodes: %s", location, len(nodes), strings.Join(nodes, ", "))
+	}
+	return getAPI().DeleteLocation(location)
+}
+
+func exportLocations(c *cli.Context) error {
+	list, err := getAPI().GetLocations()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(list.ToGeoJSON(), "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// findRequisitionNodes gets the nodes (as foreignSource:foreignID) that use a given location on all the requisitions
+func findRequisitionNodes(location string) ([]string, error) {
+	list, err := services.GetProvisioningUtilsAPI(rest.Instance).GetRequisitionNames()
+	if err != nil {
+		return nil, err
+	}
+	reqAPI := services.GetRequisitionsAPI(rest.Instance)
+	nodes := make([]string, 0)
+	for _, name := range list.ForeignSources {
+		req, err := reqAPI.GetRequisition(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range req.Nodes {
+			// Nodes without a location are monitored from the Default location
+			nodeLocation := node.Location
+			if nodeLocation == "" {
+				nodeLocation = "Default"
+			}
+			if nodeLocation == location {
+				nodes = append(nodes, name+":"+node.ForeignID)
+			}
+		}
+	}
+	sort.Strings(nodes)
+	return nodes, nil
+}
+
+func locationNameBashComplete(c *cli.Context) {
+	if c.NArg() > 0 {
+		return
+	}
+	list, err := getAPI().GetLocations()
+	if err != nil {
+		return
+	}
+	for _, loc := range list.Locations {
+		fmt.Println(loc.LocationName)
+	}
+}
+
+func getAPI() api.MonitoringLocationsAPI {
+	return services.GetMonitoringLocationsAPI(rest.Instance)
+}
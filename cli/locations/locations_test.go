@@ -0,0 +1,125 @@
+package locations
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/OpenNMS/onmsctl/model"
+	"github.com/OpenNMS/onmsctl/rest"
+	"github.com/OpenNMS/onmsctl/test"
+	"gotest.tools/assert"
+)
+
+var mockLocation = model.MonitoringLocation{
+	LocationName:        "Apex",
+	MonitoringArea:      "North Carolina",
+	Priority:            100,
+	GeoLocation:         "Apex, NC",
+	Latitude:            35.7,
+	Longitude:           -78.8,
+	PollingPackageNames: []string{"example1"},
+}
+
+func createLocationsMockServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		switch req.Method + " " + req.URL.EscapedPath() {
+		case "GET /api/v2/monitoringLocations":
+			bytes, _ := json.Marshal(model.MonitoringLocationList{Count: 2, TotalCount: 2, Locations: []model.MonitoringLocation{mockLocation, {LocationName: "Default", MonitoringArea: "Default"}}})
+			res.Write(bytes)
+		case "GET /api/v2/monitoringLocations/Apex":
+			bytes, _ := json.Marshal(mockLocation)
+			res.Write(bytes)
+		case "GET /api/v2/monitoringLocations/US%2FEast":
+			bytes, _ := json.Marshal(model.MonitoringLocation{LocationName: "US/East", MonitoringArea: "US"})
+			res.Write(bytes)
+		case "POST /api/v2/monitoringLocations":
+			loc := model.MonitoringLocation{}
+			bytes, err := ioutil.ReadAll(req.Body)
+			assert.NilError(t, err)
+			assert.NilError(t, json.Unmarshal(bytes, &loc))
+			assert.DeepEqual(t, mockLocation, loc)
+		case "DELETE /api/v2/monitoringLocations/US%2FEast":
+		case "GET /rest/requisitionNames":
+			bytes, _ := json.Marshal(model.RequisitionsList{Count: 1, ForeignSources: []string{"Routers"}})
+			res.Write(bytes)
+		case "GET /rest/requisitions/Routers":
+			bytes, _ := json.Marshal(model.Requisition{Name: "Routers", Nodes: []model.RequisitionNode{
+				{ForeignID: "r2", NodeLabel: "r2", Location: "Apex"},
+				{ForeignID: "r1", NodeLabel: "r1", Location: "Apex"},
+				{ForeignID: "r3", NodeLabel: "r3"},
+			}})
+			res.Write(bytes)
+		default:
+			res.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	rest.Instance.URL = server.URL
+	return server
+}
+
+func TestListLocations(t *testing.T) {
+	app := test.CreateCli(CliCommand)
+	server := createLocationsMockServer(t)
+	defer server.Close()
+
+	err := app.Run([]string{app.Name, "locations", "list"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "loc", "get", "Apex"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "locations", "get", "US/East"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "locations", "get", "Unknown"})
+	assert.ErrorContains(t, err, "location Unknown not found")
+
+	err = app.Run([]string{app.Name, "locations", "export"})
+	assert.NilError(t, err)
+}
+
+func TestApplyLocation(t *testing.T) {
+	app := test.CreateCli(CliCommand)
+	server := createLocationsMockServer(t)
+	defer server.Close()
+
+	err := app.Run([]string{app.Name, "locations", "apply"})
+	assert.Error(t, err, "content cannot be empty")
+
+	err = app.Run([]string{app.Name, "locations", "apply", "monitoringArea: North Carolina"})
+	assert.Error(t, err, "location name cannot be empty")
+
+	yaml := `
+name: Apex
+monitoringArea: North Carolina
+priority: 100
+geoLocation: Apex, NC
+latitude: 35.7
+longitude: -78.8
+pollingPackageNames:
+- example1
+`
+	err = app.Run([]string{app.Name, "locations", "apply", yaml})
+	assert.NilError(t, err)
+}
+
+func TestDeleteLocation(t *testing.T) {
+	app := test.CreateCli(CliCommand)
+	server := createLocationsMockServer(t)
+	defer server.Close()
+
+	err := app.Run([]string{app.Name, "locations", "delete"})
+	assert.Error(t, err, "location name required")
+
+	err = app.Run([]string{app.Name, "locations", "delete", "Apex"})
+	assert.Error(t, err, "location Apex is used by 2 requisition nodes: Routers:r1, Routers:r2")
+
+	err = app.Run([]string{app.Name, "locations", "delete", "Default"})
+	assert.Error(t, err, "location Default is used by 1 requisition nodes: Routers:r3")
+
+	err = app.Run([]string{app.Name, "locations", "delete", "US/East"})
+	assert.NilError(t, err)
+}
@@ -0,0 +1,304 @@
+package maintenance
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/OpenNMS/onmsctl/api"
+	"github.com/OpenNMS/onmsctl/common"
+	"github.com/OpenNMS/onmsctl/model"
+	"github.com/OpenNMS/onmsctl/rest"
+	"github.com/OpenNMS/onmsctl/services"
+	"github.com/urfave/cli"
+)
+
+// The prefix of the scheduled outages created for maintenance windows
+const outagePrefix = "maintenance-"
+
+// CliCommand the CLI command to manage maintenance windows
+var CliCommand = cli.Command{
+	Name:  "maintenance",
+	Usage: "Manage maintenance windows for nodes (based on scheduled outages)",
+	Subcommands: []cli.Command{
+		{
+			Name:  "start",
+			Usage: "Starts a maintenance window for a node from now until the given duration",
+			Description: "Creates a specific scheduled outage for the node, and applies it to the given packages in the form target[:packageName] (e.x. poller:example1,notification).\n" +
+				"   Targets without a package use the default package of OpenNMS: example1 for poller and collector, and mib2 for threshold.\n" +
+				"   When the node already has an active maintenance window, use --extend to replace its end with the new one.\n" +
+				"   The dates of the outage don't include a time zone, and OpenNMS interprets them using the time zone of the server; use --timezone when it differs from the local one.",
+			Action: startMaintenance,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "node, n",
+					Usage: "The node ID or the foreignSource:foreignID combination",
+				},
+				cli.DurationFlag{
+					Name:  "duration, d",
+					Usage: "The duration of the maintenance window",
+					Value: time.Hour,
+				},
+				cli.StringFlag{
+					Name:  "packages, p",
+					Usage: "Comma separated list of packages where the maintenance window applies, in the form target[:packageName]; valid targets: " + model.ScheduledOutageTargets.EnumAsString(),
+					Value: defaultPackages,
+				},
+				cli.BoolFlag{
+					Name:  "extend, e",
+					Usage: "Extend the active maintenance window of the node instead of failing",
+				},
+				cli.BoolFlag{
+					Name:  "interfaces, i",
+					Usage: "Add the IP interfaces of the node to the maintenance window",
+				},
+				timezoneFlag,
+			},
+		},
+		{
+			Name:   "end",
+			Usage:  "Ends the maintenance window of a node",
+			Action: endMaintenance,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "node, n",
+					Usage: "The node ID or the foreignSource:foreignID combination",
+				},
+			},
+		},
+		{
+			Name:   "list",
+			Usage:  "List the active maintenance windows with the remaining time",
+			Action: listMaintenance,
+			Flags:  []cli.Flag{timezoneFlag},
+		},
+	},
+}
+
+// The time zone used by the OpenNMS server to interpret the dates of the scheduled outages
+var timezoneFlag = cli.StringFlag{
+	Name:  "timezone, z",
+	Usage: "The time zone of the OpenNMS server, as an IANA name (e.x. America/New_York); defaults to the local time zone",
+}
+
+// The packages used when none is given: stop the service polling and the notifications of the node
+const defaultPackages = "poller,notification"
+
+// outagePackage a package where a scheduled outage applies
+type outagePackage struct {
+	target string
+	name   string
+}
+
+func startMaintenance(c *cli.Context) error {
+	duration := c.Duration("duration")
+	if duration <= 0 {
+		return fmt.Errorf("duration must be greater than 0")
+	}
+	packages := make([]outagePackage, 0)
+	for _, text := range strings.Split(c.String("packages"), ",") {
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		target, packageName, err := model.ParseScheduledOutagePackage(text)
+		if err != nil {
+			return err
+		}
+		packages = append(packages, outagePackage{target, packageName})
+	}
+	if len(packages) == 0 {
+		return fmt.Errorf("at least one package is required, otherwise the maintenance window has no effect")
+	}
+	location, err := getLocation(c)
+	if err != nil {
+		return err
+	}
+	node, nodeID, err := getNode(c.String("node"))
+	if err != nil {
+		return err
+	}
+	now := time.Now().Truncate(time.Second)
+	outage := model.ScheduledOutage{
+		Name:  getOutageName(nodeID),
+		Type:  "specific",
+		Nodes: []model.ScheduledNode{{ID: nodeID}},
+		Times: []model.ScheduledTime{model.NewSpecificScheduledTimeIn(now, now.Add(duration), location)},
+	}
+	list, err := getAPI().GetScheduledOutages()
+	if err != nil {
+		return err
+	}
+	active := getActiveWindows(list, now, location)
+	extended := false
+	for _, w := range active {
+		if w.Name != outage.Name {
+			continue
+		}
+		if !c.Bool("extend") {
+			return fmt.Errorf("node %s already has a maintenance window that ends at %s; use --extend to replace its end", node.Label, w.Ends)
+		}
+		outage.Times[0].Begins = w.Begins
+		extended = true
+	}
+	if c.Bool("interfaces") {
+		list, err := services.GetNodesAPI(rest.Instance).GetIPInterfaces(node.ID)
+		if err != nil {
+			return err
+		}
+		for _, intf := range list.Interfaces {
+			outage.Interfaces = append(outage.Interfaces, model.ScheduledInterface{Address: intf.IPAddress})
+		}
+	}
+	if err = getAPI().SetScheduledOutage(outage); err != nil {
+		return err
+	}
+	for _, p := range packages {
+		if err = getAPI().AddToPackage(outage.Name, p.target, p.name); err != nil {
+			// Avoid leaving a partial maintenance window
+			if !extended {
+				getAPI().DeleteScheduledOutage(outage.Name)
+			}
+			return err
+		}
+	}
+	if extended {
+		fmt.Printf("Maintenance window %s extended for node %s, ends at %s\n", outage.Name, node.Label, outage.Times[0].Ends)
+		return nil
+	}
+	fmt.Printf("Maintenance window %s started for node %s, ends at %s\n", outage.Name, node.Label, outage.Times[0].Ends)
+	return nil
+}
+
+func endMaintenance(c *cli.Context) error {
+	node, nodeID, err := getNode(c.String("node"))
+	if err != nil {
+		return err
+	}
+	list, err := getAPI().GetScheduledOutages()
+	if err != nil {
+		return err
+	}
+	name := getOutageName(nodeID)
+	if list.GetOutage(name) == nil {
+		return fmt.Errorf("there is no maintenance window for node %s", node.Label)
+	}
+	if err = getAPI().DeleteScheduledOutage(name); err != nil {
+		return err
+	}
+	fmt.Printf("Maintenance window %s ended for node %s\n", name, node.Label)
+	return nil
+}
+
+// maintenanceWindow an active maintenance window
+type maintenanceWindow struct {
+	Name       string   `json:"name" yaml:"name"`
+	Nodes      []int    `json:"nodes" yaml:"nodes"`
+	Interfaces []string `json:"interfaces,omitempty" yaml:"interfaces,omitempty"`
+	Begins     string   `json:"begins" yaml:"begins"`
+	Ends       string   `json:"ends" yaml:"ends"`
+	Remaining  string   `json:"remaining" yaml:"remaining"`
+}
+
+func listMaintenance(c *cli.Context) error {
+	location, err := getLocation(c)
+	if err != nil {
+		return err
+	}
+	list, err := getAPI().GetScheduledOutages()
+	if err != nil {
+		return err
+	}
+	windows := getActiveWindows(list, time.Now(), location)
+	table := &common.Table{
+		Headers: []string{"Name", "Nodes", "Interfaces", "Begins", "Ends", "Remaining"},
+		Empty:   "There are no active maintenance windows",
+	}
+	for _, w := range windows {
+		nodes := make([]string, len(w.Nodes))
+		for i, id := range w.Nodes {
+			nodes[i] = strconv.Itoa(id)
+		}
+		table.AddRow(w.Name, strings.Join(nodes, ", "), len(w.Interfaces), w.Begins, w.Ends, w.Remaining)
+	}
+	return common.Print(windows, table)
+}
+
+// getActiveWindows gets the maintenance windows that haven't ended, sorted by end time
+// The dates of the outages are interpreted using the given time zone.
+func getActiveWindows(list *model.ScheduledOutageList, now time.Time, location *time.Location) []maintenanceWindow {
+	type entry struct {
+		window maintenanceWindow
+		ends   time.Time
+	}
+	entries := make([]entry, 0)
+	for _, o := range list.Outages {
+		if !strings.HasPrefix(o.Name, outagePrefix) || o.Type != "specific" || len(o.Times) == 0 {
+			continue
+		}
+		begins, ends, err := o.Times[0].GetSpecificRangeIn(location)
+		if err != nil || !ends.After(now) || begins.After(now) {
+			continue
+		}
+		w := maintenanceWindow{
+			Name:      o.Name,
+			Nodes:     make([]int, len(o.Nodes)),
+			Begins:    o.Times[0].Begins,
+			Ends:      o.Times[0].Ends,
+			Remaining: ends.Sub(now).Round(time.Second).String(),
+		}
+		for i, n := range o.Nodes {
+			w.Nodes[i] = n.ID
+		}
+		for _, intf := range o.Interfaces {
+			w.Interfaces = append(w.Interfaces, intf.Address)
+		}
+		entries = append(entries, entry{w, ends})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].ends.Before(entries[j].ends)
+	})
+	windows := make([]maintenanceWindow, len(entries))
+	for i, e := range entries {
+		windows[i] = e.window
+	}
+	return windows
+}
+
+// getNode gets a node and its numeric ID from the node criteria
+func getNode(nodeCriteria string) (*model.OnmsNode, int, error) {
+	if nodeCriteria == "" {
+		return nil, 0, fmt.Errorf("node ID or foreignSource:foreignID combination required")
+	}
+	node, err := services.GetNodesAPI(rest.Instance).GetNode(nodeCriteria)
+	if err != nil {
+		return nil, 0, err
+	}
+	nodeID, err := strconv.Atoi(node.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid ID %s for node %s", node.ID, nodeCriteria)
+	}
+	return node, nodeID, nil
+}
+
+// getLocation gets the time zone of the server from the timezone flag
+func getLocation(c *cli.Context) (*time.Location, error) {
+	name := c.String("timezone")
+	if name == "" {
+		return time.Local, nil
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %s", name)
+	}
+	return location, nil
+}
+
+func getOutageName(nodeID int) string {
+	return outagePrefix + strconv.Itoa(nodeID)
+}
+
+func getAPI() api.ScheduledOutagesAPI {
+	return services.GetScheduledOutagesAPI(rest.Instance)
+}
@@ -0,0 +1,164 @@
+package maintenance
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/OpenNMS/onmsctl/model"
+	"github.com/OpenNMS/onmsctl/rest"
+	"github.com/OpenNMS/onmsctl/test"
+	"gotest.tools/assert"
+)
+
+// maintenanceMockServer simulates the scheduled outages ReST API, keeping the outages in memory
+type maintenanceMockServer struct {
+	mutex    sync.Mutex
+	outages  map[string]model.ScheduledOutage
+	packages []string
+}
+
+func (m *maintenanceMockServer) start(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+		switch req.Method + " " + req.URL.Path {
+		case "GET /api/v2/nodes/Servers:srv01":
+			bytes, _ := json.Marshal(model.OnmsNode{ID: "10", Label: "srv01"})
+			res.Write(bytes)
+		case "GET /api/v2/nodes/10/ipinterfaces":
+			bytes, _ := json.Marshal(model.OnmsIPInterfaceList{Count: 1, Interfaces: []model.OnmsIPInterface{{IPAddress: "10.0.0.1"}}})
+			res.Write(bytes)
+		case "GET /rest/sched-outages":
+			list := model.ScheduledOutageList{Outages: []model.ScheduledOutage{}}
+			for _, o := range m.outages {
+				list.Outages = append(list.Outages, o)
+			}
+			bytes, _ := json.Marshal(list)
+			res.Write(bytes)
+		case "POST /rest/sched-outages":
+			outage := model.ScheduledOutage{}
+			bytes, err := ioutil.ReadAll(req.Body)
+			assert.NilError(t, err)
+			assert.NilError(t, json.Unmarshal(bytes, &outage))
+			m.outages[outage.Name] = outage
+		case "DELETE /rest/sched-outages/maintenance-10":
+			delete(m.outages, "maintenance-10")
+		case "PUT /rest/sched-outages/maintenance-10/pollerd/example1", "PUT /rest/sched-outages/maintenance-10/notifd":
+			m.packages = append(m.packages, req.URL.Path)
+		default:
+			res.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	rest.Instance.URL = server.URL
+	return server
+}
+
+func TestMaintenance(t *testing.T) {
+	var err error
+	app := test.CreateCli(CliCommand)
+	mock := &maintenanceMockServer{outages: make(map[string]model.ScheduledOutage)}
+	server := mock.start(t)
+	defer server.Close()
+
+	err = app.Run([]string{app.Name, "maintenance", "start"})
+	assert.Error(t, err, "node ID or foreignSource:foreignID combination required")
+
+	err = app.Run([]string{app.Name, "maintenance", "start", "-n", "Servers:srv01", "-d", "0s"})
+	assert.Error(t, err, "duration must be greater than 0")
+
+	err = app.Run([]string{app.Name, "maintenance", "start", "-n", "Servers:srv01", "-z", "Mars/Olympus"})
+	assert.Error(t, err, "invalid time zone Mars/Olympus")
+
+	err = app.Run([]string{app.Name, "maintenance", "start", "-n", "Servers:srv01", "-d", "2h", "-p", "poller,notifications", "-i", "-z", "UTC"})
+	assert.NilError(t, err)
+	outage, ok := mock.outages["maintenance-10"]
+	assert.Assert(t, ok)
+	assert.Equal(t, "specific", outage.Type)
+	assert.DeepEqual(t, []model.ScheduledNode{{ID: 10}}, outage.Nodes)
+	assert.DeepEqual(t, []model.ScheduledInterface{{Address: "10.0.0.1"}}, outage.Interfaces)
+	begins, ends, err := outage.Times[0].GetSpecificRangeIn(time.UTC)
+	assert.NilError(t, err)
+	assert.Equal(t, 2*time.Hour, ends.Sub(begins))
+	assert.Assert(t, time.Since(begins) < time.Minute)
+	assert.DeepEqual(t, []string{"/rest/sched-outages/maintenance-10/pollerd/example1", "/rest/sched-outages/maintenance-10/notifd"}, mock.packages)
+
+	err = app.Run([]string{app.Name, "maintenance", "start", "-n", "Servers:srv01", "-d", "3h", "-z", "UTC"})
+	assert.ErrorContains(t, err, "node srv01 already has a maintenance window that ends at ")
+	assert.DeepEqual(t, outage, mock.outages["maintenance-10"])
+
+	err = app.Run([]string{app.Name, "maintenance", "start", "-n", "Servers:srv01", "-d", "3h", "-z", "UTC", "--extend"})
+	assert.NilError(t, err)
+	extended := mock.outages["maintenance-10"]
+	assert.Equal(t, outage.Times[0].Begins, extended.Times[0].Begins)
+	_, ends, err = extended.Times[0].GetSpecificRangeIn(time.UTC)
+	assert.NilError(t, err)
+	assert.Assert(t, ends.Sub(begins) >= 3*time.Hour)
+
+	err = app.Run([]string{app.Name, "maintenance", "list", "-z", "UTC"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "maintenance", "end", "-n", "Servers:srv01"})
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(mock.outages))
+
+	err = app.Run([]string{app.Name, "maintenance", "end", "-n", "Servers:srv01"})
+	assert.Error(t, err, "there is no maintenance window for node srv01")
+}
+
+func TestStartMaintenanceWithInvalidPackage(t *testing.T) {
+	app := test.CreateCli(CliCommand)
+	mock := &maintenanceMockServer{outages: make(map[string]model.ScheduledOutage)}
+	server := mock.start(t)
+	defer server.Close()
+
+	err := app.Run([]string{app.Name, "maintenance", "start", "-n", "Servers:srv01", "-p", "collector:unknown"})
+	assert.ErrorContains(t, err, "either scheduled outage maintenance-10 or collector package unknown not found")
+	assert.Equal(t, 0, len(mock.outages))
+}
+
+func TestStartMaintenanceWithoutPackages(t *testing.T) {
+	app := test.CreateCli(CliCommand)
+	mock := &maintenanceMockServer{outages: make(map[string]model.ScheduledOutage)}
+	server := mock.start(t)
+	defer server.Close()
+
+	err := app.Run([]string{app.Name, "maintenance", "start", "-n", "Servers:srv01", "-p", ""})
+	assert.Error(t, err, "at least one package is required, otherwise the maintenance window has no effect")
+	assert.Equal(t, 0, len(mock.outages))
+
+	err = app.Run([]string{app.Name, "maintenance", "start", "-n", "Servers:srv01"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []string{"/rest/sched-outages/maintenance-10/pollerd/example1", "/rest/sched-outages/maintenance-10/notifd"}, mock.packages)
+}
+
+func TestGetActiveWindows(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	window := func(name string, begins time.Duration, ends time.Duration) model.ScheduledOutage {
+		return model.ScheduledOutage{
+			Name:  name,
+			Type:  "specific",
+			Nodes: []model.ScheduledNode{{ID: 1}},
+			Times: []model.ScheduledTime{model.NewSpecificScheduledTime(now.Add(begins), now.Add(ends))},
+		}
+	}
+	list := &model.ScheduledOutageList{
+		Outages: []model.ScheduledOutage{
+			window("maintenance-1", -time.Hour, 2*time.Hour),
+			window("maintenance-2", -time.Hour, 30*time.Minute),
+			window("maintenance-3", -2*time.Hour, -time.Hour),
+			window("maintenance-4", time.Hour, 2*time.Hour),
+			window("Weekend", -time.Hour, time.Hour),
+		},
+	}
+	windows := getActiveWindows(list, now, time.Local)
+	assert.Equal(t, 2, len(windows))
+	assert.Equal(t, "maintenance-2", windows[0].Name)
+	assert.Equal(t, "30m0s", windows[0].Remaining)
+	assert.Equal(t, "maintenance-1", windows[1].Name)
+	assert.Equal(t, "2h0m0s", windows[1].Remaining)
+}
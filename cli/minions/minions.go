@@ -0,0 +1,136 @@
+package minions
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/OpenNMS/onmsctl/api"
+	"github.com/OpenNMS/onmsctl/common"
+	"github.com/OpenNMS/onmsctl/model"
+	"github.com/OpenNMS/onmsctl/rest"
+	"github.com/OpenNMS/onmsctl/services"
+	"github.com/urfave/cli"
+)
+
+// CliCommand the CLI command to manage Minions
+var CliCommand = cli.Command{
+	Name:  "minions",
+	Usage: "Manage Minions",
+	Subcommands: []cli.Command{
+		{
+			Name:        "list",
+			Usage:       "List all the Minions",
+			Description: "Minions on a location without a monitoring location definition are flagged, as well as stale Minions when using --stale.",
+			Action:      listMinions,
+			Flags: []cli.Flag{
+				cli.DurationFlag{
+					Name:  "stale, s",
+					Usage: "Only show Minions that didn't check in during the given duration (e.x. 10m)",
+				},
+			},
+		},
+		{
+			Name:         "get",
+			Usage:        "Gets a Minion",
+			ArgsUsage:    "<id>",
+			Action:       showMinion,
+			BashComplete: minionBashComplete,
+		},
+		{
+			Name:         "delete",
+			ShortName:    "del",
+			Usage:        "Deletes a Minion",
+			ArgsUsage:    "<id>",
+			Action:       deleteMinion,
+			BashComplete: minionBashComplete,
+		},
+	},
+}
+
+func listMinions(c *cli.Context) error {
+	stale := c.Duration("stale")
+	if stale < 0 {
+		return fmt.Errorf("stale duration cannot be negative")
+	}
+	list, err := getAPI().GetMinions()
+	if err != nil {
+		return err
+	}
+	knownLocations, err := getKnownLocations()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if stale > 0 {
+		minions := make([]model.OnmsMinion, 0)
+		for _, m := range list.Minions {
+			if m.IsStale(stale, now) {
+				minions = append(minions, m)
+			}
+		}
+		list.Minions = minions
+		list.Count = len(minions)
+	}
+	table := &common.Table{
+		Headers:     []string{"ID", "Location", "Status", "Last Updated", "Issues"},
+		WideHeaders: []string{"Label", "Type"},
+		Empty:       "There are no Minions",
+	}
+	if stale > 0 {
+		table.Empty = "There are no stale Minions"
+	}
+	for _, m := range list.Minions {
+		issues := make([]string, 0)
+		if !knownLocations[m.Location] {
+			issues = append(issues, "unknown location")
+		}
+		if stale > 0 {
+			issues = append(issues, "stale")
+		}
+		table.AddRow(m.ID, m.Location, m.Status, common.GetDisplayTime(m.LastUpdated), strings.Join(issues, ", "), m.Label, m.Type)
+	}
+	return common.Print(list, table)
+}
+
+// getKnownLocations gets the names of every monitoring location defined on the server
+func getKnownLocations() (map[string]bool, error) {
+	locations, err := services.GetMonitoringLocationsAPI(rest.Instance).GetLocations()
+	if err != nil {
+		return nil, err
+	}
+	knownLocations := make(map[string]bool)
+	for _, loc := range locations.Locations {
+		knownLocations[loc.LocationName] = true
+	}
+	return knownLocations, nil
+}
+
+func showMinion(c *cli.Context) error {
+	minion, err := getAPI().GetMinion(c.Args().First())
+	if err != nil {
+		return err
+	}
+	return common.Print(minion, nil)
+}
+
+func deleteMinion(c *cli.Context) error {
+	return getAPI().DeleteMinion(c.Args().First())
+}
+
+func minionBashComplete(c *cli.Context) {
+	if c.NArg() > 0 {
+		return
+	}
+	list, err := getAPI().GetMinions()
+	if err != nil {
+		return
+	}
+	for _, m := range list.Minions {
+		fmt.Println(m.ID)
+	}
+}
+
+func getAPI() api.MinionsAPI {
+	return services.GetMinionsAPI(rest.Instance)
+}
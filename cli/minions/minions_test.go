@@ -0,0 +1,110 @@
+package minions
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/OpenNMS/onmsctl/model"
+	"github.com/OpenNMS/onmsctl/rest"
+	"github.com/OpenNMS/onmsctl/test"
+	"gotest.tools/assert"
+)
+
+func createMinionsMockServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		switch req.Method + " " + req.URL.Path {
+		case "GET /api/v2/minions":
+			list := model.OnmsMinionList{Count: 2, TotalCount: 2, Minions: []model.OnmsMinion{
+				{ID: "minion-01", Location: "Apex", Status: "UP", LastUpdated: &model.Time{Time: time.Now()}},
+				{ID: "minion-02", Location: "Durham", Status: "DOWN", LastUpdated: &model.Time{Time: time.Now().Add(-time.Hour)}},
+			}}
+			bytes, _ := json.Marshal(list)
+			res.Write(bytes)
+		case "GET /api/v2/minions/minion-01":
+			bytes, _ := json.Marshal(model.OnmsMinion{ID: "minion-01", Location: "Apex", Status: "UP"})
+			res.Write(bytes)
+		case "GET /api/v2/monitoringLocations":
+			// Durham is on the second page
+			offset, _ := strconv.Atoi(req.URL.Query().Get("offset"))
+			limit, _ := strconv.Atoi(req.URL.Query().Get("limit"))
+			list := model.MonitoringLocationList{TotalCount: 15, Offset: offset}
+			for i := offset; i < offset+limit && i < 15; i++ {
+				name := fmt.Sprintf("Loc%02d", i)
+				switch i {
+				case 0:
+					name = "Apex"
+				case 12:
+					name = "Durham"
+				}
+				list.Locations = append(list.Locations, model.MonitoringLocation{LocationName: name})
+			}
+			list.Count = len(list.Locations)
+			bytes, _ := json.Marshal(list)
+			res.Write(bytes)
+		case "DELETE /api/v2/minions/minion-02":
+		default:
+			res.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	rest.Instance.URL = server.URL
+	return server
+}
+
+func TestListMinions(t *testing.T) {
+	app := test.CreateCli(CliCommand)
+	server := createMinionsMockServer(t)
+	defer server.Close()
+
+	err := app.Run([]string{app.Name, "minions", "list"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "minions", "list", "--stale", "10m"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "minions", "list", "--stale", "-10m"})
+	assert.Error(t, err, "stale duration cannot be negative")
+}
+
+func TestGetKnownLocations(t *testing.T) {
+	server := createMinionsMockServer(t)
+	defer server.Close()
+
+	locations, err := getKnownLocations()
+	assert.NilError(t, err)
+	assert.Equal(t, 15, len(locations))
+	assert.Assert(t, locations["Apex"])
+	assert.Assert(t, locations["Durham"])
+	assert.Assert(t, !locations["Cary"])
+}
+
+func TestGetMinion(t *testing.T) {
+	app := test.CreateCli(CliCommand)
+	server := createMinionsMockServer(t)
+	defer server.Close()
+
+	err := app.Run([]string{app.Name, "minions", "get"})
+	assert.Error(t, err, "minion ID required")
+
+	err = app.Run([]string{app.Name, "minions", "get", "minion-01"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "minions", "get", "minion-03"})
+	assert.ErrorContains(t, err, "minion minion-03 not found")
+}
+
+func TestDeleteMinion(t *testing.T) {
+	app := test.CreateCli(CliCommand)
+	server := createMinionsMockServer(t)
+	defer server.Close()
+
+	err := app.Run([]string{app.Name, "minions", "delete", "minion-02"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "minions", "del", "minion-03"})
+	assert.ErrorContains(t, err, "minion minion-03 not found")
+}
@@ -3,11 +3,10 @@ package nodes
 import (
 	"fmt"
 
+	"github.com/OpenNMS/onmsctl/common"
 	"github.com/OpenNMS/onmsctl/rest"
 	"github.com/OpenNMS/onmsctl/services"
 	"github.com/urfave/cli"
-
-	"gopkg.in/yaml.v2"
 )
 
 // AssetsCliCommand the CLI command to manage nodes
@@ -61,9 +60,7 @@ func getAssets(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	text, _ := yaml.Marshal(record)
-	fmt.Println(string(text))
-	return nil
+	return common.Print(record, nil)
 }
 
 func deleteAssetField(c *cli.Context) error {
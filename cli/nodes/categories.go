@@ -45,17 +45,14 @@ func getCategories(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	if len(list) == 0 {
-		fmt.Println("There are no categories")
-		return nil
+	table := &common.Table{
+		Headers: []string{"ID", "Name"},
+		Empty:   "There are no categories",
 	}
-	writer := common.NewTableWriter()
-	fmt.Fprintln(writer, "ID\tName")
 	for _, cat := range list {
-		fmt.Fprintf(writer, "%d\t%s\n", cat.ID, cat.Name)
+		table.AddRow(cat.ID, cat.Name)
 	}
-	writer.Flush()
-	return nil
+	return common.Print(list, table)
 }
 
 func deleteCategory(c *cli.Context) error {
@@ -122,17 +122,14 @@ func getIPInterfaces(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	if len(list.Interfaces) == 0 {
-		fmt.Println("There are no IP interfaces")
-		return nil
+	table := &common.Table{
+		Headers: []string{"ID", "IP Address", "ifIndex", "Is Managed", "SNMP Primary", "Is Down"},
+		Empty:   "There are no IP interfaces",
 	}
-	writer := common.NewTableWriter()
-	fmt.Fprintln(writer, "ID\tIP Address\tifIndex\tIs Managed\tSNMP Primary\tIs Down")
 	for _, n := range list.Interfaces {
-		fmt.Fprintf(writer, "%s\t%s\t%d\t%s\t%s\t%v\n", n.ID, n.IPAddress, n.IfIndex, n.IsManaged, n.SnmpPrimary, n.IsDown)
+		table.AddRow(n.ID, n.IPAddress, n.IfIndex, n.IsManaged, n.SnmpPrimary, n.IsDown)
 	}
-	writer.Flush()
-	return nil
+	return common.Print(list, table)
 }
 
 func deleteIPInterface(c *cli.Context) error {
@@ -178,17 +175,14 @@ func listInterfaceMetadata(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	if len(meta) == 0 {
-		fmt.Println("There is no metadata")
-		return nil
+	table := &common.Table{
+		Headers: []string{"Context", "Key", "Value"},
+		Empty:   "There is no metadata",
 	}
-	writer := common.NewTableWriter()
-	fmt.Fprintln(writer, "Context\tKey\tValue")
 	for _, m := range meta {
-		fmt.Fprintf(writer, "%s\t%s\t%s\n", m.Context, m.Key, m.Value)
+		table.AddRow(m.Context, m.Key, m.Value)
 	}
-	writer.Flush()
-	return nil
+	return common.Print(meta, table)
 }
 
 func setInterfaceMetadata(c *cli.Context) error {
@@ -142,17 +142,15 @@ func getNodes(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	if len(list.Nodes) == 0 {
-		fmt.Println("There are no nodes")
-		return nil
+	table := &common.Table{
+		Headers:     []string{"Node ID", "Node Label", "Foreign Source", "Foreign ID", "SNMP sysObjectID"},
+		WideHeaders: []string{"Location", "SNMP sysName", "SNMP sysLocation"},
+		Empty:       "There are no nodes",
 	}
-	writer := common.NewTableWriter()
-	fmt.Fprintln(writer, "Node ID\tNode Label\tForeign Source\tForeign ID\tSNMP sysObjectID")
 	for _, n := range list.Nodes {
-		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n", n.ID, n.Label, n.ForeignSource, n.ForeignID, n.SysObjectID)
+		table.AddRow(n.ID, n.Label, n.ForeignSource, n.ForeignID, n.SysObjectID, n.Location, n.SysName, n.SysLocation)
 	}
-	writer.Flush()
-	return nil
+	return common.Print(list, table)
 }
 
 func deleteNode(c *cli.Context) error {
@@ -208,17 +206,14 @@ func listNodeMetadata(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	if len(meta) == 0 {
-		fmt.Println("There is no metadata")
-		return nil
+	table := &common.Table{
+		Headers: []string{"Context", "Key", "Value"},
+		Empty:   "There is no metadata",
 	}
-	writer := common.NewTableWriter()
-	fmt.Fprintln(writer, "Context\tKey\tValue")
 	for _, m := range meta {
-		fmt.Fprintf(writer, "%s\t%s\t%s\n", m.Context, m.Key, m.Value)
+		table.AddRow(m.Context, m.Key, m.Value)
 	}
-	writer.Flush()
-	return nil
+	return common.Print(meta, table)
 }
 
 func setNodeMetadata(c *cli.Context) error {
@@ -102,17 +102,14 @@ func getServices(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	if len(list.Services) == 0 {
-		fmt.Println("There are no monitored services")
-		return nil
+	table := &common.Table{
+		Headers: []string{"ID", "Service Name", "Is Down", "Last Good", "Last Fail"},
+		Empty:   "There are no monitored services",
 	}
-	writer := common.NewTableWriter()
-	fmt.Fprintln(writer, "ID\tService Name\tIs Down\tLast Good\tLast Fail")
 	for _, n := range list.Services {
-		fmt.Fprintf(writer, "%d\t%s\t%v\t%s\t%s\n", n.ID, n.ServiceType.Name, n.IsDown, n.LastGood, n.LastFail)
+		table.AddRow(n.ID, n.ServiceType.Name, n.IsDown, n.LastGood, n.LastFail)
 	}
-	writer.Flush()
-	return nil
+	return common.Print(list, table)
 }
 
 func deleteService(c *cli.Context) error {
@@ -172,17 +169,14 @@ func listServiceMetadata(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	if len(meta) == 0 {
-		fmt.Println("There is no metadata")
-		return nil
+	table := &common.Table{
+		Headers: []string{"Context", "Key", "Value"},
+		Empty:   "There is no metadata",
 	}
-	writer := common.NewTableWriter()
-	fmt.Fprintln(writer, "Context\tKey\tValue")
 	for _, m := range meta {
-		fmt.Fprintf(writer, "%s\t%s\t%s\n", m.Context, m.Key, m.Value)
+		table.AddRow(m.Context, m.Key, m.Value)
 	}
-	writer.Flush()
-	return nil
+	return common.Print(meta, table)
 }
 
 func setServiceMetadata(c *cli.Context) error {
@@ -96,17 +96,14 @@ func getSnmpInterfaces(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	if len(list.Interfaces) == 0 {
-		fmt.Println("There are no SNMP interfaces")
-		return nil
+	table := &common.Table{
+		Headers: []string{"ID", "ifIndex", "ifDescr", "ifName", "ifAlias", "ifType", "ifOperStatus", "ifAdminStatus", "Collect", "Poll"},
+		Empty:   "There are no SNMP interfaces",
 	}
-	writer := common.NewTableWriter()
-	fmt.Fprintln(writer, "ID\tifIndex\tifDescr\tifName\tifAlias\tifType\tifOperStatus\tifAdminStatus\tCollect\tPoll")
 	for _, n := range list.Interfaces {
-		fmt.Fprintf(writer, "%d\t%d\t%s\t%s\t%s\t%d\t%d\t%d\t%s\t%s\n", n.ID, n.IfIndex, n.IfDescr, n.IfName, n.IfAlias, n.IfType, n.IfOperStatus, n.IfAdminStatus, n.CollectFlag, n.PollFlag)
+		table.AddRow(n.ID, n.IfIndex, n.IfDescr, n.IfName, n.IfAlias, n.IfType, n.IfOperStatus, n.IfAdminStatus, n.CollectFlag, n.PollFlag)
 	}
-	writer.Flush()
-	return nil
+	return common.Print(list, table)
 }
 
 func deleteSnmpInterface(c *cli.Context) error {
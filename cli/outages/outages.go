@@ -0,0 +1,159 @@
+package outages
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/OpenNMS/onmsctl/api"
+	"github.com/OpenNMS/onmsctl/common"
+	"github.com/OpenNMS/onmsctl/model"
+	"github.com/OpenNMS/onmsctl/rest"
+	"github.com/OpenNMS/onmsctl/services"
+	"github.com/urfave/cli"
+)
+
+// CliCommand the CLI command to manage outages
+var CliCommand = cli.Command{
+	Name:  "outages",
+	Usage: "Manage outages",
+	Subcommands: []cli.Command{
+		{
+			Name:   "list",
+			Usage:  "List current and historical outages",
+			Action: listOutages,
+			Flags: append(filterFlags(0),
+				cli.BoolFlag{
+					Name:  "current, c",
+					Usage: "Only show the outages that haven't been resolved",
+				},
+				cli.IntFlag{
+					Name:  "limit, l",
+					Usage: "Maximum number of outages to display (0 for all)",
+					Value: 10,
+				},
+			),
+		},
+		{
+			Name:        "stats",
+			Usage:       "Shows the downtime and availability per node and service",
+			Description: "Aggregates the outages within the period defined by --since; services without outages are 100% available and are not listed.",
+			Action:      showOutageStats,
+			Flags:       filterFlags(30 * 24 * time.Hour),
+		},
+		ScheduleCliCommand,
+	},
+}
+
+func filterFlags(since time.Duration) []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "filter, f",
+			Usage: "The filter to apply in FIQL format",
+		},
+		cli.StringFlag{
+			Name:  "node, n",
+			Usage: "The node ID or node label of the outages",
+		},
+		cli.StringFlag{
+			Name:  "location, L",
+			Usage: "The monitoring location of the nodes",
+		},
+		cli.DurationFlag{
+			Name:  "since, s",
+			Usage: "Only consider the outages that were active during the given time until now (e.x. 24h)",
+			Value: since,
+		},
+	}
+}
+
+func listOutages(c *cli.Context) error {
+	filter, err := buildOutagesFilter(c, time.Now())
+	if err != nil {
+		return err
+	}
+	if c.Bool("current") {
+		filter = model.AndFiql(filter, model.FiqlConstraint{Selector: "ifRegainedService", Operator: "==", Value: `\u0000`}.String())
+	}
+	list, err := getOutagesAPI().GetOutages(filter, c.Int("limit"))
+	if err != nil {
+		return err
+	}
+	table := &common.Table{
+		Headers:     []string{"ID", "Node", "IP Address", "Service", "Lost", "Regained", "Duration"},
+		WideHeaders: []string{"Location", "Foreign Source", "Foreign ID"},
+		Empty:       "There are no outages",
+	}
+	now := time.Now()
+	for _, o := range list.Outages {
+		table.AddRow(o.ID, getNodeLabel(o), o.IPAddress, o.GetServiceName(), common.GetDisplayTime(o.ServiceLostTime), common.GetDisplayTime(o.ServiceRegainedTime),
+			o.GetDuration(now).Round(time.Second), o.Location, o.ForeignSource, o.ForeignID)
+	}
+	return common.Print(list, table)
+}
+
+func showOutageStats(c *cli.Context) error {
+	end := time.Now()
+	since := c.Duration("since")
+	if since <= 0 {
+		return fmt.Errorf("the period defined by --since must be greater than 0")
+	}
+	filter, err := buildOutagesFilter(c, end)
+	if err != nil {
+		return err
+	}
+	list, err := getOutagesAPI().GetOutages(filter, 0)
+	if err != nil {
+		return err
+	}
+	start := end.Add(-since)
+	stats := model.ComputeOutageStats(list.Outages, start, end)
+	table := &common.Table{
+		Headers: []string{"Node", "Service", "Outages", "Downtime", "Availability"},
+		Empty:   "There are no outages",
+	}
+	for _, s := range stats {
+		downtime := time.Duration(s.DowntimeSeconds * float64(time.Second)).Round(time.Second)
+		table.AddRow(s.NodeLabel, s.ServiceName, s.Outages, downtime, fmt.Sprintf("%.3f%%", s.Availability))
+	}
+	if common.Output.IsTable() {
+		fmt.Printf("Period: %s - %s\n\n", start.Format(common.DisplayTimeFormat), end.Format(common.DisplayTimeFormat))
+	}
+	return common.Print(stats, table)
+}
+
+// buildOutagesFilter builds the FIQL expression based on the filter flags
+// With --since, an outage is considered when it hasn't been resolved, or when it was resolved within the period.
+func buildOutagesFilter(c *cli.Context, now time.Time) (string, error) {
+	filters := []string{c.String("filter")}
+	if node := c.String("node"); node != "" {
+		filters = append(filters, model.NodeFiqlConstraint(node).String())
+	}
+	if location := c.String("location"); location != "" {
+		filters = append(filters, model.FiqlConstraint{Selector: "node.location.locationName", Operator: "==", Value: location}.String())
+	}
+	if since := c.Duration("since"); since != 0 {
+		if since < 0 {
+			return "", fmt.Errorf("the period defined by --since cannot be negative")
+		}
+		start := now.Add(-since).Format(model.FiqlTimeFormat)
+		open := model.FiqlConstraint{Selector: "ifRegainedService", Operator: "==", Value: `\u0000`}
+		regained := model.FiqlConstraint{Selector: "ifRegainedService", Operator: "=ge=", Value: start}
+		filters = append(filters, open.String()+","+regained.String())
+	}
+	return model.AndFiql(filters...), nil
+}
+
+func getNodeLabel(outage model.OnmsOutage) string {
+	if outage.NodeLabel != "" {
+		return outage.NodeLabel
+	}
+	if outage.NodeID > 0 {
+		return strconv.Itoa(outage.NodeID)
+	}
+	return "-"
+}
+
+func getOutagesAPI() api.OutagesAPI {
+	return services.GetOutagesAPI(rest.Instance)
+}
@@ -0,0 +1,77 @@
+package outages
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/OpenNMS/onmsctl/model"
+	"github.com/OpenNMS/onmsctl/rest"
+	"github.com/OpenNMS/onmsctl/test"
+	"gotest.tools/assert"
+)
+
+func createOutagesMockServer(t *testing.T, expectedFilter string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/api/v2/outages", req.URL.Path)
+		filter := req.URL.Query().Get("_s")
+		assert.Assert(t, strings.HasPrefix(filter, expectedFilter), "unexpected filter %s", filter)
+		now := time.Now()
+		list := model.OnmsOutageList{Count: 2, TotalCount: 2, Outages: []model.OnmsOutage{
+			{
+				ID: 2, NodeID: 1, NodeLabel: "srv01", IPAddress: "10.0.0.1",
+				MonitoredService: &model.OnmsMonitoredService{ServiceType: &model.OnmsServiceType{Name: "ICMP"}},
+				ServiceLostTime:  &model.Time{Time: now.Add(-time.Hour)},
+			},
+			{
+				ID: 1, NodeID: 1, NodeLabel: "srv01", IPAddress: "10.0.0.1",
+				MonitoredService:    &model.OnmsMonitoredService{ServiceType: &model.OnmsServiceType{Name: "ICMP"}},
+				ServiceLostTime:     &model.Time{Time: now.Add(-3 * time.Hour)},
+				ServiceRegainedTime: &model.Time{Time: now.Add(-2 * time.Hour)},
+			},
+		}}
+		bytes, _ := json.Marshal(list)
+		res.Write(bytes)
+	}))
+	rest.Instance.URL = server.URL
+	return server
+}
+
+func TestListOutages(t *testing.T) {
+	var err error
+	app := test.CreateCli(CliCommand)
+
+	server := createOutagesMockServer(t, "")
+	err = app.Run([]string{app.Name, "outages", "list"})
+	assert.NilError(t, err)
+	server.Close()
+
+	server = createOutagesMockServer(t, `(node.label==srv01);(ifRegainedService==\u0000)`)
+	err = app.Run([]string{app.Name, "outages", "list", "--node", "srv01", "--current"})
+	assert.NilError(t, err)
+	server.Close()
+
+	server = createOutagesMockServer(t, `(node.id==1);(node.location.locationName==Apex);(ifRegainedService==\u0000,ifRegainedService=ge=`)
+	err = app.Run([]string{app.Name, "outages", "list", "-n", "1", "-L", "Apex", "--since", "24h"})
+	assert.NilError(t, err)
+	server.Close()
+
+	err = app.Run([]string{app.Name, "outages", "list", "--since", "-24h"})
+	assert.Error(t, err, "the period defined by --since cannot be negative")
+}
+
+func TestOutageStats(t *testing.T) {
+	var err error
+	app := test.CreateCli(CliCommand)
+
+	server := createOutagesMockServer(t, `(node.label==srv01);(ifRegainedService==\u0000,ifRegainedService=ge=`)
+	defer server.Close()
+	err = app.Run([]string{app.Name, "outages", "stats", "--node", "srv01", "--since", "24h"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "outages", "stats", "--since", "0s"})
+	assert.Error(t, err, "the period defined by --since must be greater than 0")
+}
@@ -0,0 +1,214 @@
+package outages
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/OpenNMS/onmsctl/api"
+	"github.com/OpenNMS/onmsctl/common"
+	"github.com/OpenNMS/onmsctl/model"
+	"github.com/OpenNMS/onmsctl/rest"
+	"github.com/OpenNMS/onmsctl/services"
+	"github.com/urfave/cli"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ScheduleCliCommand the CLI command to manage scheduled outages
+var ScheduleCliCommand = cli.Command{
+	Name:      "schedule",
+	ShortName: "sched",
+	Usage:     "Manage scheduled outages",
+	Subcommands: []cli.Command{
+		{
+			Name:   "list",
+			Usage:  "List all the scheduled outages",
+			Action: listScheduledOutages,
+		},
+		{
+			Name:         "get",
+			Usage:        "Gets a scheduled outage",
+			ArgsUsage:    "<name>",
+			Action:       showScheduledOutage,
+			BashComplete: outageNameBashComplete,
+		},
+		{
+			Name:      "apply",
+			Usage:     "Creates or updates a scheduled outage from YAML",
+			ArgsUsage: "<yaml>",
+			Action:    applyScheduledOutage,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "file, f",
+					Usage: "External YAML file (use '-' for STDIN Pipe)",
+				},
+			},
+		},
+		{
+			Name:         "delete",
+			ShortName:    "del",
+			Usage:        "Deletes a scheduled outage",
+			ArgsUsage:    "<name>",
+			Action:       deleteScheduledOutage,
+			BashComplete: outageNameBashComplete,
+		},
+		{
+			Name:         "export",
+			Usage:        "Exports scheduled outages to iCalendar (ICS) format",
+			ArgsUsage:    "[name ...]",
+			Description:  "Exports all the scheduled outages, or the given ones, as RFC 5545 events; recurring outages use RRULEs.",
+			Action:       exportScheduledOutages,
+			BashComplete: outageNameBashComplete,
+		},
+		{
+			Name:        "import",
+			Usage:       "Creates or updates scheduled outages from an iCalendar (ICS) file",
+			ArgsUsage:   "<ics>",
+			Description: "Events with the same summary become a single scheduled outage; only daily, weekly (by day) and monthly (by day of month) recurrence rules are supported.",
+			Action:      importScheduledOutages,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "file, f",
+					Usage: "External ICS file (use '-' for STDIN Pipe)",
+				},
+			},
+		},
+		{
+			Name:         "attach",
+			Usage:        "Applies a scheduled outage to a poller, collector or threshold package, or to notifications",
+			ArgsUsage:    "<name> <target> [packageName]",
+			Description:  "Valid targets: " + model.ScheduledOutageTargets.EnumAsString() + " (the notification target doesn't require a package)",
+			Action:       attachScheduledOutage,
+			BashComplete: outageTargetBashComplete,
+		},
+		{
+			Name:         "detach",
+			Usage:        "Removes a scheduled outage from a poller, collector or threshold package, or from notifications",
+			ArgsUsage:    "<name> <target> [packageName]",
+			Description:  "Valid targets: " + model.ScheduledOutageTargets.EnumAsString() + " (the notification target doesn't require a package)",
+			Action:       detachScheduledOutage,
+			BashComplete: outageTargetBashComplete,
+		},
+	},
+}
+
+func listScheduledOutages(c *cli.Context) error {
+	list, err := getAPI().GetScheduledOutages()
+	if err != nil {
+		return err
+	}
+	table := &common.Table{
+		Headers: []string{"Name", "Type", "Nodes", "Interfaces", "Times"},
+		Empty:   "There are no scheduled outages",
+	}
+	for _, o := range list.Outages {
+		times := make([]string, len(o.Times))
+		for i, t := range o.Times {
+			times[i] = strings.TrimSpace(t.Day + " " + t.Begins + " - " + t.Ends)
+		}
+		table.AddRow(o.Name, o.Type, len(o.Nodes), len(o.Interfaces), strings.Join(times, ", "))
+	}
+	return common.Print(list, table)
+}
+
+func showScheduledOutage(c *cli.Context) error {
+	outage, err := getAPI().GetScheduledOutage(c.Args().First())
+	if err != nil {
+		return err
+	}
+	return common.Print(outage, nil)
+}
+
+func applyScheduledOutage(c *cli.Context) error {
+	data, err := common.ReadInput(c, 0)
+	if err != nil {
+		return err
+	}
+	outage := model.ScheduledOutage{}
+	if err = yaml.Unmarshal(data, &outage); err != nil {
+		return err
+	}
+	return getAPI().SetScheduledOutage(outage)
+}
+
+func deleteScheduledOutage(c *cli.Context) error {
+	return getAPI().DeleteScheduledOutage(c.Args().First())
+}
+
+func exportScheduledOutages(c *cli.Context) error {
+	list, err := getAPI().GetScheduledOutages()
+	if err != nil {
+		return err
+	}
+	outages := list.Outages
+	if c.Args().Present() {
+		outages = make([]model.ScheduledOutage, 0, c.NArg())
+		for _, name := range c.Args() {
+			outage := list.GetOutage(name)
+			if outage == nil {
+				return fmt.Errorf("scheduled outage %s not found", name)
+			}
+			outages = append(outages, *outage)
+		}
+	}
+	return model.WriteScheduledOutagesICS(outages, time.Now(), os.Stdout)
+}
+
+func importScheduledOutages(c *cli.Context) error {
+	data, err := common.ReadInput(c, 0)
+	if err != nil {
+		return err
+	}
+	outages, err := model.ParseScheduledOutagesICS(data)
+	if err != nil {
+		return err
+	}
+	if len(outages) == 0 {
+		return fmt.Errorf("there are no events on the ICS content")
+	}
+	for _, outage := range outages {
+		if err = getAPI().SetScheduledOutage(outage); err != nil {
+			return fmt.Errorf("cannot apply scheduled outage %s: %v", outage.Name, err)
+		}
+		fmt.Printf("Scheduled outage %s (%s) applied\n", outage.Name, outage.Type)
+	}
+	return nil
+}
+
+func attachScheduledOutage(c *cli.Context) error {
+	return getAPI().AddToPackage(c.Args().Get(0), c.Args().Get(1), c.Args().Get(2))
+}
+
+func detachScheduledOutage(c *cli.Context) error {
+	return getAPI().RemoveFromPackage(c.Args().Get(0), c.Args().Get(1), c.Args().Get(2))
+}
+
+func outageNameBashComplete(c *cli.Context) {
+	if c.NArg() > 0 {
+		return
+	}
+	list, err := getAPI().GetScheduledOutages()
+	if err != nil {
+		return
+	}
+	for _, o := range list.Outages {
+		fmt.Println(o.Name)
+	}
+}
+
+func outageTargetBashComplete(c *cli.Context) {
+	switch c.NArg() {
+	case 0:
+		outageNameBashComplete(c)
+	case 1:
+		for _, target := range model.ScheduledOutageTargets.Enum {
+			fmt.Println(target)
+		}
+	}
+}
+
+func getAPI() api.ScheduledOutagesAPI {
+	return services.GetScheduledOutagesAPI(rest.Instance)
+}
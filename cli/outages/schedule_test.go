@@ -0,0 +1,149 @@
+package outages
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/OpenNMS/onmsctl/model"
+	"github.com/OpenNMS/onmsctl/rest"
+	"github.com/OpenNMS/onmsctl/test"
+	"gotest.tools/assert"
+)
+
+var mockOutage = model.ScheduledOutage{
+	Name: "Weekend",
+	Type: "weekly",
+	Nodes: []model.ScheduledNode{
+		{ID: 1},
+	},
+	Times: []model.ScheduledTime{
+		{Day: "saturday", Begins: "00:00:00", Ends: "23:59:59"},
+	},
+}
+
+func createScheduleMockServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		switch req.Method + " " + req.URL.Path {
+		case "GET /rest/sched-outages":
+			bytes, _ := json.Marshal(model.ScheduledOutageList{Outages: []model.ScheduledOutage{mockOutage}})
+			res.Write(bytes)
+		case "GET /rest/sched-outages/Weekend":
+			bytes, _ := json.Marshal(mockOutage)
+			res.Write(bytes)
+		case "POST /rest/sched-outages":
+			outage := model.ScheduledOutage{}
+			bytes, err := ioutil.ReadAll(req.Body)
+			assert.NilError(t, err)
+			assert.NilError(t, json.Unmarshal(bytes, &outage))
+			assert.DeepEqual(t, mockOutage, outage)
+		case "DELETE /rest/sched-outages/Weekend":
+		case "PUT /rest/sched-outages/Weekend/pollerd/example1":
+		case "DELETE /rest/sched-outages/Weekend/notifd":
+		default:
+			res.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	rest.Instance.URL = server.URL
+	return server
+}
+
+func TestListScheduledOutages(t *testing.T) {
+	app := test.CreateCli(CliCommand)
+	server := createScheduleMockServer(t)
+	defer server.Close()
+
+	err := app.Run([]string{app.Name, "outages", "schedule", "list"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "outages", "sched", "get", "Weekend"})
+	assert.NilError(t, err)
+}
+
+func TestApplyScheduledOutage(t *testing.T) {
+	app := test.CreateCli(CliCommand)
+	server := createScheduleMockServer(t)
+	defer server.Close()
+
+	err := app.Run([]string{app.Name, "outages", "schedule", "apply"})
+	assert.Error(t, err, "content cannot be empty")
+
+	err = app.Run([]string{app.Name, "outages", "schedule", "apply", "name: Weekend\ntype: weekly\ntimes:\n- day: funday\n  begins: 00:00:00\n  ends: 23:59:59\n"})
+	assert.ErrorContains(t, err, "invalid day for weekly schedule")
+
+	yaml := `
+name: Weekend
+type: weekly
+nodes:
+- id: 1
+times:
+- day: saturday
+  begins: "00:00:00"
+  ends: "23:59:59"
+`
+	err = app.Run([]string{app.Name, "outages", "schedule", "apply", yaml})
+	assert.NilError(t, err)
+}
+
+func TestDeleteScheduledOutage(t *testing.T) {
+	app := test.CreateCli(CliCommand)
+	server := createScheduleMockServer(t)
+	defer server.Close()
+
+	err := app.Run([]string{app.Name, "outages", "schedule", "delete"})
+	assert.Error(t, err, "outage name required")
+
+	err = app.Run([]string{app.Name, "outages", "schedule", "delete", "Weekend"})
+	assert.NilError(t, err)
+}
+
+func TestAttachScheduledOutage(t *testing.T) {
+	app := test.CreateCli(CliCommand)
+	server := createScheduleMockServer(t)
+	defer server.Close()
+
+	err := app.Run([]string{app.Name, "outages", "schedule", "attach", "Weekend", "poller"})
+	assert.Error(t, err, "package name required for poller target")
+
+	err = app.Run([]string{app.Name, "outages", "schedule", "attach", "Weekend", "poller", "example1"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "outages", "schedule", "attach", "Weekend", "collector", "unknown"})
+	assert.ErrorContains(t, err, "either scheduled outage Weekend or collector package unknown not found")
+
+	err = app.Run([]string{app.Name, "outages", "schedule", "detach", "Weekend", "notification"})
+	assert.NilError(t, err)
+}
+
+func TestExportScheduledOutages(t *testing.T) {
+	app := test.CreateCli(CliCommand)
+	server := createScheduleMockServer(t)
+	defer server.Close()
+
+	err := app.Run([]string{app.Name, "outages", "schedule", "export"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "outages", "schedule", "export", "Weekend"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "outages", "schedule", "export", "Holidays"})
+	assert.Error(t, err, "scheduled outage Holidays not found")
+}
+
+func TestImportScheduledOutages(t *testing.T) {
+	app := test.CreateCli(CliCommand)
+	server := createScheduleMockServer(t)
+	defer server.Close()
+
+	err := app.Run([]string{app.Name, "outages", "schedule", "import", "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"})
+	assert.Error(t, err, "there are no events on the ICS content")
+
+	buffer := &bytes.Buffer{}
+	assert.NilError(t, model.WriteScheduledOutagesICS([]model.ScheduledOutage{mockOutage}, time.Now(), buffer))
+	err = app.Run([]string{app.Name, "outages", "schedule", "import", buffer.String()})
+	assert.NilError(t, err)
+}
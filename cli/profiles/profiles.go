@@ -58,6 +58,35 @@ var CliCommand = cli.Command{
 					Name:  "insecure",
 					Usage: "To skip TLS Certificate validation",
 				},
+				cli.IntFlag{
+					Name:  "retries",
+					Usage: "Maximum number of retries for transient errors (0 disables retries)",
+				},
+				cli.IntFlag{
+					Name:  "retry-backoff",
+					Usage: "Initial wait between retries in milliseconds, doubled on each retry (0 uses the default)",
+				},
+				cli.IntFlag{
+					Name:  "retry-max-backoff",
+					Usage: "Maximum wait between retries in milliseconds (0 uses the default)",
+				},
+				cli.GenericFlag{
+					Name:  "retry-codes",
+					Value: &rest.StatusCodes{},
+					Usage: "Comma-separated list of HTTP status codes to retry (defaults to " + rest.DefaultRetryStatusCodes.String() + ")",
+				},
+				cli.BoolFlag{
+					Name:  "retry-post",
+					Usage: "Retry POST requests on transient errors (only safe when the server handles them idempotently)",
+				},
+				cli.IntFlag{
+					Name:  "breaker-threshold",
+					Usage: "Consecutive failures that open the circuit breaker (0 uses the default)",
+				},
+				cli.IntFlag{
+					Name:  "breaker-cooldown",
+					Usage: "Seconds to wait before sending requests after the circuit breaker opens (0 uses the default)",
+				},
 			},
 		},
 		{
@@ -80,21 +109,26 @@ func listConfigProfiles(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	if cfg == nil || cfg.IsEmpty() {
-		fmt.Println("There are no profiles configured")
-		return nil
+	if cfg == nil {
+		cfg = &model.ProfilesConfig{}
+	}
+	table := &common.Table{
+		Headers:     []string{"Default", "Name", "User", "URL"},
+		WideHeaders: []string{"Insecure", "Timeout", "Retries"},
+		Empty:       "There are no profiles configured",
 	}
-	writer := common.NewTableWriter()
-	fmt.Fprintln(writer, "Default\tName\tUser\tURL")
-	for _, p := range cfg.Profiles {
+	// Passwords are never displayed
+	output := model.ProfilesConfig{Default: cfg.Default, Profiles: make([]model.Profile, len(cfg.Profiles))}
+	for i, p := range cfg.Profiles {
 		def := ""
 		if cfg.Default == p.Name {
 			def = "*"
 		}
-		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", def, p.Name, p.Username, p.URL)
+		table.AddRow(def, p.Name, p.Username, p.URL, p.Insecure, p.Timeout, p.MaxRetries)
+		p.Password = ""
+		output.Profiles[i] = p
 	}
-	writer.Flush()
-	return nil
+	return common.Print(output, table)
 }
 
 func setConfigProfile(c *cli.Context) error {
@@ -103,8 +137,18 @@ func setConfigProfile(c *cli.Context) error {
 		URL:      c.String("url"),
 		Username: c.String("user"),
 		Password: c.String("passwd"),
-		Timeout:  c.Int("passwd"),
+		Timeout:  c.Int("timeout"),
 		Insecure: c.Bool("insecure"),
+
+		MaxRetries:       c.Int("retries"),
+		RetryBackoff:     c.Int("retry-backoff"),
+		RetryMaxBackoff:  c.Int("retry-max-backoff"),
+		RetryPost:        c.Bool("retry-post"),
+		BreakerThreshold: c.Int("breaker-threshold"),
+		BreakerCooldown:  c.Int("breaker-cooldown"),
+	}
+	if codes, ok := c.Generic("retry-codes").(*rest.StatusCodes); ok && len(*codes) > 0 {
+		profile.RetryStatusCodes = *codes
 	}
 	return getAPI().SetProfile(profile)
 }
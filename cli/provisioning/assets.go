@@ -1,8 +1,6 @@
 package provisioning
 
 import (
-	"fmt"
-
 	"github.com/OpenNMS/onmsctl/common"
 	"github.com/OpenNMS/onmsctl/model"
 	"github.com/urfave/cli"
@@ -49,17 +47,14 @@ func listAssets(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	if len(node.Assets) == 0 {
-		fmt.Println("There are no assets on the chosen node")
-		return nil
+	table := &common.Table{
+		Headers: []string{"Asset Name", "Asset Value"},
+		Empty:   "There are no assets on the chosen node",
 	}
-	writer := common.NewTableWriter()
-	fmt.Fprintln(writer, "Asset Name\tAsset Value")
 	for _, asset := range node.Assets {
-		fmt.Fprintf(writer, "%s\t%s\n", asset.Name, asset.Value)
+		table.AddRow(asset.Name, asset.Value)
 	}
-	writer.Flush()
-	return nil
+	return common.Print(node.Assets, table)
 }
 
 func enumerateAssets(c *cli.Context) error {
@@ -67,13 +62,11 @@ func enumerateAssets(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	writer := common.NewTableWriter()
-	fmt.Fprintln(writer, "Asset Name")
+	table := &common.Table{Headers: []string{"Asset Name"}}
 	for _, asset := range assets.Element {
-		fmt.Fprintf(writer, "%s\n", asset)
+		table.AddRow(asset)
 	}
-	writer.Flush()
-	return nil
+	return common.Print(assets.Element, table)
 }
 
 func setAsset(c *cli.Context) error {
@@ -1,8 +1,6 @@
 package provisioning
 
 import (
-	"fmt"
-
 	"github.com/OpenNMS/onmsctl/common"
 	"github.com/OpenNMS/onmsctl/model"
 	"github.com/urfave/cli"
@@ -43,17 +41,14 @@ func listCategories(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	if len(node.Categories) == 0 {
-		fmt.Println("There are no categories on the chosen node")
-		return nil
+	table := &common.Table{
+		Headers: []string{"Category Name"},
+		Empty:   "There are no categories on the chosen node",
 	}
-	writer := common.NewTableWriter()
-	fmt.Fprintln(writer, "Category Name")
 	for _, cat := range node.Categories {
-		fmt.Fprintf(writer, "%s\n", cat.Name)
+		table.AddRow(cat.Name)
 	}
-	writer.Flush()
-	return nil
+	return common.Print(node.Categories, table)
 }
 
 func addCategory(c *cli.Context) error {
@@ -7,6 +7,7 @@ import (
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -133,9 +134,32 @@ func createTestServer(t *testing.T) *httptest.Server {
 				assert.Assert(t, net.ParseIP(node.Interfaces[0].IPAddress) != nil)
 			}
 
-		case "/rest/requisitions/Local/import":
+		case "/rest/requisitions/Local/import", "/rest/requisitions/Test/import":
 			assert.Equal(t, http.MethodPut, req.Method)
 
+		case "/api/v2/events":
+			assert.Equal(t, http.MethodGet, req.Method)
+			if req.URL.Query().Get("_s") == "" {
+				sendData(res, model.OnmsEventList{Count: 1, Events: []model.OnmsEvent{{ID: 10}}})
+				return
+			}
+			filter := req.URL.Query().Get("_s")
+			assert.Assert(t, strings.Contains(filter, "id=gt=10"))
+			events := []model.OnmsEvent{
+				{ID: 12, UEI: model.ImportFailedUEI, Parameters: []model.OnmsEventParam{{Name: "url", Value: "requisition://Test?rescanExisting=true"}, {Name: "reason", Value: "Invalid requisition"}}},
+				{ID: 11, UEI: model.ImportSuccessfulUEI, Parameters: []model.OnmsEventParam{{Name: "url", Value: "requisition://Local?rescanExisting=true"}}},
+			}
+			if idx := strings.Index(filter, "id=lt="); idx > 0 {
+				upper, _ := strconv.Atoi(filter[idx+6:])
+				for len(events) > 0 && events[0].ID >= upper {
+					events = events[1:]
+				}
+			}
+			if limit, _ := strconv.Atoi(req.URL.Query().Get("limit")); limit < len(events) {
+				events = events[:limit]
+			}
+			sendData(res, model.OnmsEventList{Count: len(events), Events: events})
+
 		case "/rest/requisitions/Local":
 			assert.Equal(t, http.MethodDelete, req.Method)
 
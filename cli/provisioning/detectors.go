@@ -87,17 +87,14 @@ func listDetectors(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	if len(fsDef.Detectors) == 0 {
-		fmt.Println("There are no detectors on the chosen foreign source definition")
-		return nil
+	table := &common.Table{
+		Headers: []string{"Detector Name", "Detector Class"},
+		Empty:   "There are no detectors on the chosen foreign source definition",
 	}
-	writer := common.NewTableWriter()
-	fmt.Fprintln(writer, "Detector Name\tDetector Class")
 	for _, detector := range fsDef.Detectors {
-		fmt.Fprintf(writer, "%s\t%s\n", detector.Name, detector.Class)
+		table.AddRow(detector.Name, detector.Class)
 	}
-	writer.Flush()
-	return nil
+	return common.Print(fsDef.Detectors, table)
 }
 
 func enumerateDetectorClasses(c *cli.Context) error {
@@ -105,13 +102,11 @@ func enumerateDetectorClasses(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	writer := common.NewTableWriter()
-	fmt.Fprintln(writer, "Detector Name\tDetector Class")
+	table := &common.Table{Headers: []string{"Detector Name", "Detector Class"}}
 	for _, plugin := range detectors.Plugins {
-		fmt.Fprintf(writer, "%s\t%s\n", plugin.Name, plugin.Class)
+		table.AddRow(plugin.Name, plugin.Class)
 	}
-	writer.Flush()
-	return nil
+	return common.Print(detectors.Plugins, table)
 }
 
 func describeDetectorClass(c *cli.Context) error {
@@ -119,9 +114,7 @@ func describeDetectorClass(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	data, _ := yaml.Marshal(plugin)
-	fmt.Println(string(data))
-	return nil
+	return common.Print(plugin, nil)
 }
 
 func getDetector(c *cli.Context) error {
@@ -129,9 +122,7 @@ func getDetector(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	data, _ := yaml.Marshal(detector)
-	fmt.Println(string(data))
-	return nil
+	return common.Print(detector, nil)
 }
 
 func setDetector(c *cli.Context) error {
@@ -92,9 +92,7 @@ func showForeignSource(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	data, _ := yaml.Marshal(fsDef)
-	fmt.Println(string(data))
-	return nil
+	return common.Print(fsDef, nil)
 }
 
 func setScanInterval(c *cli.Context) error {
@@ -1,7 +1,6 @@
 package provisioning
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/OpenNMS/onmsctl/common"
@@ -114,21 +113,18 @@ func listInterfaces(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	if len(node.Interfaces) == 0 {
-		fmt.Println("There are no IP interfaces on the chosen node")
-		return nil
+	table := &common.Table{
+		Headers: []string{"IP Address", "Description", "SNMP Primary", "Services"},
+		Empty:   "There are no IP interfaces on the chosen node",
 	}
-	writer := common.NewTableWriter()
-	fmt.Fprintln(writer, "IP Address\tDescription\tSNMP Primary\tServices")
 	for _, intf := range node.Interfaces {
 		desc := intf.Description
 		if desc == "" {
 			desc = "N/A"
 		}
-		fmt.Fprintf(writer, "%s\t%s\t%s\t%d\n", intf.IPAddress, desc, intf.SnmpPrimary, len(intf.Services))
+		table.AddRow(intf.IPAddress, desc, intf.SnmpPrimary, len(intf.Services))
 	}
-	writer.Flush()
-	return nil
+	return common.Print(node.Interfaces, table)
 }
 
 func showInterface(c *cli.Context) error {
@@ -136,9 +132,7 @@ func showInterface(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	data, _ := yaml.Marshal(&intf)
-	fmt.Println(string(data))
-	return nil
+	return common.Print(intf, nil)
 }
 
 func setInterface(c *cli.Context) error {
@@ -185,17 +179,14 @@ func intfListMetaData(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	if len(intf.MetaData) == 0 {
-		fmt.Println("There is no meta-data for the chosen IP interface")
-		return nil
+	table := &common.Table{
+		Headers: []string{"Context", "Key", "Value"},
+		Empty:   "There is no meta-data for the chosen IP interface",
 	}
-	writer := common.NewTableWriter()
-	fmt.Fprintln(writer, "Context\tKey\tValue")
 	for _, m := range intf.MetaData {
-		fmt.Fprintf(writer, "%s\t%s\t%s\n", m.Context, m.Key, m.Value)
+		table.AddRow(m.Context, m.Key, m.Value)
 	}
-	writer.Flush()
-	return nil
+	return common.Print(intf.MetaData, table)
 }
 
 func intfSetMetaData(c *cli.Context) error {
@@ -25,6 +25,10 @@ func getUtilsAPI() api.ProvisioningUtilsAPI {
 	return services.GetProvisioningUtilsAPI(rest.Instance)
 }
 
+func getEventsAPI() api.EventsAPI {
+	return services.GetEventsAPI(rest.Instance)
+}
+
 func requisitionNameBashComplete(c *cli.Context) {
 	if c.NArg() > 0 {
 		return
@@ -1,7 +1,6 @@
 package provisioning
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/OpenNMS/onmsctl/common"
@@ -131,21 +130,18 @@ func listNodes(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	if len(requisition.Nodes) == 0 {
-		fmt.Println("There are no nodes on the chosen requisition")
-		return nil
+	table := &common.Table{
+		Headers: []string{"Foreign ID", "Label", "Location", "Interfaces", "Assets", "Categories"},
+		Empty:   "There are no nodes on the chosen requisition",
 	}
-	writer := common.NewTableWriter()
-	fmt.Fprintln(writer, "Foreign ID\tLabel\tLocation\tInterfaces\tAssets\tCategories")
 	for _, node := range requisition.Nodes {
 		location := node.Location
 		if location == "" {
 			location = "Default"
 		}
-		fmt.Fprintf(writer, "%s\t%s\t%s\t%d\t%d\t%d\n", node.ForeignID, node.NodeLabel, location, len(node.Interfaces), len(node.Assets), len(node.Categories))
+		table.AddRow(node.ForeignID, node.NodeLabel, location, len(node.Interfaces), len(node.Assets), len(node.Categories))
 	}
-	writer.Flush()
-	return nil
+	return common.Print(requisition.Nodes, table)
 }
 
 func showNode(c *cli.Context) error {
@@ -153,9 +149,7 @@ func showNode(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	data, _ := yaml.Marshal(&node)
-	fmt.Println(string(data))
-	return nil
+	return common.Print(node, nil)
 }
 
 func setNode(c *cli.Context) error {
@@ -206,17 +200,14 @@ func nodeListMetaData(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	if len(node.MetaData) == 0 {
-		fmt.Println("There is no meta-data for the chosen node")
-		return nil
+	table := &common.Table{
+		Headers: []string{"Context", "Key", "Value"},
+		Empty:   "There is no meta-data for the chosen node",
 	}
-	writer := common.NewTableWriter()
-	fmt.Fprintln(writer, "Context\tKey\tValue")
 	for _, m := range node.MetaData {
-		fmt.Fprintf(writer, "%s\t%s\t%s\n", m.Context, m.Key, m.Value)
+		table.AddRow(m.Context, m.Key, m.Value)
 	}
-	writer.Flush()
-	return nil
+	return common.Print(node.MetaData, table)
 }
 
 func nodeSetMetaData(c *cli.Context) error {
@@ -87,17 +87,14 @@ func listPolicies(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	if len(fsDef.Policies) == 0 {
-		fmt.Println("There are no policies on the chosen foreign source definition")
-		return nil
+	table := &common.Table{
+		Headers: []string{"Policy Name", "Policy Class"},
+		Empty:   "There are no policies on the chosen foreign source definition",
 	}
-	writer := common.NewTableWriter()
-	fmt.Fprintln(writer, "Policy Name\tPolicy Class")
 	for _, policy := range fsDef.Policies {
-		fmt.Fprintf(writer, "%s\t%s\n", policy.Name, policy.Class)
+		table.AddRow(policy.Name, policy.Class)
 	}
-	writer.Flush()
-	return nil
+	return common.Print(fsDef.Policies, table)
 }
 
 func enumeratePolicyClasses(c *cli.Context) error {
@@ -105,13 +102,11 @@ func enumeratePolicyClasses(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	writer := common.NewTableWriter()
-	fmt.Fprintln(writer, "Policy Name\tPolicy Class")
+	table := &common.Table{Headers: []string{"Policy Name", "Policy Class"}}
 	for _, plugin := range policies.Plugins {
-		fmt.Fprintf(writer, "%s\t%s\n", plugin.Name, plugin.Class)
+		table.AddRow(plugin.Name, plugin.Class)
 	}
-	writer.Flush()
-	return nil
+	return common.Print(policies.Plugins, table)
 }
 
 func describePolicyClass(c *cli.Context) error {
@@ -119,9 +114,7 @@ func describePolicyClass(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	data, _ := yaml.Marshal(plugin)
-	fmt.Println(string(data))
-	return nil
+	return common.Print(plugin, nil)
 }
 
 func getPolicy(c *cli.Context) error {
@@ -129,9 +122,7 @@ func getPolicy(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	data, _ := yaml.Marshal(detector)
-	fmt.Println(string(data))
-	return nil
+	return common.Print(detector, nil)
 }
 
 func setPolicy(c *cli.Context) error {
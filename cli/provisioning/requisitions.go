@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"net/url"
+	"os"
+	"path"
 	"strings"
 	"sync"
 	"time"
@@ -15,6 +18,9 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
+var importPollInterval = 2 * time.Second
+var importEventsPageSize = 100
+
 // RequisitionsCliCommand the CLI command configuration for managing requisitions
 var RequisitionsCliCommand = cli.Command{
 	Name:      "requisition",
@@ -34,6 +40,40 @@ var RequisitionsCliCommand = cli.Command{
 			BashComplete: requisitionNameBashComplete,
 			ArgsUsage:    "<name>",
 		},
+		{
+			Name:         "export",
+			Usage:        "Exports a requisition to a given format\n   CSV contains one row per IP interface; node level fields are repeated on each row.",
+			Action:       exportRequisition,
+			BashComplete: requisitionNameBashComplete,
+			ArgsUsage:    "<name>",
+			Flags: []cli.Flag{
+				cli.GenericFlag{
+					Name: "format, x",
+					Value: &model.EnumValue{
+						Enum:    append([]string{"csv"}, Formats...),
+						Default: "yaml",
+					},
+					Usage: "File Format: csv, " + strings.Join(Formats, ", "),
+				},
+			},
+		},
+		{
+			Name:  "import-csv",
+			Usage: "Creates or updates a requisition from a CSV file, overriding any existing content",
+			Description: "Creates or updates a requisition from a CSV file, overriding any existing content\n" +
+				"   Each row represents an IP interface; rows with the same foreignId are merged into a single node.\n" +
+				"   Columns: " + strings.Join(model.RequisitionCSVColumns, ", ") + ", asset:<field> and meta:<key>.\n" +
+				"   The classic PRIS layout is also supported (Node_Label, IP_Management, MgmtType_, svc_Forced, cat_*, Asset_*).",
+			Action:       importCSVRequisition,
+			BashComplete: requisitionNameBashComplete,
+			ArgsUsage:    "<name> <content>",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "file, f",
+					Usage: "External CSV file (use '-' for STDIN Pipe)",
+				},
+			},
+		},
 		{
 			Name:      "add",
 			Usage:     "Adds a new requisition",
@@ -60,6 +100,26 @@ var RequisitionsCliCommand = cli.Command{
 			},
 			ArgsUsage: "<content>",
 		},
+		{
+			Name:   "diff",
+			Usage:  "Shows the differences between a requisition from a external file and its current content on the server",
+			Action: diffRequisition,
+			Flags: []cli.Flag{
+				cli.GenericFlag{
+					Name: "format, x",
+					Value: &model.EnumValue{
+						Enum:    Formats,
+						Default: "yaml",
+					},
+					Usage: "File Format: " + strings.Join(Formats, ", "),
+				},
+				cli.StringFlag{
+					Name:  "file, f",
+					Usage: "External file (use '-' for STDIN Pipe)",
+				},
+			},
+			ArgsUsage: "<content>",
+		},
 		{
 			Name:      "validate",
 			ShortName: "v",
@@ -108,6 +168,15 @@ var RequisitionsCliCommand = cli.Command{
 	dbonly, to add/detete/update nodes on the DB skipping the scan phase
 	`,
 				},
+				cli.BoolFlag{
+					Name:  "wait, w",
+					Usage: "Wait until the import process finishes, reporting its progress",
+				},
+				cli.DurationFlag{
+					Name:  "wait-timeout",
+					Value: 10 * time.Minute,
+					Usage: "Maximum time to wait for the import process to finish (with --wait)",
+				},
 			},
 			ArgsUsage: "<name>|ALL",
 		},
@@ -127,22 +196,22 @@ func listRequisitions(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	if len(requisitions.ForeignSources) == 0 {
-		fmt.Println("There are no requisitions")
-		return nil
-	}
 	statistics, err := getReqAPI().GetRequisitionsStats()
 	if err != nil {
 		return err
 	}
-	writer := common.NewTableWriter()
-	fmt.Fprintln(writer, "Requisition\tNodes in DB\tLast Import")
+	table := &common.Table{
+		Headers: []string{"Requisition", "Nodes in DB", "Last Import"},
+		Empty:   "There are no requisitions",
+	}
+	list := make([]model.RequisitionStats, 0, len(requisitions.ForeignSources))
 	for _, req := range requisitions.ForeignSources {
 		stats := statistics.GetRequisitionStats(req)
-		fmt.Fprintf(writer, "%s\t%d\t%s\n", req, len(stats.ForeignIDs), getDisplayTime(stats.LastImport))
+		stats.Name = req
+		table.AddRow(req, len(stats.ForeignIDs), getDisplayTime(stats.LastImport))
+		list = append(list, stats)
 	}
-	writer.Flush()
-	return nil
+	return common.Print(list, table)
 }
 
 func showRequisition(c *cli.Context) error {
@@ -150,11 +219,52 @@ func showRequisition(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	data, _ := yaml.Marshal(requisition)
+	return common.Print(requisition, nil)
+}
+
+func exportRequisition(c *cli.Context) error {
+	requisition, err := getReqAPI().GetRequisition(c.Args().First())
+	if err != nil {
+		return err
+	}
+	var data []byte
+	switch c.String("format") {
+	case "csv":
+		return model.WriteRequisitionCSV(requisition, os.Stdout)
+	case "xml":
+		data, err = xml.MarshalIndent(requisition, "", "  ")
+	case "json":
+		data, err = json.MarshalIndent(requisition, "", "  ")
+	default:
+		data, err = yaml.Marshal(requisition)
+	}
+	if err != nil {
+		return err
+	}
 	fmt.Println(string(data))
 	return nil
 }
 
+func importCSVRequisition(c *cli.Context) error {
+	name := c.Args().First()
+	if name == "" {
+		return fmt.Errorf("requisition name required")
+	}
+	data, err := common.ReadInput(c, 1)
+	if err != nil {
+		return err
+	}
+	requisition, err := model.ParseRequisitionCSV(name, data)
+	if err != nil {
+		return err
+	}
+	if err := getReqAPI().SetRequisition(*requisition); err != nil {
+		return err
+	}
+	fmt.Printf("Requisition %s has been updated with %d nodes\n", requisition.Name, len(requisition.Nodes))
+	return nil
+}
+
 func addRequisition(c *cli.Context) error {
 	return getReqAPI().CreateRequisition(c.Args().First())
 }
@@ -167,6 +277,39 @@ func applyRequisition(c *cli.Context) error {
 	return getReqAPI().SetRequisition(*requisition)
 }
 
+// DiffChangesExitCode the exit code of the diff command when the requisition differs from the server (errors use 1)
+const DiffChangesExitCode = 2
+
+func diffRequisition(c *cli.Context) error {
+	requisition, err := parseRequisition(c)
+	if err != nil {
+		return err
+	}
+	var current *model.Requisition
+	if getUtilsAPI().RequisitionExists(requisition.Name) {
+		if current, err = getReqAPI().GetRequisition(requisition.Name); err != nil {
+			return err
+		}
+	}
+	diff := model.DiffRequisitions(current, requisition)
+	if common.Output.IsTable() {
+		if current == nil {
+			fmt.Printf("Requisition %s doesn't exist on the server\n", requisition.Name)
+		}
+		fmt.Printf("Requisition %s: %d added, %d removed, %d modified\n", diff.Name, diff.Added, diff.Removed, diff.Modified)
+		for _, change := range diff.Changes {
+			fmt.Println(change.String())
+		}
+	} else if err := common.Print(diff, nil); err != nil {
+		return err
+	}
+	if diff.HasChanges() {
+		// Like diff, a non-zero exit code tells scripts that there are changes to apply
+		return cli.NewExitError("", DiffChangesExitCode)
+	}
+	return nil
+}
+
 func validateRequisition(c *cli.Context) error {
 	requisition, err := parseRequisition(c)
 	if err != nil {
@@ -187,12 +330,18 @@ func validateRequisition(c *cli.Context) error {
 func importRequisition(c *cli.Context) error {
 	requisition := c.Args().First()
 	if strings.ToLower(requisition) != "all" {
+		if c.Bool("wait") {
+			return importAndWait([]string{requisition}, c.String("rescanExisting"), c.Duration("wait-timeout"))
+		}
 		return getReqAPI().ImportRequisition(requisition, c.String("rescanExisting"))
 	}
 	requisitions, err := getUtilsAPI().GetRequisitionNames()
 	if err != nil {
 		return err
 	}
+	if c.Bool("wait") {
+		return importAndWait(requisitions.ForeignSources, c.String("rescanExisting"), c.Duration("wait-timeout"))
+	}
 	wg := &sync.WaitGroup{}
 	for _, req := range requisitions.ForeignSources {
 		wg.Add(1)
@@ -208,6 +357,131 @@ func importRequisition(c *cli.Context) error {
 	return nil
 }
 
+// importAndWait imports a list of requisitions in parallel, and waits until all of them are either done or failed
+func importAndWait(requisitions []string, rescanExisting string, timeout time.Duration) error {
+	lastEventID, err := getLastEventID()
+	if err != nil {
+		return err
+	}
+	deadline := time.Now().Add(timeout)
+	if len(requisitions) == 1 {
+		if err := getReqAPI().ImportRequisition(requisitions[0], rescanExisting); err != nil {
+			return err
+		}
+		return waitForImport(requisitions[0], lastEventID, deadline)
+	}
+	results := make([]error, len(requisitions))
+	wg := &sync.WaitGroup{}
+	for i, req := range requisitions {
+		wg.Add(1)
+		go func(i int, req string) {
+			defer wg.Done()
+			fmt.Printf("Importing requisition %s\n", req)
+			if results[i] = getReqAPI().ImportRequisition(req, rescanExisting); results[i] == nil {
+				results[i] = waitForImport(req, lastEventID, deadline)
+			}
+		}(i, req)
+	}
+	wg.Wait()
+	failed := 0
+	for i, err := range results {
+		if err != nil {
+			fmt.Printf("ERROR: Cannot import requisition %s because %v\n", requisitions[i], err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d requisitions failed to import", failed, len(requisitions))
+	}
+	return nil
+}
+
+// waitForImport polls the import events and statistics of a given requisition until the import finishes
+// Only events newer than lastEventID are considered, to ignore the outcome of previous imports.
+func waitForImport(foreignSource string, lastEventID int, deadline time.Time) error {
+	nodes := -1
+	for {
+		events, err := getImportEventsAfter(lastEventID)
+		if err != nil {
+			return err
+		}
+		for _, e := range events {
+			if e.ID > lastEventID {
+				lastEventID = e.ID
+			}
+			if !isImportEventFor(e, foreignSource) {
+				continue
+			}
+			if e.UEI == model.ImportFailedUEI {
+				return fmt.Errorf("import failed: %s", e.GetParameter("reason"))
+			}
+			fmt.Printf("Requisition %s: import completed\n", foreignSource)
+			return nil
+		}
+		stats, err := getReqAPI().GetRequisitionsStats()
+		if err != nil {
+			return fmt.Errorf("cannot get the statistics of requisition %s: %v", foreignSource, err)
+		}
+		if count := len(stats.GetRequisitionStats(foreignSource).ForeignIDs); count != nodes {
+			nodes = count
+			fmt.Printf("Requisition %s: importing, %d nodes in DB\n", foreignSource, nodes)
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timeout waiting for the import of requisition %s", foreignSource)
+		}
+		time.Sleep(importPollInterval)
+	}
+}
+
+// getImportEventsAfter gets all the import events with an ID greater than lastEventID
+// The events are fetched in pages, from the newest to the oldest, so none is missed on a busy server.
+func getImportEventsAfter(lastEventID int) ([]model.OnmsEvent, error) {
+	result := make([]model.OnmsEvent, 0)
+	upperID := 0
+	for {
+		filter := fmt.Sprintf("(eventUei==%s,eventUei==%s);id=gt=%d", model.ImportSuccessfulUEI, model.ImportFailedUEI, lastEventID)
+		if upperID > 0 {
+			filter += fmt.Sprintf(";id=lt=%d", upperID)
+		}
+		events, err := getEventsAPI().GetEvents(filter, importEventsPageSize)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, events.Events...)
+		if len(events.Events) < importEventsPageSize {
+			return result, nil
+		}
+		upperID = events.Events[len(events.Events)-1].ID
+	}
+}
+
+// isImportEventFor verifies if an import event belongs to a given requisition
+// The URL parameter looks like requisition://Name?rescanExisting=true, or file:/opt/opennms/etc/imports/Name.xml
+func isImportEventFor(e model.OnmsEvent, foreignSource string) bool {
+	if fs := e.GetParameter("foreignSource"); fs != "" {
+		return fs == foreignSource
+	}
+	u, err := url.Parse(e.GetParameter("url"))
+	if err != nil {
+		return false
+	}
+	if u.Host != "" {
+		return u.Host == foreignSource
+	}
+	return strings.TrimSuffix(path.Base(u.Path), ".xml") == foreignSource
+}
+
+func getLastEventID() (int, error) {
+	events, err := getEventsAPI().GetEvents("", 1)
+	if err != nil {
+		return 0, err
+	}
+	if len(events.Events) == 0 {
+		return 0, nil
+	}
+	return events.Events[0].ID, nil
+}
+
 func deleteRequisition(c *cli.Context) error {
 	return getReqAPI().DeleteRequisition(c.Args().First())
 }
@@ -1,10 +1,13 @@
 package provisioning
 
 import (
+	"os"
 	"testing"
 
+	"github.com/OpenNMS/onmsctl/common"
 	"github.com/OpenNMS/onmsctl/model"
 	"github.com/OpenNMS/onmsctl/test"
+	"github.com/urfave/cli"
 	"gopkg.in/yaml.v2"
 	"gotest.tools/assert"
 )
@@ -45,6 +48,36 @@ func TestImportRequisition(t *testing.T) {
 	assert.NilError(t, err)
 }
 
+func TestImportRequisitionWithWait(t *testing.T) {
+	var err error
+	app := test.CreateCli(RequisitionsCliCommand)
+	server := createTestServer(t)
+	defer server.Close()
+
+	err = app.Run([]string{app.Name, "req", "import", "--wait", "Local"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "req", "import", "--wait", "Test"})
+	assert.Error(t, err, "import failed: Invalid requisition")
+
+	err = app.Run([]string{app.Name, "req", "import", "--wait", "ALL"})
+	assert.Error(t, err, "1 of 2 requisitions failed to import")
+
+	importEventsPageSize = 1
+	defer func() { importEventsPageSize = 100 }()
+	err = app.Run([]string{app.Name, "req", "import", "--wait", "Local"})
+	assert.NilError(t, err)
+}
+
+func TestIsImportEventFor(t *testing.T) {
+	event := model.OnmsEvent{Parameters: []model.OnmsEventParam{{Name: "url", Value: "file:/opt/opennms/etc/imports/Local.xml"}}}
+	assert.Assert(t, isImportEventFor(event, "Local"))
+	assert.Assert(t, !isImportEventFor(event, "Loc"))
+	event.Parameters[0].Value = "requisition://Local?rescanExisting=false"
+	assert.Assert(t, isImportEventFor(event, "Local"))
+	assert.Assert(t, !isImportEventFor(event, "Test"))
+}
+
 func TestImportAllRequisition(t *testing.T) {
 	var err error
 	app := test.CreateCli(RequisitionsCliCommand)
@@ -109,3 +142,70 @@ func TestApplyRequisition(t *testing.T) {
 	err = app.Run([]string{app.Name, "req", "apply", string(reqYaml)})
 	assert.NilError(t, err)
 }
+
+func TestDiffRequisition(t *testing.T) {
+	var err error
+	app := test.CreateCli(RequisitionsCliCommand)
+	server := createTestServer(t)
+	defer server.Close()
+
+	err = app.Run([]string{app.Name, "req", "diff"})
+	assert.Error(t, err, "content cannot be empty")
+
+	node := testNode
+	node.NodeLabel = "node1"
+	testReq := model.Requisition{
+		Name:  "Test",
+		Nodes: []model.RequisitionNode{node, {ForeignID: "n2"}},
+	}
+	reqYaml, _ := yaml.Marshal(testReq)
+
+	exitCode := 0
+	cli.OsExiter = func(code int) { exitCode = code }
+	defer func() { cli.OsExiter = os.Exit }()
+
+	err = app.Run([]string{app.Name, "req", "diff", string(reqYaml)})
+	assert.ErrorType(t, err, (*cli.ExitError)(nil))
+	assert.Equal(t, DiffChangesExitCode, exitCode)
+
+	common.Output.Set(common.OutputJSON)
+	defer func() { *common.Output = common.OutputFormat{} }()
+	exitCode = 0
+	err = app.Run([]string{app.Name, "req", "diff", string(reqYaml)})
+	assert.ErrorType(t, err, (*cli.ExitError)(nil))
+	assert.Equal(t, DiffChangesExitCode, exitCode)
+}
+
+func TestExportRequisition(t *testing.T) {
+	var err error
+	app := test.CreateCli(RequisitionsCliCommand)
+	server := createTestServer(t)
+	defer server.Close()
+
+	err = app.Run([]string{app.Name, "req", "export"})
+	assert.Error(t, err, "requisition name required")
+
+	for _, format := range []string{"csv", "xml", "json", "yaml"} {
+		err = app.Run([]string{app.Name, "req", "export", "-x", format, "Test"})
+		assert.NilError(t, err)
+	}
+}
+
+func TestImportCSVRequisition(t *testing.T) {
+	var err error
+	app := test.CreateCli(RequisitionsCliCommand)
+	server := createTestServer(t)
+	defer server.Close()
+
+	err = app.Run([]string{app.Name, "req", "import-csv"})
+	assert.Error(t, err, "requisition name required")
+
+	err = app.Run([]string{app.Name, "req", "import-csv", "Test"})
+	assert.Error(t, err, "content cannot be empty")
+
+	err = app.Run([]string{app.Name, "req", "import-csv", "Test", "foreignId,ipAddress,services\nn1,10.0.0.1,ICMP\nn1,10.0.0.1,SNMP\n"})
+	assert.ErrorContains(t, err, "row 3: problem on node n1: IP Address 10.0.0.1 is already defined on row 2")
+
+	err = app.Run([]string{app.Name, "req", "import-csv", "Test", "foreignId,ipAddress,services\nn1,10.0.0.1,ICMP\nn2,10.0.0.2,SNMP\n"})
+	assert.NilError(t, err)
+}
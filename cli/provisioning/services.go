@@ -81,17 +81,14 @@ func listServices(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	if len(intf.Services) == 0 {
-		fmt.Println("There are no monitored services on the chosen IP interface")
-		return nil
+	table := &common.Table{
+		Headers: []string{"Service Name"},
+		Empty:   "There are no monitored services on the chosen IP interface",
 	}
-	writer := common.NewTableWriter()
-	fmt.Fprintln(writer, "Service Name")
 	for _, svc := range intf.Services {
-		fmt.Fprintf(writer, "%s\n", svc.Name)
+		table.AddRow(svc.Name)
 	}
-	writer.Flush()
-	return nil
+	return common.Print(intf.Services, table)
 }
 
 func setService(c *cli.Context) error {
@@ -113,17 +110,14 @@ func svcListMetaData(c *cli.Context) error {
 	if err == nil {
 		return err
 	}
-	if len(service.MetaData) == 0 {
-		fmt.Println("There is no meta-data for the chosen service")
-		return nil
+	table := &common.Table{
+		Headers: []string{"Context", "Key", "Value"},
+		Empty:   "There is no meta-data for the chosen service",
 	}
-	writer := common.NewTableWriter()
-	fmt.Fprintln(writer, "Context\tKey\tValue")
 	for _, m := range service.MetaData {
-		fmt.Fprintf(writer, "%s\t%s\t%s\n", m.Context, m.Key, m.Value)
+		table.AddRow(m.Context, m.Key, m.Value)
 	}
-	writer.Flush()
-	return nil
+	return common.Print(service.MetaData, table)
 }
 
 func svcSetMetaData(c *cli.Context) error {
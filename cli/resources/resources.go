@@ -4,11 +4,10 @@ import (
 	"fmt"
 
 	"github.com/OpenNMS/onmsctl/api"
+	"github.com/OpenNMS/onmsctl/common"
 	"github.com/OpenNMS/onmsctl/rest"
 	"github.com/OpenNMS/onmsctl/services"
 	"github.com/urfave/cli"
-
-	"gopkg.in/yaml.v2"
 )
 
 // CliCommand the CLI command to manage events
@@ -57,9 +56,7 @@ func showResource(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	data, _ := yaml.Marshal(resource)
-	fmt.Println(string(data))
-	return nil
+	return common.Print(resource, nil)
 }
 
 func showNode(c *cli.Context) error {
@@ -67,9 +64,7 @@ func showNode(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	data, _ := yaml.Marshal(resource)
-	fmt.Println(string(data))
-	return nil
+	return common.Print(resource, nil)
 }
 
 func deleteResource(c *cli.Context) error {
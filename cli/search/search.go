@@ -1,35 +1,76 @@
 package search
 
 import (
-	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/OpenNMS/onmsctl/common"
 	"github.com/OpenNMS/onmsctl/model"
 	"github.com/OpenNMS/onmsctl/rest"
+	"github.com/OpenNMS/onmsctl/services"
 	"github.com/urfave/cli"
-	"gopkg.in/yaml.v2"
 )
 
 // Entities list of valid searchable entities
 var Entities = &model.EnumValue{
-	Enum: []string{"nodes", "events", "alarms", "outages"},
+	Enum: model.SearchEntityNames(),
+}
+
+// The comparison operators accepted by the severity filter
+var severityOperators = map[string]string{
+	"eq": "==",
+	"ne": "!=",
+	"lt": "=lt=",
+	"le": "=le=",
+	"gt": "=gt=",
+	"ge": "=ge=",
 }
 
 // CliCommand the CLI command to provide search capabilities information
 var CliCommand = cli.Command{
 	Name:  "search",
 	Usage: "Search OpenNMS database",
+	Description: "Search entities through the ReST API v2. The filter flags are combined with AND into a FIQL expression.\n" +
+		"   Each --where uses the format property<operator>value, where operator is one of == != < <= > >= =lt= =le= =gt= =ge= (e.x. --where 'label==srv*').\n" +
+		"   Each --severity uses the format [operator:]severity, where operator is one of eq, ne, lt, le, gt, ge (e.x. --severity ge:MAJOR).",
 	Flags: []cli.Flag{
 		cli.GenericFlag{
-			Name:     "entity, e",
-			Value:    Entities,
-			Usage:    "The severity of the event: " + Entities.EnumAsString(),
-			Required: true,
+			Name:  "entity, e",
+			Value: Entities,
+			Usage: "The entity to search: " + Entities.EnumAsString(),
 		},
 		cli.StringFlag{
 			Name:  "filter, f",
 			Usage: "The filter to apply in FIQL format",
 		},
+		cli.StringSliceFlag{
+			Name:  "where, w",
+			Usage: "A property condition, like label==srv* (can be used multiple times)",
+		},
+		cli.StringFlag{
+			Name:  "severity, s",
+			Usage: "Filter by severity, like ge:MAJOR (only for events, alarms, and situations)",
+		},
+		cli.DurationFlag{
+			Name:  "since",
+			Usage: "Only include entities created or updated within the given period (e.x. 1h, 30m)",
+		},
+		cli.StringFlag{
+			Name:  "node, n",
+			Usage: "Filter by node ID or label (only for entities that belong to a node)",
+		},
+		cli.StringFlag{
+			Name:  "orderBy",
+			Usage: "The property used to sort the entities",
+		},
+		cli.GenericFlag{
+			Name:  "order",
+			Value: &model.EnumValue{Enum: model.SearchOrders.Enum},
+			Usage: "The sort order: " + model.SearchOrders.EnumAsString(),
+		},
 		cli.IntFlag{
 			Name:  "limit, l",
 			Usage: "The amount of entities per query",
@@ -40,42 +81,155 @@ var CliCommand = cli.Command{
 			Usage: "The starting entity index (for pagination)",
 			Value: 0,
 		},
+		cli.BoolFlag{
+			Name:  "all, a",
+			Usage: "Get all the entities, walking every page of --limit entities",
+		},
+	},
+	Action: searchEntities,
+	Subcommands: []cli.Command{
+		{
+			Name:         "fields",
+			Usage:        "List the searchable properties of an entity, as exposed by the server",
+			ArgsUsage:    "<entity>",
+			Action:       listFields,
+			BashComplete: entityBashComplete,
+		},
 	},
-	Action: func(c *cli.Context) error {
-		entity := c.String("entity")
-		if entity == "" {
-			return fmt.Errorf("Entity required; options: %s", Entities.EnumAsString())
+}
+
+func searchEntities(c *cli.Context) error {
+	entity := c.String("entity")
+	if entity == "" {
+		return fmt.Errorf("Entity required; options: %s", Entities.EnumAsString())
+	}
+	filter, err := buildFilter(c, time.Now())
+	if err != nil {
+		return err
+	}
+	query := model.SearchQuery{
+		Filter:  filter,
+		OrderBy: c.String("orderBy"),
+		Order:   c.String("order"),
+		Limit:   c.Int("limit"),
+		Offset:  c.Int("offset"),
+	}
+	api := services.GetSearchAPI(rest.Instance)
+	var list model.SearchList
+	if c.Bool("all") {
+		list, err = api.SearchAll(entity, query)
+	} else {
+		list, err = api.Search(entity, query)
+	}
+	if err != nil {
+		return err
+	}
+	return common.Print(list, buildTable(entity, list))
+}
+
+func listFields(c *cli.Context) error {
+	entity := c.Args().First()
+	if entity == "" {
+		return fmt.Errorf("entity required; options: %s", Entities.EnumAsString())
+	}
+	list, err := services.GetSearchAPI(rest.Instance).GetProperties(entity)
+	if err != nil {
+		return err
+	}
+	table := &common.Table{
+		Headers:     []string{"Property", "Name", "Type", "Sortable"},
+		WideHeaders: []string{"Values"},
+		Empty:       fmt.Sprintf("There are no searchable properties for %s", entity),
+	}
+	for _, p := range list.Properties {
+		values := make([]string, 0, len(p.Values))
+		for k, v := range p.Values {
+			values = append(values, k+"="+v)
 		}
-		url := fmt.Sprintf("/api/v2/%s?limit=%d&offset=%d", entity, c.Int("limit"), c.Int("offset"))
-		filter := c.String("filter")
-		if filter != "" {
-			url += "&_s=" + filter
+		sort.Strings(values)
+		table.AddRow(p.ID, p.Name, p.Type, p.OrderBy, strings.Join(values, ","))
+	}
+	return common.Print(list, table)
+}
+
+func entityBashComplete(c *cli.Context) {
+	if c.NArg() > 0 {
+		return
+	}
+	for _, name := range Entities.Enum {
+		fmt.Println(name)
+	}
+}
+
+// buildFilter builds the FIQL expression combining the filter flags
+func buildFilter(c *cli.Context, now time.Time) (string, error) {
+	entity, err := model.GetSearchEntity(c.String("entity"))
+	if err != nil {
+		return "", err
+	}
+	filters := make([]string, 0)
+	if filter := c.String("filter"); filter != "" {
+		if err := model.ValidateFiql(filter); err != nil {
+			return "", err
 		}
-		jsonBytes, err := rest.Instance.Get(url)
+		filters = append(filters, filter)
+	}
+	for _, where := range c.StringSlice("where") {
+		constraint, err := model.ParseFiqlConstraint(where)
 		if err != nil {
-			return err
+			return "", err
 		}
-		if len(jsonBytes) == 0 {
-			fmt.Printf("There is no data for %s\n", entity)
-			return nil
+		filters = append(filters, constraint.String())
+	}
+	if severity := c.String("severity"); severity != "" {
+		constraint, err := parseSeverity(entity, severity)
+		if err != nil {
+			return "", err
 		}
-		var data interface{}
-		switch entity {
-		case "nodes":
-			data = &model.OnmsNodeList{}
-		case "events":
-			data = &model.OnmsEventList{}
-		case "alarms":
-			data = &model.OnmsAlarmList{}
-		case "outages":
-			data = &model.OnmsOutageList{}
+		filters = append(filters, constraint.String())
+	}
+	if since := c.Duration("since"); since != 0 {
+		if since < 0 {
+			return "", fmt.Errorf("the period defined by --since cannot be negative")
 		}
-		err = json.Unmarshal(jsonBytes, data)
-		if err != nil {
-			return err
+		if entity.TimeProperty == "" {
+			return "", fmt.Errorf("--since is not supported when searching %s", entity.Name)
 		}
-		yamlBytes, _ := yaml.Marshal(data)
-		fmt.Println(string(yamlBytes))
-		return nil
-	},
+		constraint := model.FiqlConstraint{Selector: entity.TimeProperty, Operator: "=ge=", Value: now.Add(-since).Format(model.FiqlTimeFormat)}
+		filters = append(filters, constraint.String())
+	}
+	if node := c.String("node"); node != "" {
+		if !entity.NodeFilter {
+			return "", fmt.Errorf("--node is not supported when searching %s", entity.Name)
+		}
+		filters = append(filters, model.NodeFiqlConstraint(node).String())
+	}
+	return model.AndFiql(filters...), nil
+}
+
+// parseSeverity parses a severity filter in the form [operator:]severity
+func parseSeverity(entity *model.SearchEntity, text string) (model.FiqlConstraint, error) {
+	constraint := model.FiqlConstraint{Selector: entity.SeverityProperty, Operator: "=="}
+	if entity.SeverityProperty == "" {
+		return constraint, fmt.Errorf("--severity is not supported when searching %s", entity.Name)
+	}
+	severity := text
+	if data := strings.SplitN(text, ":", 2); len(data) == 2 {
+		op, ok := severityOperators[strings.ToLower(data[0])]
+		if !ok {
+			return constraint, fmt.Errorf("invalid severity operator %s in %s, expected one of eq, ne, lt, le, gt, ge", data[0], text)
+		}
+		constraint.Operator = op
+		severity = data[1]
+	}
+	id := model.GetSeverityID(severity)
+	if id == 0 {
+		return constraint, fmt.Errorf("invalid severity %s in %s", severity, text)
+	}
+	if entity.NumericSeverity {
+		constraint.Value = strconv.Itoa(id)
+	} else {
+		constraint.Value = strings.ToUpper(severity)
+	}
+	return constraint, nil
 }
@@ -0,0 +1,187 @@
+package search
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/OpenNMS/onmsctl/model"
+	"github.com/OpenNMS/onmsctl/rest"
+	"github.com/OpenNMS/onmsctl/test"
+	"github.com/urfave/cli"
+	"gotest.tools/assert"
+)
+
+// createAlarmsMockServer simulates 25 alarms, and keeps track of the requested offsets
+func createAlarmsMockServer(t *testing.T, expectedFilter string, expectedOrder string) (*httptest.Server, *[]int) {
+	offsets := make([]int, 0)
+	m := sync.Mutex{}
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query()
+		if req.URL.Path != "/api/v2/alarms" || query.Get("_s") != expectedFilter || query.Get("orderBy")+":"+query.Get("order") != expectedOrder {
+			t.Errorf("unexpected request %s", req.URL.String())
+			res.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		m.Lock()
+		offsets = append(offsets, offset)
+		m.Unlock()
+		list := model.OnmsAlarmList{TotalCount: 25, Offset: offset}
+		for i := offset; i < offset+limit && i < 25; i++ {
+			list.Alarms = append(list.Alarms, model.OnmsAlarm{ID: i + 1, Severity: "MAJOR"})
+		}
+		list.Count = len(list.Alarms)
+		bytes, _ := json.Marshal(list)
+		res.Write(bytes)
+	}))
+	rest.Instance.URL = server.URL
+	return server, &offsets
+}
+
+func TestSearch(t *testing.T) {
+	var err error
+	app := test.CreateCli(CliCommand)
+
+	server, offsets := createAlarmsMockServer(t, "(alarm.severity=ge=MAJOR);(node.label==srv01)", ":")
+	err = app.Run([]string{app.Name, "search", "-e", "alarms", "--severity", "ge:major", "--node", "srv01"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []int{0}, *offsets)
+	server.Close()
+
+	server, offsets = createAlarmsMockServer(t, "(alarm.uei==*nodeDown);(reductionKey==a%2Cb)", "id:")
+	err = app.Run([]string{app.Name, "search", "-e", "alarms", "-f", "alarm.uei==*nodeDown", "-w", "reductionKey==a,b", "--limit", "10", "--all"})
+	assert.NilError(t, err)
+	assert.Equal(t, 3, len(*offsets))
+	server.Close()
+
+	server, offsets = createAlarmsMockServer(t, "", "lastEventTime:desc")
+	err = app.Run([]string{app.Name, "search", "-e", "alarms", "--orderBy", "lastEventTime", "--order", "desc", "--offset", "5", "--all"})
+	assert.NilError(t, err)
+	assert.Equal(t, 2, len(*offsets))
+	server.Close()
+}
+
+func TestSearchInvalidFilters(t *testing.T) {
+	var err error
+	app := test.CreateCli(CliCommand)
+
+	err = app.Run([]string{app.Name, "search", "-e", "nodes", "-w", "label=~srv"})
+	assert.ErrorContains(t, err, `found "=~" at position 6`)
+
+	err = app.Run([]string{app.Name, "search", "-e", "nodes", "-f", "(label==srv*"})
+	assert.ErrorContains(t, err, "expected ) but found end of expression")
+
+	err = app.Run([]string{app.Name, "search", "-e", "nodes", "--severity", "MAJOR"})
+	assert.Error(t, err, "--severity is not supported when searching nodes")
+
+	err = app.Run([]string{app.Name, "search", "-e", "alarms", "--severity", "above:MAJOR"})
+	assert.ErrorContains(t, err, "invalid severity operator above")
+
+	err = app.Run([]string{app.Name, "search", "-e", "events", "--severity", "ge:BAD"})
+	assert.ErrorContains(t, err, "invalid severity BAD")
+}
+
+func TestBuildFilter(t *testing.T) {
+	app := test.CreateCli(CliCommand)
+	app.Commands[0].Action = func(c *cli.Context) error {
+		now, _ := time.Parse(model.FiqlTimeFormat, "2020-05-01T10:00:00.000+0000")
+		filter, err := buildFilter(c, now)
+		assert.NilError(t, err)
+		assert.Equal(t, "(eventSeverity=ge=6);(eventTime=ge=2020-05-01T09:00:00.000+0000);(node.id==1)", filter)
+		return nil
+	}
+	err := app.Run([]string{app.Name, "search", "-e", "events", "-s", "ge:major", "--since", "1h", "-n", "1"})
+	assert.NilError(t, err)
+}
+
+func TestSearchEntities(t *testing.T) {
+	var err error
+	app := test.CreateCli(CliCommand)
+
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		filter := req.URL.Query().Get("_s")
+		switch req.URL.Path {
+		case "/api/v2/snmpinterfaces":
+			if filter != "(ifSpeed=lt=1000000000);(collect==C)" {
+				t.Errorf("unexpected filter %s", filter)
+			}
+			res.Write([]byte(`{"count":1,"totalCount":1,"offset":0,"snmpInterface":[{"id":1,"ifIndex":2,"ifName":"eth0","ifSpeed":100000000,"collectFlag":"C"}]}`))
+		case "/api/v2/alarms":
+			if filter != "(isSituation==true);(node.label==srv01)" {
+				t.Errorf("unexpected filter %s", filter)
+			}
+			res.Write([]byte(`{"count":1,"totalCount":1,"offset":0,"alarm":[{"id":10,"severity":"MAJOR","nodeLabel":"srv01","relatedAlarms":[{"id":1},{"id":2}]}]}`))
+		case "/api/v2/ifservices":
+			res.Write([]byte(`{"count":1,"totalCount":1,"offset":0,"service":[{"id":3,"ipAddress":"10.0.0.1","nodeId":1,"nodeLabel":"srv01","serviceType":{"name":"ICMP"},"status":"A"}]}`))
+		case "/api/v2/applications":
+			res.Write([]byte(`{"count":1,"totalCount":1,"offset":0,"application":[{"id":1,"name":"Web","monitoredServices":[{"id":3,"serviceType":{"name":"HTTP"}}],"perspectiveLocations":[{"location-name":"Apex"}]}]}`))
+		case "/rest/business-services":
+			res.Write([]byte(`{"count":1,"totalCount":1,"offset":0,"business-services":[{"id":5,"name":"Web Shop","attributes":{"owner":"ops"},"reduce-function":{"type":"HighestSeverity"},"operational-status":"NORMAL"}]}`))
+		case "/api/v2/monitoringLocations":
+			if filter != "monitoringArea==US*" {
+				t.Errorf("unexpected filter %s", filter)
+			}
+			res.Write([]byte(`{"count":1,"totalCount":1,"offset":0,"location":[{"location-name":"US-East","monitoring-area":"US","priority":100,"tags":["dc"]}]}`))
+		case "/api/v2/snmpinterfaces/properties":
+			res.Write([]byte(`{"searchProperty":[{"id":"ifSpeed","name":"Interface Speed","type":"LONG","orderBy":true},{"id":"collect","name":"Collection","type":"STRING","orderBy":true,"values":{"C":"Collect","N":"Don't collect"}}]}`))
+		default:
+			res.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	rest.Instance.URL = server.URL
+
+	err = app.Run([]string{app.Name, "search", "-e", "snmpinterfaces", "-w", "ifSpeed<1000000000", "-w", "collect==C"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "search", "-e", "situations", "-n", "srv01"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "search", "-e", "monitoredservices"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "search", "-e", "applications"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "search", "-e", "applications", "-n", "srv01"})
+	assert.Error(t, err, "--node is not supported when searching applications")
+
+	err = app.Run([]string{app.Name, "search", "-e", "businessservices"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "search", "-e", "locations", "-w", "monitoringArea==US*"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "search", "fields", "snmpinterfaces"})
+	assert.NilError(t, err)
+
+	err = app.Run([]string{app.Name, "search", "fields", "locations"})
+	assert.ErrorContains(t, err, "the server doesn't expose the searchable properties of locations")
+
+	err = app.Run([]string{app.Name, "search", "fields", "unknown"})
+	assert.Error(t, err, "invalid entity unknown")
+}
+
+func TestBuildTable(t *testing.T) {
+	for _, e := range model.SearchEntities {
+		assert.Assert(t, buildTable(e.Name, e.NewList()) != nil, "missing table for %s", e.Name)
+	}
+}
+
+func TestBuildBusinessServicesTable(t *testing.T) {
+	list := &model.OnmsBusinessServiceList{Services: []model.OnmsBusinessService{{
+		ID:                5,
+		Name:              "Web Shop",
+		Attributes:        map[string]string{"tier": "1", "owner": "ops"},
+		ReduceFunction:    &model.BSReduceFunction{Type: "HighestSeverity"},
+		OperationalStatus: "NORMAL",
+	}}}
+	table := buildTable("businessservices", list)
+	assert.DeepEqual(t, []string{"5", "Web Shop", "NORMAL", "HighestSeverity", "owner=ops,tier=1"}, table.Rows[0])
+}
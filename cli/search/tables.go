@@ -0,0 +1,161 @@
+package search
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/OpenNMS/onmsctl/common"
+	"github.com/OpenNMS/onmsctl/model"
+)
+
+// buildTable builds the table to display the result of a search, based on the entity
+func buildTable(entity string, list model.SearchList) *common.Table {
+	switch l := list.(type) {
+	case *model.OnmsNodeList:
+		table := &common.Table{
+			Headers:     []string{"Node ID", "Node Label", "Foreign Source", "Foreign ID", "SNMP sysObjectID"},
+			WideHeaders: []string{"Location", "SNMP sysName", "SNMP sysLocation"},
+			Empty:       "There are no nodes",
+		}
+		for _, n := range l.Nodes {
+			table.AddRow(n.ID, n.Label, n.ForeignSource, n.ForeignID, n.SysObjectID, n.Location, n.SysName, n.SysLocation)
+		}
+		return table
+	case *model.OnmsEventList:
+		table := &common.Table{
+			Headers:     []string{"ID", "Time", "Severity", "Node", "UEI"},
+			WideHeaders: []string{"IP Address", "Source", "Log Message"},
+			Empty:       "There are no events",
+		}
+		for _, e := range l.Events {
+			table.AddRow(e.ID, common.GetDisplayTime(e.EventTime), e.Severity, getNodeLabel(e.NodeID, e.NodeLabel), e.UEI, e.IPAddress, e.EventSource, e.LogMessage)
+		}
+		return table
+	case *model.OnmsAlarmList:
+		if entity == "situations" {
+			table := &common.Table{
+				Headers:     []string{"ID", "Severity", "Node", "Related Alarms", "Last Event", "Ack User"},
+				WideHeaders: []string{"Reduction Key", "Log Message"},
+				Empty:       "There are no situations",
+			}
+			for _, a := range l.Alarms {
+				table.AddRow(a.ID, a.Severity, getNodeLabel(a.NodeID, a.NodeLabel), len(a.RelatedAlarms), common.GetDisplayTime(a.LastEventTime), a.AckUser, a.ReductionKey, a.LogMessage)
+			}
+			return table
+		}
+		table := &common.Table{
+			Headers:     []string{"ID", "Severity", "Node", "Count", "Last Event", "Ack User"},
+			WideHeaders: []string{"UEI", "Reduction Key", "Ticket", "Log Message"},
+			Empty:       "There are no alarms",
+		}
+		for _, a := range l.Alarms {
+			table.AddRow(a.ID, a.Severity, getNodeLabel(a.NodeID, a.NodeLabel), a.Count, common.GetDisplayTime(a.LastEventTime), a.AckUser, a.UEI, a.ReductionKey, a.TroubleTicketID, a.LogMessage)
+		}
+		return table
+	case *model.OnmsOutageList:
+		table := &common.Table{
+			Headers:     []string{"ID", "Node", "IP Address", "Service", "Lost", "Regained"},
+			WideHeaders: []string{"Location", "Foreign Source", "Foreign ID"},
+			Empty:       "There are no outages",
+		}
+		for _, o := range l.Outages {
+			table.AddRow(o.ID, getNodeLabel(o.NodeID, o.NodeLabel), o.IPAddress, o.GetServiceName(), common.GetDisplayTime(o.ServiceLostTime), common.GetDisplayTime(o.ServiceRegainedTime), o.Location, o.ForeignSource, o.ForeignID)
+		}
+		return table
+	case *model.OnmsIPInterfaceList:
+		table := &common.Table{
+			Headers:     []string{"ID", "Node ID", "IP Address", "Host Name", "Is Managed", "SNMP Primary", "Is Down"},
+			WideHeaders: []string{"ifIndex", "Services", "Last Poll"},
+			Empty:       "There are no IP interfaces",
+		}
+		for _, i := range l.Interfaces {
+			table.AddRow(i.ID, i.NodeID, i.IPAddress, i.HostName, i.IsManaged, i.SnmpPrimary, i.IsDown, i.IfIndex, i.MonitoredServiceCount, common.GetDisplayTime(i.LastPoll))
+		}
+		return table
+	case *model.OnmsSnmpInterfaceList:
+		table := &common.Table{
+			Headers:     []string{"ID", "ifIndex", "ifName", "ifDescr", "ifSpeed", "ifOperStatus", "ifAdminStatus", "Collect", "Poll"},
+			WideHeaders: []string{"ifAlias", "ifType", "Physical Address"},
+			Empty:       "There are no SNMP interfaces",
+		}
+		for _, i := range l.Interfaces {
+			table.AddRow(i.ID, i.IfIndex, i.IfName, i.IfDescr, i.IfSpeed, i.IfOperStatus, i.IfAdminStatus, i.CollectFlag, i.PollFlag, i.IfAlias, i.IfType, i.PhysAddress)
+		}
+		return table
+	case *model.OnmsMonitoredServiceList:
+		table := &common.Table{
+			Headers:     []string{"ID", "Node", "IP Address", "Service Name", "Status", "Is Down"},
+			WideHeaders: []string{"Last Good", "Last Fail"},
+			Empty:       "There are no monitored services",
+		}
+		for _, s := range l.Services {
+			name := "-"
+			if s.ServiceType != nil {
+				name = s.ServiceType.Name
+			}
+			table.AddRow(s.ID, getNodeLabel(s.NodeID, s.NodeLabel), s.IPAddress, name, s.Status, s.IsDown, common.GetDisplayTime(s.LastGood), common.GetDisplayTime(s.LastFail))
+		}
+		return table
+	case *model.OnmsApplicationList:
+		table := &common.Table{
+			Headers:     []string{"ID", "Name", "Services", "Perspective Locations"},
+			WideHeaders: []string{"Service Names"},
+			Empty:       "There are no applications",
+		}
+		for _, a := range l.Applications {
+			locations := make([]string, len(a.PerspectiveLocations))
+			for i, loc := range a.PerspectiveLocations {
+				locations[i] = loc.LocationName
+			}
+			services := make([]string, 0, len(a.MonitoredServices))
+			for _, s := range a.MonitoredServices {
+				if s.ServiceType != nil {
+					services = append(services, s.ServiceType.Name)
+				}
+			}
+			table.AddRow(a.ID, a.Name, len(a.MonitoredServices), strings.Join(locations, ","), strings.Join(services, ","))
+		}
+		return table
+	case *model.OnmsBusinessServiceList:
+		table := &common.Table{
+			Headers:     []string{"ID", "Name", "Operational Status", "Reduce Function"},
+			WideHeaders: []string{"Attributes"},
+			Empty:       "There are no business services",
+		}
+		for _, s := range l.Services {
+			function := "-"
+			if s.ReduceFunction != nil {
+				function = s.ReduceFunction.Type
+			}
+			attributes := make([]string, 0, len(s.Attributes))
+			for k, v := range s.Attributes {
+				attributes = append(attributes, k+"="+v)
+			}
+			sort.Strings(attributes)
+			table.AddRow(s.ID, s.Name, s.OperationalStatus, function, strings.Join(attributes, ","))
+		}
+		return table
+	case *model.MonitoringLocationList:
+		table := &common.Table{
+			Headers:     []string{"Name", "Monitoring Area", "Priority", "Geolocation"},
+			WideHeaders: []string{"Latitude", "Longitude", "Tags"},
+			Empty:       "There are no monitoring locations",
+		}
+		for _, loc := range l.Locations {
+			table.AddRow(loc.LocationName, loc.MonitoringArea, loc.Priority, loc.GeoLocation, loc.Latitude, loc.Longitude, strings.Join(loc.Tags, ","))
+		}
+		return table
+	}
+	return nil
+}
+
+func getNodeLabel(nodeID int, nodeLabel string) string {
+	if nodeLabel != "" {
+		return nodeLabel
+	}
+	if nodeID > 0 {
+		return strconv.Itoa(nodeID)
+	}
+	return "-"
+}
@@ -143,9 +143,7 @@ func showSnmpConfig(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	data, _ := yaml.Marshal(&snmp)
-	fmt.Println(string(data))
-	return nil
+	return common.Print(snmp, nil)
 }
 
 func setSnmpConfig(c *cli.Context) error {
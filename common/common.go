@@ -2,16 +2,18 @@ package common
 
 import (
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"text/tabwriter"
 
+	"github.com/OpenNMS/onmsctl/model"
 	"github.com/OpenNMS/onmsctl/rest"
 	"github.com/OpenNMS/onmsctl/services"
 	"github.com/urfave/cli"
 )
 
-var tableWriterOutput = os.Stdout
+var tableWriterOutput io.Writer = os.Stdout
 var inputStream = os.Stdin
 
 // Reads YAML configuration from file and place it on a target object
@@ -24,6 +26,17 @@ func NewTableWriter() *tabwriter.Writer {
 	return tabwriter.NewWriter(tableWriterOutput, 0, 8, 1, '\t', tabwriter.AlignRight)
 }
 
+// DisplayTimeFormat the format used to display times on tables
+const DisplayTimeFormat = "2006-01-02 15:04:05"
+
+// GetDisplayTime formats a time to be displayed on a table, using - when it is not set
+func GetDisplayTime(t *model.Time) string {
+	if t == nil || t.IsZero() {
+		return "-"
+	}
+	return t.Format(DisplayTimeFormat)
+}
+
 // ReadInput reads data from a file specified on the CLI context
 func ReadInput(c *cli.Context, dataIndex int) ([]byte, error) {
 	ymlFile := c.String("file")
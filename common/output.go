@@ -0,0 +1,294 @@
+package common
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Output formats supported by the --output flag
+const (
+	OutputYAML       = "yaml"
+	OutputJSON       = "json"
+	OutputTable      = "table"
+	OutputWide       = "wide"
+	OutputCSV        = "csv"
+	OutputJSONPath   = "jsonpath"
+	OutputGoTemplate = "go-template"
+)
+
+// OutputFormats the list of valid values for the --output flag
+var OutputFormats = []string{OutputYAML, OutputJSON, OutputTable, OutputWide, OutputCSV, OutputJSONPath + "=<expr>", OutputGoTemplate + "=<tpl>"}
+
+// OutputFormat an output format with its optional expression (used by jsonpath and go-template)
+type OutputFormat struct {
+	Name       string
+	Expression string
+}
+
+// Output the output format selected through the global --output flag
+// When empty, commands use their default format: a table for lists, YAML for individual objects.
+var Output = &OutputFormat{}
+
+// Set parses an output format; implements flag.Value
+func (f *OutputFormat) Set(value string) error {
+	name, expr := value, ""
+	if idx := strings.Index(value, "="); idx > 0 {
+		name, expr = value[:idx], value[idx+1:]
+	}
+	switch name {
+	case OutputYAML, OutputJSON, OutputTable, OutputWide, OutputCSV:
+		if expr != "" {
+			return fmt.Errorf("output format %s doesn't accept an expression", name)
+		}
+	case OutputJSONPath, OutputGoTemplate:
+		if expr == "" {
+			return fmt.Errorf("output format %s requires an expression, e.x. %s=<expression>", name, name)
+		}
+		if name == OutputGoTemplate {
+			if _, err := template.New("output").Parse(expr); err != nil {
+				return fmt.Errorf("invalid template: %v", err)
+			}
+		}
+	default:
+		return fmt.Errorf("invalid output format %s, valid formats: %s", value, strings.Join(OutputFormats, ", "))
+	}
+	f.Name = name
+	f.Expression = expr
+	return nil
+}
+
+func (f *OutputFormat) String() string {
+	if f.Expression == "" {
+		return f.Name
+	}
+	return f.Name + "=" + f.Expression
+}
+
+// IsTable returns true when the output is going to be a human readable table
+func (f *OutputFormat) IsTable() bool {
+	return f.Name == "" || f.Name == OutputTable || f.Name == OutputWide
+}
+
+// Table a tabular representation of an object, used by the table, wide and csv output formats
+// WideHeaders are additional columns only displayed with the wide and csv formats; each row must contain a cell for every header, including the wide ones.
+// Empty is the message displayed instead of the table when there are no rows.
+type Table struct {
+	Headers     []string
+	WideHeaders []string
+	Rows        [][]string
+	Empty       string
+}
+
+// AddRow adds a row to the table, converting each cell to a string
+func (t *Table) AddRow(cells ...interface{}) {
+	row := make([]string, len(cells))
+	for i, cell := range cells {
+		row[i] = fmt.Sprint(cell)
+	}
+	t.Rows = append(t.Rows, row)
+}
+
+// Print writes an object to the standard output using the format selected with the global --output flag
+// The table is used for the table, wide and csv formats, and it is the default format when provided; otherwise, YAML is the default.
+func Print(object interface{}, table *Table) error {
+	format := Output.Name
+	if format == "" {
+		format = OutputYAML
+		if table != nil {
+			format = OutputTable
+		}
+	}
+	switch format {
+	case OutputYAML:
+		data, err := yaml.Marshal(object)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(tableWriterOutput, string(data))
+	case OutputJSON:
+		data, err := json.MarshalIndent(object, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(tableWriterOutput, string(data))
+	case OutputTable, OutputWide, OutputCSV:
+		if table == nil {
+			return fmt.Errorf("output format %s is not supported by this command", format)
+		}
+		if format == OutputCSV {
+			return printCSV(table)
+		}
+		printTable(table, format == OutputWide)
+	case OutputJSONPath:
+		data, err := toGeneric(object)
+		if err != nil {
+			return err
+		}
+		results, err := evalJSONPath(Output.Expression, data)
+		if err != nil {
+			return err
+		}
+		values := make([]string, len(results))
+		for i, r := range results {
+			values[i] = formatJSONValue(r)
+		}
+		fmt.Fprintln(tableWriterOutput, strings.Join(values, " "))
+	case OutputGoTemplate:
+		data, err := toGeneric(object)
+		if err != nil {
+			return err
+		}
+		tpl, err := template.New("output").Parse(Output.Expression)
+		if err != nil {
+			return fmt.Errorf("invalid template: %v", err)
+		}
+		return tpl.Execute(tableWriterOutput, data)
+	}
+	return nil
+}
+
+func printTable(table *Table, wide bool) {
+	if len(table.Rows) == 0 && table.Empty != "" {
+		fmt.Fprintln(tableWriterOutput, table.Empty)
+		return
+	}
+	headers := table.Headers
+	if wide {
+		headers = append(append([]string{}, table.Headers...), table.WideHeaders...)
+	}
+	writer := NewTableWriter()
+	fmt.Fprintln(writer, strings.Join(headers, "\t"))
+	for _, row := range table.Rows {
+		if len(row) > len(headers) {
+			row = row[:len(headers)]
+		}
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+	writer.Flush()
+}
+
+func printCSV(table *Table) error {
+	writer := csv.NewWriter(tableWriterOutput)
+	writer.Write(append(append([]string{}, table.Headers...), table.WideHeaders...))
+	writer.WriteAll(table.Rows)
+	return writer.Error()
+}
+
+// toGeneric converts an object into its generic JSON representation (maps, slices and scalars)
+// This way, jsonpath expressions and templates use the same field names as the JSON output.
+func toGeneric(object interface{}) (interface{}, error) {
+	data, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
+
+// evalJSONPath evaluates a JSONPath expression against the generic representation of an object
+// Supports a subset of the syntax: child fields (.name), wildcards (.* and [*]) and array indexes ([0] or [-1]), optionally wrapped with {}.
+func evalJSONPath(expression string, data interface{}) ([]interface{}, error) {
+	path := strings.TrimSpace(expression)
+	if strings.HasPrefix(path, "{") && strings.HasSuffix(path, "}") {
+		path = strings.TrimSpace(path[1 : len(path)-1])
+	}
+	path = strings.TrimPrefix(path, "$")
+	if path != "" && path[0] != '.' && path[0] != '[' {
+		path = "." + path
+	}
+	results := []interface{}{data}
+	for path != "" {
+		next := make([]interface{}, 0)
+		switch path[0] {
+		case '.':
+			path = path[1:]
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			key := path[:end]
+			path = path[end:]
+			if key == "" {
+				continue
+			}
+			for _, r := range results {
+				if m, ok := r.(map[string]interface{}); ok {
+					if key == "*" {
+						for _, k := range sortedMapKeys(m) {
+							next = append(next, m[k])
+						}
+					} else if v, ok := m[key]; ok {
+						next = append(next, v)
+					}
+				}
+			}
+		case '[':
+			end := strings.Index(path, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("invalid JSONPath expression %s: missing ]", expression)
+			}
+			index := strings.TrimSpace(path[1:end])
+			path = path[end+1:]
+			for _, r := range results {
+				array, ok := r.([]interface{})
+				if !ok {
+					continue
+				}
+				if index == "*" {
+					next = append(next, array...)
+					continue
+				}
+				i, err := strconv.Atoi(index)
+				if err != nil {
+					return nil, fmt.Errorf("invalid JSONPath expression %s: invalid index %s", expression, index)
+				}
+				if i < 0 {
+					i += len(array)
+				}
+				if i >= 0 && i < len(array) {
+					next = append(next, array[i])
+				}
+			}
+		default:
+			return nil, fmt.Errorf("invalid JSONPath expression %s", expression)
+		}
+		results = next
+	}
+	return results, nil
+}
+
+func sortedMapKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatJSONValue prints strings and numbers as they are, and complex objects as JSON
+func formatJSONValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case nil:
+		return ""
+	}
+	data, _ := json.Marshal(value)
+	return string(data)
+}
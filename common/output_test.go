@@ -0,0 +1,99 @@
+package common
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+type testItem struct {
+	Name  string   `json:"name" yaml:"name"`
+	Count int      `json:"count" yaml:"count"`
+	Tags  []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+}
+
+func printWithFormat(t *testing.T, format string, object interface{}, table *Table) string {
+	t.Helper()
+	buffer := &bytes.Buffer{}
+	tableWriterOutput = buffer
+	defer func() {
+		tableWriterOutput = os.Stdout
+		Output = &OutputFormat{}
+	}()
+	Output = &OutputFormat{}
+	if format != "" {
+		assert.NilError(t, Output.Set(format))
+	}
+	assert.NilError(t, Print(object, table))
+	return buffer.String()
+}
+
+func createTestTable(items []testItem) *Table {
+	table := &Table{
+		Headers:     []string{"Name", "Count"},
+		WideHeaders: []string{"Tags"},
+		Empty:       "There are no items",
+	}
+	for _, item := range items {
+		table.AddRow(item.Name, item.Count, len(item.Tags))
+	}
+	return table
+}
+
+func TestOutputFormatSet(t *testing.T) {
+	format := &OutputFormat{}
+	assert.NilError(t, format.Set("jsonpath={.name}"))
+	assert.Equal(t, OutputJSONPath, format.Name)
+	assert.Equal(t, "{.name}", format.Expression)
+	assert.Equal(t, "jsonpath={.name}", format.String())
+
+	assert.ErrorContains(t, format.Set("xml"), "invalid output format xml")
+	assert.ErrorContains(t, format.Set("json=.name"), "doesn't accept an expression")
+	assert.ErrorContains(t, format.Set("jsonpath="), "requires an expression")
+	assert.ErrorContains(t, format.Set("go-template={{.name"), "invalid template")
+}
+
+func TestPrint(t *testing.T) {
+	items := []testItem{{Name: "a", Count: 1, Tags: []string{"x", "y"}}, {Name: "b", Count: 2}}
+	table := createTestTable(items)
+
+	assert.Equal(t, "Name\tCount\na\t1\nb\t2\n", printWithFormat(t, "", items, table))
+	assert.Equal(t, "Name\tCount\tTags\na\t1\t2\nb\t2\t0\n", printWithFormat(t, "wide", items, table))
+	assert.Equal(t, "Name,Count,Tags\na,1,2\nb,2,0\n", printWithFormat(t, "csv", items, table))
+	assert.Equal(t, "There are no items\n", printWithFormat(t, "table", []testItem{}, createTestTable(nil)))
+	assert.Equal(t, "- name: a\n  count: 1\n  tags:\n  - x\n  - \"y\"\n\n", printWithFormat(t, "", items[0:1], nil))
+	assert.Equal(t, "[\n  {\n    \"name\": \"b\",\n    \"count\": 2\n  }\n]\n", printWithFormat(t, "json", items[1:], table))
+	assert.Equal(t, "a b\n", printWithFormat(t, "jsonpath={[*].name}", items, table))
+	assert.Equal(t, "y\n", printWithFormat(t, "jsonpath=[0].tags[-1]", items, table))
+	assert.Equal(t, "a=1;b=2;", printWithFormat(t, "go-template={{range .}}{{.name}}={{.count}};{{end}}", items, table))
+}
+
+func TestPrintWithoutTable(t *testing.T) {
+	Output = &OutputFormat{}
+	defer func() { Output = &OutputFormat{} }()
+	assert.NilError(t, Output.Set("wide"))
+	assert.Error(t, Print(testItem{Name: "a"}, nil), "output format wide is not supported by this command")
+}
+
+func TestEvalJSONPath(t *testing.T) {
+	data, err := toGeneric(map[string]interface{}{"nodes": []testItem{{Name: "a", Count: 1000000}}})
+	assert.NilError(t, err)
+	results, err := evalJSONPath("{.nodes[0].count}", data)
+	assert.NilError(t, err)
+	assert.Equal(t, "1000000", formatJSONValue(results[0]))
+
+	results, err = evalJSONPath("$.nodes[*]", data)
+	assert.NilError(t, err)
+	assert.Equal(t, `{"count":1000000,"name":"a"}`, formatJSONValue(results[0]))
+
+	results, err = evalJSONPath(".unknown.field", data)
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(results))
+
+	_, err = evalJSONPath(".nodes[x]", data)
+	assert.ErrorContains(t, err, "invalid index x")
+	_, err = evalJSONPath(".nodes[0", data)
+	assert.ErrorContains(t, err, "missing ]")
+}
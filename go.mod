@@ -4,7 +4,8 @@ go 1.23
 
 require (
 	dario.cat/mergo v1.0.1
-	github.com/google/go-cmp v0.5.2
+	github.com/google/go-cmp v0.6.0
+	github.com/gosnmp/gosnmp v1.38.0
 	github.com/urfave/cli v1.22.16
 	gopkg.in/yaml.v2 v2.4.0
 	gotest.tools v2.2.0+incompatible
@@ -1,5 +1,24 @@
 package model
 
+// AlarmActions list of valid actions that can be performed on alarms
+var AlarmActions = EnumValue{
+	Enum: []string{"ack", "unack", "escalate", "clear", "ticketCreate", "ticketUpdate", "ticketClose"},
+}
+
+// AlarmMemoTypes list of valid memo types; sticky memos belong to a single alarm, journal memos to all the alarms with the same reduction key
+var AlarmMemoTypes = EnumValue{
+	Enum: []string{"sticky", "journal"},
+}
+
+// OnmsMemo a memo (or note) attached to an alarm
+type OnmsMemo struct {
+	ID      int    `json:"id,omitempty" yaml:"id,omitempty"`
+	Body    string `json:"body,omitempty" yaml:"body,omitempty"`
+	Author  string `json:"author,omitempty" yaml:"author,omitempty"`
+	Created *Time  `json:"created,omitempty" yaml:"created,omitempty"`
+	Updated *Time  `json:"updated,omitempty" yaml:"updated,omitempty"`
+}
+
 // OnmsAlarm OpenNMS alarm entity
 type OnmsAlarm struct {
 	// Inherit from Events
@@ -55,6 +74,21 @@ type OnmsAlarm struct {
 	FirstEventTime        *Time      `json:"firstEventTime,omitempty" yaml:"firstEventTime,omitempty"`
 	LastEventTime         *Time      `json:"lastEventTime,omitempty" yaml:"lastEventTime,omitempty"`
 	LastEvent             *OnmsEvent `json:"lastEvent,omitempty" yaml:"-"`
+	StickyMemo            *OnmsMemo  `json:"stickyMemo,omitempty" yaml:"stickyMemo,omitempty"`
+	JournalMemo           *OnmsMemo  `json:"reductionKeyMemo,omitempty" yaml:"journalMemo,omitempty"`
+	// Situation fields
+	RelatedAlarms []OnmsAlarmSummary `json:"relatedAlarms,omitempty" yaml:"relatedAlarms,omitempty"`
+}
+
+// OnmsAlarmSummary the summary of an alarm that is part of a situation
+type OnmsAlarmSummary struct {
+	ID           int    `json:"id" yaml:"id"`
+	Type         int    `json:"type,omitempty" yaml:"type,omitempty"`
+	Severity     string `json:"severity,omitempty" yaml:"severity,omitempty"`
+	ReductionKey string `json:"reductionKey,omitempty" yaml:"reductionKey,omitempty"`
+	UEI          string `json:"uei,omitempty" yaml:"uei,omitempty"`
+	NodeLabel    string `json:"nodeLabel,omitempty" yaml:"nodeLabel,omitempty"`
+	LogMessage   string `json:"logMessage,omitempty" yaml:"logMessage,omitempty"`
 }
 
 // OnmsAlarmList a list of alarms
@@ -64,3 +98,16 @@ type OnmsAlarmList struct {
 	Offset     int         `json:"offset" yaml:"offset"`
 	Alarms     []OnmsAlarm `json:"alarm" yaml:"alarms"`
 }
+
+// Merge appends the alarms of another page of the list
+func (list *OnmsAlarmList) Merge(page SearchList) {
+	if p, ok := page.(*OnmsAlarmList); ok {
+		list.Alarms = append(list.Alarms, p.Alarms...)
+		list.Count = len(list.Alarms)
+	}
+}
+
+// Len gets the amount of alarms on the list
+func (list *OnmsAlarmList) Len() int {
+	return len(list.Alarms)
+}
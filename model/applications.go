@@ -0,0 +1,30 @@
+package model
+
+// OnmsApplication an OpenNMS application, a group of monitored services that can be polled from multiple locations
+type OnmsApplication struct {
+	ID                   int                    `json:"id" yaml:"id"`
+	Name                 string                 `json:"name" yaml:"name"`
+	MonitoredServices    []OnmsMonitoredService `json:"monitoredServices,omitempty" yaml:"monitoredServices,omitempty"`
+	PerspectiveLocations []MonitoringLocation   `json:"perspectiveLocations,omitempty" yaml:"perspectiveLocations,omitempty"`
+}
+
+// OnmsApplicationList a list of applications
+type OnmsApplicationList struct {
+	Count        int               `json:"count" yaml:"count"`
+	TotalCount   int               `json:"totalCount" yaml:"totalCount"`
+	Offset       int               `json:"offset" yaml:"offset"`
+	Applications []OnmsApplication `json:"application" yaml:"applications"`
+}
+
+// Merge appends the applications of another page of the list
+func (list *OnmsApplicationList) Merge(page SearchList) {
+	if p, ok := page.(*OnmsApplicationList); ok {
+		list.Applications = append(list.Applications, p.Applications...)
+		list.Count = len(list.Applications)
+	}
+}
+
+// Len gets the amount of applications on the list
+func (list *OnmsApplicationList) Len() int {
+	return len(list.Applications)
+}
@@ -0,0 +1,26 @@
+package model
+
+import "fmt"
+
+// BackupManifest describes the content of a provisioning backup
+type BackupManifest struct {
+	CreatedAt    string    `json:"createdAt" yaml:"createdAt"`
+	ServerURL    string    `json:"serverUrl" yaml:"serverUrl"`
+	ServerInfo   *OnmsInfo `json:"serverInfo,omitempty" yaml:"serverInfo,omitempty"`
+	Requisitions []string  `json:"requisitions" yaml:"requisitions"`
+}
+
+// Validate returns an error if the manifest is invalid
+func (m BackupManifest) Validate() error {
+	names := make(map[string]bool)
+	for _, name := range m.Requisitions {
+		if name == "" {
+			return fmt.Errorf("requisition name cannot be empty")
+		}
+		if names[name] {
+			return fmt.Errorf("requisition %s is listed more than once", name)
+		}
+		names[name] = true
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package model
+
+// OnmsBusinessService an OpenNMS business service, which computes its status from the edges that represent its dependencies
+type OnmsBusinessService struct {
+	ID                int               `json:"id" yaml:"id"`
+	Name              string            `json:"name" yaml:"name"`
+	Attributes        map[string]string `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+	ReduceFunction    *BSReduceFunction `json:"reduce-function,omitempty" yaml:"reduceFunction,omitempty"`
+	OperationalStatus string            `json:"operational-status,omitempty" yaml:"operationalStatus,omitempty"`
+	ParentServices    []int             `json:"parent-services,omitempty" yaml:"parentServices,omitempty"`
+}
+
+// BSReduceFunction the function used to compute the status of a business service from the status of its edges
+type BSReduceFunction struct {
+	Type       string            `json:"type" yaml:"type"`
+	Properties map[string]string `json:"properties,omitempty" yaml:"properties,omitempty"`
+}
+
+// OnmsBusinessServiceList a list of business services
+type OnmsBusinessServiceList struct {
+	Count      int                   `json:"count" yaml:"count"`
+	TotalCount int                   `json:"totalCount" yaml:"totalCount"`
+	Offset     int                   `json:"offset" yaml:"offset"`
+	Services   []OnmsBusinessService `json:"business-services" yaml:"businessServices"`
+}
+
+// Merge appends the business services of another page of the list
+func (list *OnmsBusinessServiceList) Merge(page SearchList) {
+	if p, ok := page.(*OnmsBusinessServiceList); ok {
+		list.Services = append(list.Services, p.Services...)
+		list.Count = len(list.Services)
+	}
+}
+
+// Len gets the amount of business services on the list
+func (list *OnmsBusinessServiceList) Len() int {
+	return len(list.Services)
+}
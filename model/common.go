@@ -19,13 +19,21 @@ type EnumValue struct {
 
 // Set sets a value of the enum
 func (e *EnumValue) Set(value string) error {
+	if e.isValid(value) {
+		e.selected = value
+		return nil
+	}
+	return fmt.Errorf("allowed values are %s", strings.Join(e.Enum, ", "))
+}
+
+// isValid verifies if a value is part of the enum, without changing the selected value
+func (e EnumValue) isValid(value string) bool {
 	for _, enum := range e.Enum {
 		if enum == value {
-			e.selected = value
-			return nil
+			return true
 		}
 	}
-	return fmt.Errorf("allowed values are %s", strings.Join(e.Enum, ", "))
+	return false
 }
 
 // String gets the value of the enum as string
@@ -0,0 +1,29 @@
+package model
+
+// OnmsDaemon the status of an OpenNMS daemon, as reported by the server
+type OnmsDaemon struct {
+	Name       string `json:"name" yaml:"name"`
+	Internal   bool   `json:"internal" yaml:"internal"`
+	Enabled    bool   `json:"enabled" yaml:"enabled"`
+	Reloadable bool   `json:"reloadable" yaml:"reloadable"`
+	Status     string `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+// OnmsDaemonStatus the runtime status of an OpenNMS daemon (e.x. running or stopped)
+type OnmsDaemonStatus struct {
+	Name   string `json:"name" yaml:"name"`
+	Status string `json:"status" yaml:"status"`
+}
+
+// OnmsHealth the health of the OpenNMS server, as reported by its health checks
+type OnmsHealth struct {
+	Healthy   bool                 `json:"healthy" yaml:"healthy"`
+	Responses []OnmsHealthResponse `json:"responses,omitempty" yaml:"responses,omitempty"`
+}
+
+// OnmsHealthResponse the result of a single health check
+type OnmsHealthResponse struct {
+	Description string `json:"description" yaml:"description"`
+	Status      string `json:"status" yaml:"status"`
+	Message     string `json:"message,omitempty" yaml:"message,omitempty"`
+}
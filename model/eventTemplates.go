@@ -0,0 +1,138 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v2"
+)
+
+// BuiltInEventTemplateSource the source of the event templates shipped with onmsctl
+const BuiltInEventTemplateSource = "built-in"
+
+// BuiltInEventTemplates the event templates shipped with onmsctl; templates stored by the user with the same name take precedence
+var BuiltInEventTemplates = map[string]string{
+	"reloadDaemonConfig": `# Reloads the configuration of a daemon, optionally from a specific file
+uei: uei.opennms.org/internal/reloadDaemonConfig
+source: onmsctl
+parameters:
+- name: daemonName
+  value: {{ required "daemon" .daemon | quote }}
+{{- if .configFile }}
+- name: configFile
+  value: {{ quote .configFile }}
+{{- end }}
+`,
+	"nodeAdded": `# Notifies that a node has been added
+uei: uei.opennms.org/nodes/nodeAdded
+source: onmsctl
+nodeID: {{ required "node" .node }}
+parameters:
+- name: nodelabel
+  value: {{ required "label" .label | quote }}
+- name: nodelabelsource
+  value: {{ default "U" .labelSource | quote }}
+`,
+	"forceRescan": `# Forces a rescan of an existing node
+uei: uei.opennms.org/internal/capsd/forceRescan
+source: onmsctl
+nodeID: {{ required "node" .node }}
+`,
+}
+
+var templateVarPattern = regexp.MustCompile(`\.([A-Za-z_][A-Za-z0-9_]*)`)
+
+var templateActionPattern = regexp.MustCompile(`{{(.*?)}}`)
+
+var templateFuncs = template.FuncMap{
+	"default": func(defaultValue string, value string) string {
+		if value == "" {
+			return defaultValue
+		}
+		return value
+	},
+	"required": func(name string, value string) (string, error) {
+		if value == "" {
+			return "", fmt.Errorf("variable %s is required", name)
+		}
+		return value, nil
+	},
+	// Builds a double-quoted string, escaping the characters that would break the YAML content
+	"quote": strconv.Quote,
+}
+
+// EventTemplate an event in YAML format with Go-template placeholders
+type EventTemplate struct {
+	Name        string `json:"name" yaml:"name"`
+	Source      string `json:"source" yaml:"source"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Content     string `json:"content" yaml:"content"`
+}
+
+// NewEventTemplate creates an event template, using the leading comment of the content as description
+func NewEventTemplate(name string, source string, content string) EventTemplate {
+	t := EventTemplate{Name: name, Source: source, Content: content}
+	firstLine := strings.SplitN(strings.TrimSpace(content), "\n", 2)[0]
+	if strings.HasPrefix(firstLine, "#") {
+		t.Description = strings.TrimSpace(strings.TrimPrefix(firstLine, "#"))
+	}
+	return t
+}
+
+// Variables gets the sorted list of variables referenced by the template
+func (t EventTemplate) Variables() []string {
+	found := make(map[string]bool)
+	for _, action := range templateActionPattern.FindAllStringSubmatch(t.Content, -1) {
+		for _, v := range templateVarPattern.FindAllStringSubmatch(action[1], -1) {
+			found[v[1]] = true
+		}
+	}
+	vars := make([]string, 0, len(found))
+	for v := range found {
+		vars = append(vars, v)
+	}
+	sort.Strings(vars)
+	return vars
+}
+
+// Render builds and validates an event replacing the placeholders with the given variables
+// Missing variables are rendered as empty strings, unless they are wrapped with the required function.
+func (t EventTemplate) Render(vars map[string]string) (*Event, error) {
+	tmpl, err := template.New(t.Name).Option("missingkey=zero").Funcs(templateFuncs).Parse(t.Content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template %s: %v", t.Name, err)
+	}
+	if vars == nil {
+		vars = make(map[string]string)
+	}
+	buffer := &bytes.Buffer{}
+	if err := tmpl.Execute(buffer, vars); err != nil {
+		return nil, fmt.Errorf("cannot render template %s: %v", t.Name, err)
+	}
+	event := &Event{}
+	if err := yaml.Unmarshal(buffer.Bytes(), event); err != nil {
+		return nil, fmt.Errorf("template %s doesn't produce a valid event: %v", t.Name, err)
+	}
+	if err := event.Validate(); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// ParseTemplateVars parses a list of variables in the form key=value
+func ParseTemplateVars(values []string) (map[string]string, error) {
+	vars := make(map[string]string)
+	for _, v := range values {
+		data := strings.SplitN(v, "=", 2)
+		if len(data) != 2 || data[0] == "" {
+			return nil, fmt.Errorf("invalid variable %s, expected key=value", v)
+		}
+		vars[data[0]] = data[1]
+	}
+	return vars, nil
+}
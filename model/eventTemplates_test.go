@@ -0,0 +1,63 @@
+package model
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestEventTemplate(t *testing.T) {
+	tmpl := NewEventTemplate("disk-full", "test", `# Disk is almost full
+uei: uei.opennms.org/custom/diskFull
+source: onmsctl
+nodeID: {{ required "node" .node }}
+severity: {{ default "Warning" .severity }}
+parameters:
+- name: mount
+  value: {{ quote .mount }}
+`)
+	assert.Equal(t, "Disk is almost full", tmpl.Description)
+	assert.DeepEqual(t, []string{"mount", "node", "severity"}, tmpl.Variables())
+
+	event, err := tmpl.Render(map[string]string{"node": "42", "mount": "/var"})
+	assert.NilError(t, err)
+	assert.Equal(t, "uei.opennms.org/custom/diskFull", event.UEI)
+	assert.Equal(t, int64(42), event.NodeID)
+	assert.Equal(t, "Warning", event.Severity)
+	assert.DeepEqual(t, []EventParam{{Name: "mount", Value: "/var"}}, event.Parameters)
+
+	event, err = tmpl.Render(map[string]string{"node": "42", "mount": `C:\Data "shared": #1`})
+	assert.NilError(t, err)
+	assert.Equal(t, `C:\Data "shared": #1`, event.Parameters[0].Value)
+
+	_, err = tmpl.Render(map[string]string{"mount": "/var"})
+	assert.ErrorContains(t, err, "variable node is required")
+
+	_, err = tmpl.Render(map[string]string{"node": "42", "severity": "Bad"})
+	assert.ErrorContains(t, err, "allowed values are")
+
+	_, err = NewEventTemplate("broken", "test", "uei: {{ .uei").Render(nil)
+	assert.ErrorContains(t, err, "invalid template broken")
+}
+
+func TestBuiltInEventTemplates(t *testing.T) {
+	vars := map[string]string{"daemon": "Pollerd", "node": "1", "label": "srv01"}
+	for name, content := range BuiltInEventTemplates {
+		event, err := NewEventTemplate(name, BuiltInEventTemplateSource, content).Render(vars)
+		assert.NilError(t, err, name)
+		assert.Assert(t, event.UEI != "", name)
+	}
+	tmpl := NewEventTemplate("reloadDaemonConfig", BuiltInEventTemplateSource, BuiltInEventTemplates["reloadDaemonConfig"])
+	event, err := tmpl.Render(map[string]string{"daemon": "Pollerd", "configFile": "poller-configuration.xml"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []EventParam{{Name: "daemonName", Value: "Pollerd"}, {Name: "configFile", Value: "poller-configuration.xml"}}, event.Parameters)
+}
+
+func TestParseTemplateVars(t *testing.T) {
+	vars, err := ParseTemplateVars([]string{"node=42", "filter=a=b"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, map[string]string{"node": "42", "filter": "a=b"}, vars)
+
+	_, err = ParseTemplateVars([]string{"node"})
+	assert.Error(t, err, "invalid variable node, expected key=value")
+}
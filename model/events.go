@@ -1,8 +1,11 @@
 package model
 
 import (
+	"bytes"
+	"encoding/xml"
 	"fmt"
 	"net"
+	"strings"
 	"time"
 )
 
@@ -12,44 +15,66 @@ var (
 		Enum: []string{"Indeterminate", "Normal", "Warning", "Minor", "Major", "Critical"},
 	}
 
+	// ImportSuccessfulUEI the UEI of the event sent when a requisition has been successfully imported
+	ImportSuccessfulUEI = "uei.opennms.org/internal/importer/importSuccessful"
+
+	// ImportFailedUEI the UEI of the event sent when the import of a requisition has failed
+	ImportFailedUEI = "uei.opennms.org/internal/importer/importFailed"
+
 	// Destinations for logMsg
 	Destinations = EnumValue{
 		Enum: []string{"logndisplay", "displayonly", "logonly", "suppress", "donotpersist"},
 	}
 )
 
+// The numeric IDs used by OpenNMS to store the severities
+var severityIDs = map[string]int{
+	"indeterminate": 1,
+	"cleared":       2,
+	"normal":        3,
+	"warning":       4,
+	"minor":         5,
+	"major":         6,
+	"critical":      7,
+}
+
+// GetSeverityID gets the numeric ID of a severity (case insensitive), or 0 if it is invalid
+func GetSeverityID(severity string) int {
+	return severityIDs[strings.ToLower(severity)]
+}
+
 // SNMP an event SNMP object
 type SNMP struct {
-	ID        string `json:"id" yaml:"id"`
-	Version   string `json:"version,omitempty" yaml:"version,omitempty"`
-	Specific  int    `json:"specific" yaml:"specific,omitempty"`
-	Generic   int    `json:"generic" yaml:"generic,omitempty"`
-	Community string `json:"community,omitempty" yaml:"community,omitempty"`
-	Timestamp *Time  `json:"time-stamp,omitempty" yaml:"timeStamp,omitempty"`
+	ID        string `xml:"id" json:"id" yaml:"id"`
+	Version   string `xml:"version,omitempty" json:"version,omitempty" yaml:"version,omitempty"`
+	Specific  int    `xml:"specific" json:"specific" yaml:"specific,omitempty"`
+	Generic   int    `xml:"generic" json:"generic" yaml:"generic,omitempty"`
+	Community string `xml:"community,omitempty" json:"community,omitempty" yaml:"community,omitempty"`
+	Timestamp *Time  `xml:"time-stamp,omitempty" json:"time-stamp,omitempty" yaml:"timeStamp,omitempty"`
 }
 
 // EventParam an event parameter object
 type EventParam struct {
-	Name  string `json:"parmName" yaml:"name"`
-	Value string `json:"value" yaml:"value"`
+	Name  string `xml:"parmName" json:"parmName" yaml:"name"`
+	Value string `xml:"value" json:"value" yaml:"value"`
 }
 
 // MaskElement an event mask element object
 type MaskElement struct {
-	Name   string   `json:"mename" yaml:"mename"`
-	Values []string `json:"mevalue" yaml:"mevalue"`
+	Name   string   `xml:"mename" json:"mename" yaml:"mename"`
+	Values []string `xml:"mevalue" json:"mevalue" yaml:"mevalue"`
 }
 
 // Mask an event mask object
 type Mask struct {
-	Elements []MaskElement `json:"maskelement,omitempty" yaml:"maskElement,omitempty"`
+	Elements []MaskElement `xml:"maskelement,omitempty" json:"maskelement,omitempty" yaml:"maskElement,omitempty"`
 }
 
 // LogMsg the event log message
 type LogMsg struct {
-	Message     string `json:"value" yaml:"message"`
-	Notify      bool   `json:"notify" yaml:"notify"`
-	Destination string `json:"dest" yaml:"destination"`
+	Message     string `xml:",chardata" json:"value" yaml:"message"`
+	Notify      bool   `xml:"notify,attr" json:"notify" yaml:"notify"`
+	Destination string `xml:"dest,attr,omitempty" json:"dest" yaml:"destination"`
 }
 
 // Validate returns an error if the log message is invalid
@@ -66,24 +91,26 @@ func (lm *LogMsg) Validate() error {
 // Event an event object
 // Time uses a string format. Example: "Saturday, July 13, 2019 2:13:43 PM GMT"
 type Event struct {
-	SnmpMask      *Mask        `json:"mask,omitempty" yaml:"mask,omitempty"`
-	Snmp          *SNMP        `json:"snmp,omitempty" yaml:"snmp,omitempty"`
-	LogMessage    *LogMsg      `json:"logmsg,omitempty" yaml:"logmsg,omitempty"`
-	UEI           string       `json:"uei" yaml:"uei"`
-	Source        string       `json:"source" yaml:"source"`
-	Time          string       `json:"time,omitempty" yaml:"time,omitempty"`
-	Host          string       `json:"host,omitempty" yaml:"host,omitempty"`
-	MasterStation string       `json:"master-station,omitempty" yaml:"masterStation,omitempty"`
-	NodeID        int64        `json:"nodeid,omitempty" yaml:"nodeID,omitempty"`
-	Interface     string       `json:"interface,omitempty" yaml:"interface,omitempty"`
-	Service       string       `json:"service,omitempty" yaml:"service,omitempty"`
-	IfIndex       int          `json:"ifIndex,omitempty" yaml:"ifIndex,omitempty"`
-	SnmpHost      string       `json:"snmphost,omitempty" yaml:"snmpHost,omitempty"`
-	Parameters    []EventParam `json:"parms,omitempty" yaml:"parameters,omitempty"`
-	Description   string       `json:"descr,omitempty" yaml:"description,omitempty"`
-	Severity      string       `json:"severity,omitempty" yaml:"severity,omitempty"`
-	PathOutage    string       `json:"pathoutage,omitempty" yaml:"pathOutage,omitempty"`
-	OperInstruct  string       `json:"operinstruct,omitempty" yaml:"operInstruct,omitempty"`
+	XMLName       xml.Name     `xml:"event" json:"-" yaml:"-"`
+	UUID          string       `xml:"uuid,attr,omitempty" json:"-" yaml:"-"`
+	SnmpMask      *Mask        `xml:"mask,omitempty" json:"mask,omitempty" yaml:"mask,omitempty"`
+	Snmp          *SNMP        `xml:"snmp,omitempty" json:"snmp,omitempty" yaml:"snmp,omitempty"`
+	LogMessage    *LogMsg      `xml:"logmsg,omitempty" json:"logmsg,omitempty" yaml:"logmsg,omitempty"`
+	UEI           string       `xml:"uei" json:"uei" yaml:"uei"`
+	Source        string       `xml:"source" json:"source" yaml:"source"`
+	Time          string       `xml:"time,omitempty" json:"time,omitempty" yaml:"time,omitempty"`
+	Host          string       `xml:"host,omitempty" json:"host,omitempty" yaml:"host,omitempty"`
+	MasterStation string       `xml:"master-station,omitempty" json:"master-station,omitempty" yaml:"masterStation,omitempty"`
+	NodeID        int64        `xml:"nodeid,omitempty" json:"nodeid,omitempty" yaml:"nodeID,omitempty"`
+	Interface     string       `xml:"interface,omitempty" json:"interface,omitempty" yaml:"interface,omitempty"`
+	Service       string       `xml:"service,omitempty" json:"service,omitempty" yaml:"service,omitempty"`
+	IfIndex       int          `xml:"ifIndex,omitempty" json:"ifIndex,omitempty" yaml:"ifIndex,omitempty"`
+	SnmpHost      string       `xml:"snmphost,omitempty" json:"snmphost,omitempty" yaml:"snmpHost,omitempty"`
+	Parameters    []EventParam `xml:"parms>parm,omitempty" json:"parms,omitempty" yaml:"parameters,omitempty"`
+	Description   string       `xml:"descr,omitempty" json:"descr,omitempty" yaml:"description,omitempty"`
+	Severity      string       `xml:"severity,omitempty" json:"severity,omitempty" yaml:"severity,omitempty"`
+	PathOutage    string       `xml:"pathoutage,omitempty" json:"pathoutage,omitempty" yaml:"pathOutage,omitempty"`
+	OperInstruct  string       `xml:"operinstruct,omitempty" json:"operinstruct,omitempty" yaml:"operInstruct,omitempty"`
 }
 
 // AddParameter adds a new parameter to the event
@@ -123,13 +150,56 @@ func (e Event) Validate() error {
 		}
 	}
 	if e.Severity != "" {
-		if err := Severities.Set(e.Severity); err != nil {
-			return err
+		if !Severities.isValid(e.Severity) {
+			return fmt.Errorf("allowed values are %s", strings.Join(Severities.Enum, ", "))
 		}
 	}
 	return nil
 }
 
+// EventLog a list of events wrapped in the XML format used by send-event.pl and the Eventd TCP port
+type EventLog struct {
+	XMLName xml.Name `xml:"log" json:"-" yaml:"-"`
+	Events  []Event  `xml:"events>event" json:"events" yaml:"events"`
+}
+
+// EventReceipt the acknowledgement sent by the Eventd TCP port with the UUIDs of the received events
+type EventReceipt struct {
+	XMLName xml.Name `xml:"event-receipt" json:"-" yaml:"-"`
+	UUIDs   []string `xml:"uuid" json:"uuids" yaml:"uuids"`
+}
+
+// ParseEventsXML parses events in XML format, either a single event, or a log with multiple events
+func ParseEventsXML(data []byte) ([]Event, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("cannot find an event or a log element: %v", err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "log":
+			log := &EventLog{}
+			if err := decoder.DecodeElement(log, &start); err != nil {
+				return nil, err
+			}
+			return log.Events, nil
+		case "event":
+			event := Event{}
+			if err := decoder.DecodeElement(&event, &start); err != nil {
+				return nil, err
+			}
+			return []Event{event}, nil
+		default:
+			return nil, fmt.Errorf("invalid root element %s, expected event or log", start.Name.Local)
+		}
+	}
+}
+
 // OnmsEventParam parameters of an OnmsEvent entity
 type OnmsEventParam struct {
 	Name  string
@@ -167,6 +237,16 @@ type OnmsEvent struct {
 	Parameters           []OnmsEventParam `json:"parameters,omitempty" yaml:"parameters,omitempty"`
 }
 
+// GetParameter gets the value of a given parameter, or an empty string if it doesn't exist
+func (e OnmsEvent) GetParameter(name string) string {
+	for _, p := range e.Parameters {
+		if p.Name == name {
+			return p.Value
+		}
+	}
+	return ""
+}
+
 // OnmsEventList a list of events
 type OnmsEventList struct {
 	Count      int         `json:"count" yaml:"count"`
@@ -174,3 +254,62 @@ type OnmsEventList struct {
 	Offset     int         `json:"offset" yaml:"offset"`
 	Events     []OnmsEvent `json:"event" yaml:"events"`
 }
+
+// Merge appends the events of another page of the list
+func (list *OnmsEventList) Merge(page SearchList) {
+	if p, ok := page.(*OnmsEventList); ok {
+		list.Events = append(list.Events, p.Events...)
+		list.Count = len(list.Events)
+	}
+}
+
+// Len gets the amount of events on the list
+func (list *OnmsEventList) Len() int {
+	return len(list.Events)
+}
+
+// ToEvent converts a persisted event into an event that can be sent again to OpenNMS
+func (e OnmsEvent) ToEvent() Event {
+	event := Event{
+		UEI:          e.UEI,
+		Source:       e.EventSource,
+		Host:         e.EventHost,
+		NodeID:       int64(e.NodeID),
+		Interface:    e.IPAddress,
+		Service:      e.ServiceType.Name,
+		IfIndex:      e.IfIndex,
+		SnmpHost:     e.SnmpHost,
+		Description:  e.Description,
+		Severity:     e.Severity,
+		PathOutage:   e.PathOutage,
+		OperInstruct: e.OperatorInstructions,
+	}
+	if e.EventTime != nil && !e.EventTime.IsZero() {
+		event.SetTime(e.EventTime.Time)
+	}
+	// OpenNMS reports severities in upper case, but they are expected as defined on Severities
+	for _, s := range Severities.Enum {
+		if strings.EqualFold(s, event.Severity) {
+			event.Severity = s
+		}
+	}
+	if e.LogMessage != "" {
+		event.LogMessage = &LogMsg{Message: e.LogMessage, Destination: e.getDestination()}
+	}
+	for _, p := range e.Parameters {
+		event.AddParameter(p.Name, p.Value)
+	}
+	return event
+}
+
+// getDestination gets the log message destination based on the log and display flags
+func (e OnmsEvent) getDestination() string {
+	switch {
+	case e.Log == "N" && e.Display == "Y":
+		return "displayonly"
+	case e.Log == "Y" && e.Display == "N":
+		return "logonly"
+	default:
+		return "logndisplay"
+	}
+}
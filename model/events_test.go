@@ -1,7 +1,9 @@
 package model
 
 import (
+	"encoding/xml"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -19,3 +21,86 @@ func TestSetTime(t *testing.T) {
 	fmt.Println(e.Time)
 	assert.Equal(t, "Monday, January 2, 2006 10:04:05 PM GMT", e.Time)
 }
+
+func TestGetSeverityID(t *testing.T) {
+	assert.Equal(t, 6, GetSeverityID("Major"))
+	assert.Equal(t, 7, GetSeverityID("CRITICAL"))
+	assert.Equal(t, 0, GetSeverityID("Unknown"))
+}
+
+func TestParseEventsXML(t *testing.T) {
+	logXML := `<?xml version="1.0" encoding="UTF-8"?>
+<log>
+  <events>
+    <event>
+      <uei>uei.opennms.org/test/1</uei>
+      <source>perl_send_event</source>
+      <nodeid>10</nodeid>
+      <interface>10.0.0.1</interface>
+      <parms>
+        <parm><parmName>owner</parmName><value type="string" encoding="text">agalue</value></parm>
+      </parms>
+      <logmsg notify="true" dest="logonly">Something happened</logmsg>
+      <mask><maskelement><mename>id</mename><mevalue>.1.3.6.1</mevalue></maskelement></mask>
+    </event>
+    <event>
+      <uei>uei.opennms.org/test/2</uei>
+    </event>
+  </events>
+</log>`
+	events, err := ParseEventsXML([]byte(logXML))
+	assert.NilError(t, err)
+	assert.Equal(t, 2, len(events))
+	assert.Equal(t, "uei.opennms.org/test/1", events[0].UEI)
+	assert.Equal(t, int64(10), events[0].NodeID)
+	assert.DeepEqual(t, []EventParam{{Name: "owner", Value: "agalue"}}, events[0].Parameters)
+	assert.DeepEqual(t, &LogMsg{Message: "Something happened", Notify: true, Destination: "logonly"}, events[0].LogMessage)
+	assert.Equal(t, ".1.3.6.1", events[0].SnmpMask.Elements[0].Values[0])
+	assert.Equal(t, "uei.opennms.org/test/2", events[1].UEI)
+
+	events, err = ParseEventsXML([]byte(`<event><uei>uei.opennms.org/test/3</uei></event>`))
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, "uei.opennms.org/test/3", events[0].UEI)
+
+	_, err = ParseEventsXML([]byte(`<alarm><uei>uei.opennms.org/test/3</uei></alarm>`))
+	assert.Error(t, err, "invalid root element alarm, expected event or log")
+}
+
+func TestEventXMLRoundTrip(t *testing.T) {
+	event := Event{UEI: "uei.opennms.org/test", Source: "onmsctl", LogMessage: &LogMsg{Message: "Test", Destination: "logndisplay"}}
+	event.AddParameter("owner", "agalue")
+	data, err := xml.Marshal(EventLog{Events: []Event{event}})
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(string(data), `<log><events><event><logmsg notify="false" dest="logndisplay">Test</logmsg><uei>uei.opennms.org/test</uei>`))
+	assert.Assert(t, strings.Contains(string(data), `<parms><parm><parmName>owner</parmName><value>agalue</value></parm></parms>`))
+	events, err := ParseEventsXML(data)
+	assert.NilError(t, err)
+	events[0].XMLName = xml.Name{}
+	assert.DeepEqual(t, event, events[0])
+}
+
+func TestOnmsEventToEvent(t *testing.T) {
+	onmsEvent := OnmsEvent{
+		ID:          10,
+		UEI:         "uei.opennms.org/test",
+		EventSource: "onmsctl",
+		NodeID:      1,
+		IPAddress:   "10.0.0.1",
+		ServiceType: OnmsServiceType{Name: "ICMP"},
+		Severity:    "MAJOR",
+		LogMessage:  "Test",
+		Log:         "N",
+		Display:     "Y",
+		Parameters:  []OnmsEventParam{{Name: "owner", Value: "agalue", Type: "string"}},
+	}
+	event := onmsEvent.ToEvent()
+	assert.Equal(t, "Major", event.Severity)
+	assert.Equal(t, "ICMP", event.Service)
+	assert.Equal(t, "displayonly", event.LogMessage.Destination)
+	assert.DeepEqual(t, []EventParam{{Name: "owner", Value: "agalue"}}, event.Parameters)
+	assert.NilError(t, event.Validate())
+
+	onmsEvent.Severity = "INDETERMINATE"
+	assert.Equal(t, "Indeterminate", onmsEvent.ToEvent().Severity)
+}
@@ -0,0 +1,277 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FiqlTimeFormat the time format expected by FIQL expressions
+const FiqlTimeFormat = "2006-01-02T15:04:05.000-0700"
+
+// The comparison operators supported by the ReST API v2
+var fiqlOperators = []string{"==", "!=", "=lt=", "=le=", "=gt=", "=ge="}
+
+// The shorthand comparison operators accepted on a single constraint
+var fiqlShorthandOperators = map[string]string{
+	"<":  "=lt=",
+	"<=": "=le=",
+	">":  "=gt=",
+	">=": "=ge=",
+}
+
+// The characters with a special meaning on FIQL expressions
+const fiqlReserved = ",;()"
+
+// FiqlError a syntax error on a FIQL expression that points at the offending token
+type FiqlError struct {
+	Expression string
+	Position   int // Zero-based offset of the offending token
+	Token      string
+	Reason     string
+}
+
+func (e *FiqlError) Error() string {
+	token := "end of expression"
+	if e.Token != "" {
+		token = fmt.Sprintf("%q", e.Token)
+	}
+	return fmt.Sprintf("invalid filter, %s but found %s at position %d\n  %s\n  %s^", e.Reason, token, e.Position+1, e.Expression, strings.Repeat(" ", e.Position))
+}
+
+// FiqlConstraint a single FIQL comparison, like label==srv*
+type FiqlConstraint struct {
+	Selector string
+	Operator string
+	Value    string
+}
+
+// String builds the FIQL expression of the constraint, escaping the value
+func (c FiqlConstraint) String() string {
+	return c.Selector + c.Operator + EscapeFiqlValue(c.Value)
+}
+
+// NodeFiqlConstraint builds the constraint to filter by node, using its ID when numeric or its label otherwise
+func NodeFiqlConstraint(node string) FiqlConstraint {
+	if _, err := strconv.Atoi(node); err == nil {
+		return FiqlConstraint{Selector: "node.id", Operator: "==", Value: node}
+	}
+	return FiqlConstraint{Selector: "node.label", Operator: "==", Value: node}
+}
+
+// EscapeFiqlValue percent-encodes the characters of a value that have a special meaning on FIQL expressions
+// The ReST API v2 decodes the values after parsing the expression; the wildcard (*) is preserved.
+func EscapeFiqlValue(value string) string {
+	builder := strings.Builder{}
+	for _, r := range value {
+		if r == '%' || strings.ContainsRune(fiqlReserved, r) {
+			builder.WriteString(fmt.Sprintf("%%%02X", r))
+		} else {
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
+
+// AndFiql combines FIQL expressions with AND, ignoring the empty ones
+func AndFiql(expressions ...string) string {
+	filters := make([]string, 0, len(expressions))
+	for _, e := range expressions {
+		if e != "" {
+			filters = append(filters, e)
+		}
+	}
+	if len(filters) == 1 {
+		return filters[0]
+	}
+	for i, f := range filters {
+		filters[i] = "(" + f + ")"
+	}
+	return strings.Join(filters, ";")
+}
+
+// ParseFiqlConstraint parses a single comparison, like label==srv* or ifSpeed<1000000000
+// Besides the FIQL operators, <, <=, > and >= are accepted. The value is taken verbatim and escaped when building the expression.
+func ParseFiqlConstraint(text string) (FiqlConstraint, error) {
+	p := &fiqlParser{input: text}
+	c := FiqlConstraint{}
+	var err error
+	if c.Selector, err = p.parseSelector(); err != nil {
+		return c, err
+	}
+	if c.Operator, err = p.parseOperator(true); err != nil {
+		return c, err
+	}
+	c.Value = text[p.pos:]
+	if c.Value == "" {
+		return c, p.error("expected a value")
+	}
+	return c, nil
+}
+
+// ValidateFiql verifies the syntax of a FIQL expression
+// Constraints are combined with ; (AND) and , (OR), and can be grouped with parentheses.
+func ValidateFiql(expression string) error {
+	p := &fiqlParser{input: expression}
+	if err := p.parseOr(); err != nil {
+		return err
+	}
+	if p.pos < len(p.input) {
+		if p.input[p.pos] == ')' {
+			return p.error("expected ; or , (unbalanced parenthesis)")
+		}
+		return p.error("expected ; or ,")
+	}
+	return nil
+}
+
+type fiqlParser struct {
+	input string
+	pos   int
+}
+
+func (p *fiqlParser) peek() byte {
+	if p.pos < len(p.input) {
+		return p.input[p.pos]
+	}
+	return 0
+}
+
+// token gets the token at the current position, used to describe errors
+func (p *fiqlParser) token() string {
+	if p.pos >= len(p.input) {
+		return ""
+	}
+	end := p.pos
+	switch {
+	case strings.IndexByte(fiqlReserved, p.input[end]) >= 0:
+		end++
+	case strings.IndexByte("=!<>~", p.input[end]) >= 0:
+		for end < len(p.input) && strings.IndexByte("=!<>~", p.input[end]) >= 0 {
+			end++
+		}
+	default:
+		for end < len(p.input) && strings.IndexByte(fiqlReserved+"=!<>~", p.input[end]) < 0 {
+			end++
+		}
+	}
+	return p.input[p.pos:end]
+}
+
+func (p *fiqlParser) error(reason string) error {
+	return &FiqlError{Expression: p.input, Position: p.pos, Token: p.token(), Reason: reason}
+}
+
+func (p *fiqlParser) parseOr() error {
+	if err := p.parseAnd(); err != nil {
+		return err
+	}
+	for p.peek() == ',' {
+		p.pos++
+		if err := p.parseAnd(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *fiqlParser) parseAnd() error {
+	if err := p.parsePrimary(); err != nil {
+		return err
+	}
+	for p.peek() == ';' {
+		p.pos++
+		if err := p.parsePrimary(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *fiqlParser) parsePrimary() error {
+	if p.peek() != '(' {
+		return p.parseConstraint()
+	}
+	p.pos++
+	if err := p.parseOr(); err != nil {
+		return err
+	}
+	if p.peek() != ')' {
+		return p.error("expected )")
+	}
+	p.pos++
+	return nil
+}
+
+func (p *fiqlParser) parseConstraint() error {
+	if _, err := p.parseSelector(); err != nil {
+		return err
+	}
+	if _, err := p.parseOperator(false); err != nil {
+		return err
+	}
+	start := p.pos
+	for p.pos < len(p.input) && strings.IndexByte(fiqlReserved, p.input[p.pos]) < 0 {
+		p.pos++
+	}
+	if p.pos == start {
+		return p.error("expected a value")
+	}
+	return nil
+}
+
+// parseSelector parses a property name, like node.label
+func (p *fiqlParser) parseSelector() (string, error) {
+	start := p.pos
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		if c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (p.pos > start && ((c >= '0' && c <= '9') || c == '.')) {
+			p.pos++
+			continue
+		}
+		break
+	}
+	if p.pos == start {
+		return "", p.error("expected a property name")
+	}
+	selector := p.input[start:p.pos]
+	if strings.HasSuffix(selector, ".") || strings.Contains(selector, "..") {
+		p.pos = start
+		return "", p.error("expected a valid property name")
+	}
+	return selector, nil
+}
+
+// parseOperator parses a comparison operator, optionally accepting the shorthand ones
+func (p *fiqlParser) parseOperator(shorthand bool) (string, error) {
+	rest := p.input[p.pos:]
+	for _, op := range fiqlOperators {
+		if strings.HasPrefix(rest, op) {
+			p.pos += len(op)
+			return op, nil
+		}
+	}
+	if shorthand {
+		for _, op := range []string{"<=", ">=", "<", ">"} {
+			if strings.HasPrefix(rest, op) {
+				p.pos += len(op)
+				return fiqlShorthandOperators[op], nil
+			}
+		}
+	}
+	if strings.HasPrefix(rest, "=") {
+		if end := strings.Index(rest[1:], "="); end > 0 && isAlpha(rest[1:end+1]) {
+			return "", &FiqlError{Expression: p.input, Position: p.pos, Token: rest[:end+2], Reason: "expected one of " + strings.Join(fiqlOperators, " ")}
+		}
+	}
+	return "", p.error("expected a comparison operator (" + strings.Join(fiqlOperators, " ") + ")")
+}
+
+func isAlpha(text string) bool {
+	for _, c := range text {
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+			return false
+		}
+	}
+	return text != ""
+}
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestParseFiqlConstraint(t *testing.T) {
+	c, err := ParseFiqlConstraint("label==srv*")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, FiqlConstraint{Selector: "label", Operator: "==", Value: "srv*"}, c)
+
+	c, err = ParseFiqlConstraint("snmpInterface.ifSpeed<1000000000")
+	assert.NilError(t, err)
+	assert.Equal(t, "snmpInterface.ifSpeed=lt=1000000000", c.String())
+
+	c, err = ParseFiqlConstraint("node.label!=web,db;(test)")
+	assert.NilError(t, err)
+	assert.Equal(t, "node.label!=web%2Cdb%3B%28test%29", c.String())
+
+	_, err = ParseFiqlConstraint("label=~srv")
+	var fiqlErr *FiqlError
+	assert.Assert(t, errors.As(err, &fiqlErr))
+	assert.Equal(t, 5, fiqlErr.Position)
+	assert.Equal(t, "=~", fiqlErr.Token)
+	assert.ErrorContains(t, err, `found "=~" at position 6`)
+
+	_, err = ParseFiqlConstraint("label=like=srv")
+	assert.ErrorContains(t, err, `found "=like="`)
+
+	_, err = ParseFiqlConstraint("label==")
+	assert.ErrorContains(t, err, "expected a value but found end of expression")
+
+	_, err = ParseFiqlConstraint("==srv")
+	assert.ErrorContains(t, err, "expected a property name")
+}
+
+func TestValidateFiql(t *testing.T) {
+	assert.NilError(t, ValidateFiql("node.label==srv*"))
+	assert.NilError(t, ValidateFiql("(alarm.severity=ge=MAJOR;node.id==1),alarm.uei==*nodeDown"))
+	assert.NilError(t, ValidateFiql(`ifRegainedService==\u0000`))
+
+	var fiqlErr *FiqlError
+
+	err := ValidateFiql("(node.label==srv*;node.id==1")
+	assert.Assert(t, errors.As(err, &fiqlErr))
+	assert.Equal(t, 28, fiqlErr.Position)
+	assert.ErrorContains(t, err, "expected ) but found end of expression")
+
+	err = ValidateFiql("node.label==srv*)")
+	assert.Assert(t, errors.As(err, &fiqlErr))
+	assert.Equal(t, 16, fiqlErr.Position)
+	assert.ErrorContains(t, err, "unbalanced parenthesis")
+
+	err = ValidateFiql("node.label==srv*;;node.id==1")
+	assert.Assert(t, errors.As(err, &fiqlErr))
+	assert.Equal(t, 17, fiqlErr.Position)
+	assert.Equal(t, ";", fiqlErr.Token)
+
+	err = ValidateFiql("node.label=srv*")
+	assert.ErrorContains(t, err, `expected a comparison operator (== != =lt= =le= =gt= =ge=) but found "=" at position 11`)
+}
+
+func TestAndFiql(t *testing.T) {
+	assert.Equal(t, "", AndFiql())
+	assert.Equal(t, "label==a", AndFiql("", "label==a"))
+	assert.Equal(t, "(label==a);(id==1,id==2)", AndFiql("label==a", "", "id==1,id==2"))
+}
+
+func TestNodeFiqlConstraint(t *testing.T) {
+	assert.Equal(t, "node.id==10", NodeFiqlConstraint("10").String())
+	assert.Equal(t, "node.label==srv01", NodeFiqlConstraint("srv01").String())
+	assert.Equal(t, "node.label==srv%2C01", NodeFiqlConstraint("srv,01").String())
+}
+
+func TestSearchQuery(t *testing.T) {
+	q := SearchQuery{Filter: "eventTime=ge=2020-01-01T00:00:00.000+0000", OrderBy: "id", Order: "desc", Limit: 10, Offset: 20}
+	assert.Equal(t, "/api/v2/events?limit=10&offset=20&orderBy=id&order=desc&_s=eventTime%3Dge%3D2020-01-01T00%3A00%3A00.000%2B0000", q.URL("/api/v2/events"))
+}
@@ -0,0 +1,134 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ManifestKinds list of valid kinds for declarative manifests (in the order they have to be applied)
+var ManifestKinds = EnumValue{
+	Enum: []string{"MonitoringLocation", "Requisition", "ForeignSource", "SnmpConfig", "ScheduledOutage"},
+}
+
+// rawManifest the on-disk representation of a manifest, before parsing its specification
+type rawManifest struct {
+	Kind      string      `yaml:"kind"`
+	IPAddress string      `yaml:"ipAddress,omitempty"`
+	Spec      interface{} `yaml:"spec"`
+}
+
+// Manifest a declarative definition of an OpenNMS object
+// Only the field associated with the kind is populated; IPAddress is used by SnmpConfig only.
+type Manifest struct {
+	Kind               string
+	Source             string
+	IPAddress          string
+	Requisition        *Requisition
+	ForeignSource      *ForeignSourceDef
+	SnmpConfig         *SnmpInfo
+	ScheduledOutage    *ScheduledOutage
+	MonitoringLocation *MonitoringLocation
+}
+
+// Name gets the name that identifies the object on the server
+func (m Manifest) Name() string {
+	switch m.Kind {
+	case "Requisition":
+		return m.Requisition.Name
+	case "ForeignSource":
+		return m.ForeignSource.Name
+	case "SnmpConfig":
+		return m.IPAddress
+	case "ScheduledOutage":
+		return m.ScheduledOutage.Name
+	case "MonitoringLocation":
+		return m.MonitoringLocation.LocationName
+	}
+	return ""
+}
+
+// Validate returns an error if the manifest is invalid
+func (m *Manifest) Validate() error {
+	switch m.Kind {
+	case "Requisition":
+		return m.Requisition.Validate()
+	case "ForeignSource":
+		return m.ForeignSource.Validate()
+	case "SnmpConfig":
+		if m.IPAddress == "" {
+			return fmt.Errorf("ipAddress cannot be empty for SnmpConfig")
+		}
+		return m.SnmpConfig.Validate()
+	case "ScheduledOutage":
+		return m.ScheduledOutage.IsValid()
+	case "MonitoringLocation":
+		return m.MonitoringLocation.Validate()
+	}
+	return fmt.Errorf("invalid kind %s. Allowed values: %s", m.Kind, ManifestKinds.EnumAsString())
+}
+
+// ParseManifests parses all the YAML documents from the provided content, returning one manifest per document
+func ParseManifests(data []byte, source string) ([]Manifest, error) {
+	manifests := make([]Manifest, 0)
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for index := 1; ; index++ {
+		raw := rawManifest{}
+		err := decoder.Decode(&raw)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse document %d on %s: %s", index, source, err)
+		}
+		if raw.Kind == "" && raw.Spec == nil {
+			continue // Empty document
+		}
+		m, err := parseManifest(raw, source)
+		if err != nil {
+			return nil, fmt.Errorf("problem with document %d on %s: %s", index, source, err)
+		}
+		manifests = append(manifests, *m)
+	}
+	return manifests, nil
+}
+
+func parseManifest(raw rawManifest, source string) (*Manifest, error) {
+	if raw.Kind == "" {
+		return nil, fmt.Errorf("kind cannot be empty")
+	}
+	if raw.Spec == nil {
+		return nil, fmt.Errorf("spec cannot be empty")
+	}
+	spec, err := yaml.Marshal(raw.Spec)
+	if err != nil {
+		return nil, err
+	}
+	m := &Manifest{Kind: raw.Kind, Source: source, IPAddress: raw.IPAddress}
+	var target interface{}
+	switch raw.Kind {
+	case "Requisition":
+		m.Requisition = &Requisition{}
+		target = m.Requisition
+	case "ForeignSource":
+		m.ForeignSource = &ForeignSourceDef{}
+		target = m.ForeignSource
+	case "SnmpConfig":
+		m.SnmpConfig = &SnmpInfo{}
+		target = m.SnmpConfig
+	case "ScheduledOutage":
+		m.ScheduledOutage = &ScheduledOutage{}
+		target = m.ScheduledOutage
+	case "MonitoringLocation":
+		m.MonitoringLocation = &MonitoringLocation{}
+		target = m.MonitoringLocation
+	default:
+		return nil, fmt.Errorf("invalid kind %s. Allowed values: %s", raw.Kind, ManifestKinds.EnumAsString())
+	}
+	if err := yaml.UnmarshalStrict(spec, target); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
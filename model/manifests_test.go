@@ -0,0 +1,72 @@
+package model
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+const manifestsYAML = `
+kind: Requisition
+spec:
+  name: Routers
+  nodes:
+  - foreignID: r1
+    interfaces:
+    - ipAddress: 10.0.0.1
+---
+kind: SnmpConfig
+ipAddress: 10.0.0.1
+spec:
+  version: v2c
+  community: public
+---
+kind: ScheduledOutage
+spec:
+  name: Weekend
+  type: weekly
+  times:
+  - day: saturday
+    begins: "00:00:00"
+    ends: "23:59:59"
+---
+kind: MonitoringLocation
+spec:
+  name: Apex
+`
+
+func TestParseManifests(t *testing.T) {
+	manifests, err := ParseManifests([]byte(manifestsYAML), "test.yaml")
+	assert.NilError(t, err)
+	assert.Equal(t, 4, len(manifests))
+	for i := range manifests {
+		m := &manifests[i]
+		assert.NilError(t, m.Validate())
+		assert.Equal(t, "test.yaml", m.Source)
+	}
+	assert.Equal(t, "Routers", manifests[0].Name())
+	assert.Equal(t, "r1", manifests[0].Requisition.Nodes[0].NodeLabel)
+	assert.Equal(t, "10.0.0.1", manifests[1].Name())
+	assert.Equal(t, "public", manifests[1].SnmpConfig.Community)
+	assert.Equal(t, "Weekend", manifests[2].Name())
+	assert.Equal(t, "Apex", manifests[3].Name())
+	assert.Equal(t, "Apex", manifests[3].MonitoringLocation.MonitoringArea)
+}
+
+func TestParseInvalidManifests(t *testing.T) {
+	_, err := ParseManifests([]byte("kind: Node\nspec:\n  label: n1\n"), "test.yaml")
+	assert.ErrorContains(t, err, "invalid kind Node")
+
+	_, err = ParseManifests([]byte("spec:\n  name: Test\n"), "test.yaml")
+	assert.ErrorContains(t, err, "kind cannot be empty")
+
+	_, err = ParseManifests([]byte("kind: Requisition\n"), "test.yaml")
+	assert.ErrorContains(t, err, "spec cannot be empty")
+
+	_, err = ParseManifests([]byte("kind: Requisition\nspec:\n  name: Test\n  unknown: true\n"), "test.yaml")
+	assert.ErrorContains(t, err, "document 1 on test.yaml")
+
+	manifests, err := ParseManifests([]byte("kind: SnmpConfig\nspec:\n  community: public\n"), "test.yaml")
+	assert.NilError(t, err)
+	assert.ErrorContains(t, manifests[0].Validate(), "ipAddress cannot be empty")
+}
@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// OnmsMinion an OpenNMS Minion
+type OnmsMinion struct {
+	ID          string `json:"id" yaml:"id"`
+	Label       string `json:"label,omitempty" yaml:"label,omitempty"`
+	Location    string `json:"location" yaml:"location"`
+	Type        string `json:"type,omitempty" yaml:"type,omitempty"`
+	Status      string `json:"status,omitempty" yaml:"status,omitempty"`
+	LastUpdated *Time  `json:"lastUpdated,omitempty" yaml:"lastUpdated,omitempty"`
+}
+
+// IsStale returns true when the Minion hasn't checked in during the given duration, or never checked in
+func (m OnmsMinion) IsStale(maxAge time.Duration, now time.Time) bool {
+	if m.LastUpdated == nil || m.LastUpdated.IsZero() {
+		return true
+	}
+	return now.Sub(m.LastUpdated.Time) > maxAge
+}
+
+// OnmsMinionList a list of Minions
+type OnmsMinionList struct {
+	Count      int          `json:"count" yaml:"count"`
+	TotalCount int          `json:"totalCount" yaml:"totalCount"`
+	Offset     int          `json:"offset" yaml:"offset"`
+	Minions    []OnmsMinion `json:"minion" yaml:"minions"`
+}
@@ -0,0 +1,18 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func TestMinionIsStale(t *testing.T) {
+	now := time.Now()
+	minion := OnmsMinion{ID: "minion-01"}
+	assert.Equal(t, true, minion.IsStale(10*time.Minute, now))
+
+	minion.LastUpdated = &Time{Time: now.Add(-5 * time.Minute)}
+	assert.Equal(t, false, minion.IsStale(10*time.Minute, now))
+	assert.Equal(t, true, minion.IsStale(time.Minute, now))
+}
@@ -1,5 +1,7 @@
 package model
 
+import "fmt"
+
 // MonitoringLocation an OpenNMS Location
 type MonitoringLocation struct {
 	Tags                   []string `json:"tags,omitempty" yaml:"tags,omitempty"`
@@ -20,3 +22,91 @@ type MonitoringLocationList struct {
 	Offset     int                  `json:"offset" yaml:"offset"`
 	Locations  []MonitoringLocation `json:"location" yaml:"locations"`
 }
+
+// Merge appends the monitoring locations of another page of the list
+func (list *MonitoringLocationList) Merge(page SearchList) {
+	if p, ok := page.(*MonitoringLocationList); ok {
+		list.Locations = append(list.Locations, p.Locations...)
+		list.Count = len(list.Locations)
+	}
+}
+
+// Len gets the amount of monitoring locations on the list
+func (list *MonitoringLocationList) Len() int {
+	return len(list.Locations)
+}
+
+// Validate verifies the monitoring location, using the location name as the default monitoring area
+func (loc *MonitoringLocation) Validate() error {
+	if loc.LocationName == "" {
+		return fmt.Errorf("location name cannot be empty")
+	}
+	if loc.MonitoringArea == "" {
+		loc.MonitoringArea = loc.LocationName
+	}
+	if loc.Latitude < -90 || loc.Latitude > 90 {
+		return fmt.Errorf("invalid latitude %v, expected a value between -90 and 90", loc.Latitude)
+	}
+	if loc.Longitude < -180 || loc.Longitude > 180 {
+		return fmt.Errorf("invalid longitude %v, expected a value between -180 and 180", loc.Longitude)
+	}
+	return nil
+}
+
+// HasCoordinates returns true when the location has latitude or longitude
+func (loc MonitoringLocation) HasCoordinates() bool {
+	return loc.Latitude != 0 || loc.Longitude != 0
+}
+
+// GeoJSONGeometry a GeoJSON geometry; only points are used for monitoring locations
+type GeoJSONGeometry struct {
+	Type        string    `json:"type" yaml:"type"`
+	Coordinates []float64 `json:"coordinates" yaml:"coordinates"`
+}
+
+// GeoJSONFeature a GeoJSON feature
+type GeoJSONFeature struct {
+	Type       string                 `json:"type" yaml:"type"`
+	ID         string                 `json:"id,omitempty" yaml:"id,omitempty"`
+	Geometry   *GeoJSONGeometry       `json:"geometry" yaml:"geometry"`
+	Properties map[string]interface{} `json:"properties" yaml:"properties"`
+}
+
+// GeoJSONFeatureCollection a GeoJSON feature collection
+type GeoJSONFeatureCollection struct {
+	Type     string           `json:"type" yaml:"type"`
+	Features []GeoJSONFeature `json:"features" yaml:"features"`
+}
+
+// ToGeoJSON converts the monitoring location into a GeoJSON feature
+// The geometry is null when the location doesn't have coordinates; GeoJSON uses longitude before latitude.
+func (loc MonitoringLocation) ToGeoJSON() GeoJSONFeature {
+	feature := GeoJSONFeature{
+		Type: "Feature",
+		ID:   loc.LocationName,
+		Properties: map[string]interface{}{
+			"name":           loc.LocationName,
+			"monitoringArea": loc.MonitoringArea,
+			"priority":       loc.Priority,
+		},
+	}
+	if loc.HasCoordinates() {
+		feature.Geometry = &GeoJSONGeometry{Type: "Point", Coordinates: []float64{loc.Longitude, loc.Latitude}}
+	}
+	if loc.GeoLocation != "" {
+		feature.Properties["geoLocation"] = loc.GeoLocation
+	}
+	if len(loc.Tags) > 0 {
+		feature.Properties["tags"] = loc.Tags
+	}
+	return feature
+}
+
+// ToGeoJSON converts the list of monitoring locations into a GeoJSON feature collection
+func (list MonitoringLocationList) ToGeoJSON() GeoJSONFeatureCollection {
+	collection := GeoJSONFeatureCollection{Type: "FeatureCollection", Features: make([]GeoJSONFeature, 0, len(list.Locations))}
+	for _, loc := range list.Locations {
+		collection.Features = append(collection.Features, loc.ToGeoJSON())
+	}
+	return collection
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestValidateMonitoringLocation(t *testing.T) {
+	loc := MonitoringLocation{}
+	assert.Error(t, loc.Validate(), "location name cannot be empty")
+
+	loc = MonitoringLocation{LocationName: "Apex", Latitude: 95}
+	assert.Error(t, loc.Validate(), "invalid latitude 95, expected a value between -90 and 90")
+
+	loc = MonitoringLocation{LocationName: "Apex", Longitude: -181}
+	assert.Error(t, loc.Validate(), "invalid longitude -181, expected a value between -180 and 180")
+
+	loc = MonitoringLocation{LocationName: "Apex", Latitude: 35.7, Longitude: -78.8}
+	assert.NilError(t, loc.Validate())
+	assert.Equal(t, "Apex", loc.MonitoringArea)
+}
+
+func TestMonitoringLocationsToGeoJSON(t *testing.T) {
+	list := MonitoringLocationList{
+		Locations: []MonitoringLocation{
+			{LocationName: "Apex", MonitoringArea: "NC", Priority: 100, Latitude: 35.7, Longitude: -78.8, Tags: []string{"office"}},
+			{LocationName: "Default", MonitoringArea: "Default"},
+		},
+	}
+	bytes, err := json.Marshal(list.ToGeoJSON())
+	assert.NilError(t, err)
+	expected := `{"type":"FeatureCollection","features":[` +
+		`{"type":"Feature","id":"Apex","geometry":{"type":"Point","coordinates":[-78.8,35.7]},"properties":{"monitoringArea":"NC","name":"Apex","priority":100,"tags":["office"]}},` +
+		`{"type":"Feature","id":"Default","geometry":null,"properties":{"monitoringArea":"Default","name":"Default","priority":0}}]}`
+	assert.Equal(t, expected, string(bytes))
+}
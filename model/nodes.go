@@ -159,6 +159,10 @@ type OnmsMonitoredService struct {
 	Source      string           `xml:"source,attr,omitempty" json:"source,omitempty" yaml:"source,omitempty"`
 	IsDown      bool             `xml:"down,attr,omitempty" json:"down,omitempty" yaml:"isDown,omitempty"`
 	Meta        []MetaData       `xml:"metaData,attr,omitempty" json:"metaData,omitempty" yaml:"metaData,omitempty"`
+	// Only populated when the services are obtained through /api/v2/ifservices
+	IPAddress string `xml:"-" json:"ipAddress,omitempty" yaml:"ipAddress,omitempty"`
+	NodeID    int    `xml:"-" json:"nodeId,omitempty" yaml:"nodeId,omitempty"`
+	NodeLabel string `xml:"-" json:"nodeLabel,omitempty" yaml:"nodeLabel,omitempty"`
 }
 
 // Validate verify structure and apply defaults when needed
@@ -187,6 +191,19 @@ type OnmsMonitoredServiceList struct {
 	Services   []OnmsMonitoredService `xml:"service" json:"service" yaml:"services"`
 }
 
+// Merge appends the monitored services of another page of the list
+func (list *OnmsMonitoredServiceList) Merge(page SearchList) {
+	if p, ok := page.(*OnmsMonitoredServiceList); ok {
+		list.Services = append(list.Services, p.Services...)
+		list.Count = len(list.Services)
+	}
+}
+
+// Len gets the amount of monitored services on the list
+func (list *OnmsMonitoredServiceList) Len() int {
+	return len(list.Services)
+}
+
 // OnmsIPInterface an entity that represents an OpenNMS IP Interface
 type OnmsIPInterface struct {
 	XMLName               xml.Name               `xml:"ipInterface" json:"-" yaml:"-"`
@@ -263,6 +280,19 @@ type OnmsIPInterfaceList struct {
 	Interfaces []OnmsIPInterface `xml:"ipInterface" json:"ipInterface" yaml:"interfaces"`
 }
 
+// Merge appends the IP interfaces of another page of the list
+func (list *OnmsIPInterfaceList) Merge(page SearchList) {
+	if p, ok := page.(*OnmsIPInterfaceList); ok {
+		list.Interfaces = append(list.Interfaces, p.Interfaces...)
+		list.Count = len(list.Interfaces)
+	}
+}
+
+// Len gets the amount of IP interfaces on the list
+func (list *OnmsIPInterfaceList) Len() int {
+	return len(list.Interfaces)
+}
+
 // OnmsSnmpInterface an entity that represents an OpenNMS SNMP Interface
 type OnmsSnmpInterface struct {
 	XMLName                 xml.Name `xml:"snmpInterface" json:"-" yaml:"-"`
@@ -343,6 +373,19 @@ type OnmsSnmpInterfaceList struct {
 	Interfaces []OnmsSnmpInterface `xml:"snmpInterface" json:"snmpInterface" yaml:"interfaces"`
 }
 
+// Merge appends the SNMP interfaces of another page of the list
+func (list *OnmsSnmpInterfaceList) Merge(page SearchList) {
+	if p, ok := page.(*OnmsSnmpInterfaceList); ok {
+		list.Interfaces = append(list.Interfaces, p.Interfaces...)
+		list.Count = len(list.Interfaces)
+	}
+}
+
+// Len gets the amount of SNMP interfaces on the list
+func (list *OnmsSnmpInterfaceList) Len() int {
+	return len(list.Interfaces)
+}
+
 // OnmsNode an entity that represents an OpenNMS node
 type OnmsNode struct {
 	XMLName         xml.Name            `xml:"node" json:"-" yaml:"-"`
@@ -452,3 +495,16 @@ type OnmsNodeList struct {
 	Offset     int        `xml:"offset,attr" json:"offset" yaml:"offset"`
 	Nodes      []OnmsNode `xml:"node" json:"node" yaml:"nodes"`
 }
+
+// Merge appends the nodes of another page of the list
+func (list *OnmsNodeList) Merge(page SearchList) {
+	if p, ok := page.(*OnmsNodeList); ok {
+		list.Nodes = append(list.Nodes, p.Nodes...)
+		list.Count = len(list.Nodes)
+	}
+}
+
+// Len gets the amount of nodes on the list
+func (list *OnmsNodeList) Len() int {
+	return len(list.Nodes)
+}
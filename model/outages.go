@@ -1,5 +1,10 @@
 package model
 
+import (
+	"sort"
+	"time"
+)
+
 // OnmsOutage OpenNMS outage entity
 type OnmsOutage struct {
 	ID                   int                   `json:"id" yaml:"id"`
@@ -26,3 +31,129 @@ type OnmsOutageList struct {
 	Offset     int          `json:"offset" yaml:"offset"`
 	Outages    []OnmsOutage `json:"outage" yaml:"outages"`
 }
+
+// Merge appends the outages of another page of the list
+func (list *OnmsOutageList) Merge(page SearchList) {
+	if p, ok := page.(*OnmsOutageList); ok {
+		list.Outages = append(list.Outages, p.Outages...)
+		list.Count = len(list.Outages)
+	}
+}
+
+// Len gets the amount of outages on the list
+func (list *OnmsOutageList) Len() int {
+	return len(list.Outages)
+}
+
+// GetServiceName gets the name of the service affected by the outage
+func (o OnmsOutage) GetServiceName() string {
+	if o.MonitoredService != nil && o.MonitoredService.ServiceType != nil {
+		return o.MonitoredService.ServiceType.Name
+	}
+	return ""
+}
+
+// IsCurrent returns true when the service hasn't been regained yet
+func (o OnmsOutage) IsCurrent() bool {
+	return o.ServiceRegainedTime == nil || o.ServiceRegainedTime.IsZero()
+}
+
+// GetDuration gets the duration of the outage, using the given time as the end for current outages
+func (o OnmsOutage) GetDuration(now time.Time) time.Duration {
+	if o.ServiceLostTime == nil {
+		return 0
+	}
+	if o.IsCurrent() {
+		return now.Sub(o.ServiceLostTime.Time)
+	}
+	return o.ServiceRegainedTime.Sub(o.ServiceLostTime.Time)
+}
+
+// OutageStats the downtime and availability of a service on a node during a period of time
+type OutageStats struct {
+	NodeID          int     `json:"nodeId" yaml:"nodeId"`
+	NodeLabel       string  `json:"nodeLabel" yaml:"nodeLabel"`
+	ServiceName     string  `json:"serviceName" yaml:"serviceName"`
+	Outages         int     `json:"outages" yaml:"outages"`
+	DowntimeSeconds float64 `json:"downtimeSeconds" yaml:"downtimeSeconds"`
+	Availability    float64 `json:"availability" yaml:"availability"`
+}
+
+// ComputeOutageStats aggregates the downtime per node and service between two times
+// Outages are clipped to the period, and overlapping outages of the same service on different interfaces are counted once.
+func ComputeOutageStats(outages []OnmsOutage, start time.Time, end time.Time) []OutageStats {
+	type interval struct{ start, end time.Time }
+	type group struct {
+		stats     *OutageStats
+		intervals []interval
+	}
+	type key struct {
+		nodeID  int
+		service string
+	}
+	groups := make(map[key]*group)
+	keys := make([]key, 0)
+	for _, o := range outages {
+		if o.ServiceLostTime == nil {
+			continue
+		}
+		from, to := o.ServiceLostTime.Time, end
+		if !o.IsCurrent() {
+			to = o.ServiceRegainedTime.Time
+		}
+		if from.Before(start) {
+			from = start
+		}
+		if to.After(end) {
+			to = end
+		}
+		if !to.After(from) {
+			continue
+		}
+		// Labels are not unique, so outages are grouped by node ID
+		k := key{o.NodeID, o.GetServiceName()}
+		g, ok := groups[k]
+		if !ok {
+			g = &group{stats: &OutageStats{NodeID: o.NodeID, NodeLabel: o.NodeLabel, ServiceName: o.GetServiceName()}}
+			groups[k] = g
+			keys = append(keys, k)
+		}
+		g.stats.Outages++
+		g.intervals = append(g.intervals, interval{from, to})
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := groups[keys[i]].stats, groups[keys[j]].stats
+		if a.NodeLabel != b.NodeLabel {
+			return a.NodeLabel < b.NodeLabel
+		}
+		if a.NodeID != b.NodeID {
+			return a.NodeID < b.NodeID
+		}
+		return a.ServiceName < b.ServiceName
+	})
+	period := end.Sub(start)
+	results := make([]OutageStats, 0, len(keys))
+	for _, k := range keys {
+		g := groups[k]
+		sort.Slice(g.intervals, func(i, j int) bool {
+			return g.intervals[i].start.Before(g.intervals[j].start)
+		})
+		var downtime time.Duration
+		current := g.intervals[0]
+		for _, i := range g.intervals[1:] {
+			if i.start.After(current.end) {
+				downtime += current.end.Sub(current.start)
+				current = i
+			} else if i.end.After(current.end) {
+				current.end = i.end
+			}
+		}
+		downtime += current.end.Sub(current.start)
+		g.stats.DowntimeSeconds = downtime.Seconds()
+		if period > 0 {
+			g.stats.Availability = 100 * (1 - float64(downtime)/float64(period))
+		}
+		results = append(results, *g.stats)
+	}
+	return results
+}
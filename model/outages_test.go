@@ -0,0 +1,48 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func TestComputeOutageStats(t *testing.T) {
+	end := time.Date(2020, 5, 31, 0, 0, 0, 0, time.UTC)
+	start := end.Add(-100 * time.Hour)
+	at := func(hours int) *Time {
+		return &Time{Time: start.Add(time.Duration(hours) * time.Hour)}
+	}
+	icmp := &OnmsMonitoredService{ServiceType: &OnmsServiceType{Name: "ICMP"}}
+	http := &OnmsMonitoredService{ServiceType: &OnmsServiceType{Name: "HTTP"}}
+	outages := []OnmsOutage{
+		// Started before the period, clipped to 2 hours
+		{ID: 1, NodeID: 1, NodeLabel: "srv01", MonitoredService: icmp, ServiceLostTime: at(-5), ServiceRegainedTime: at(2)},
+		// Overlapping outages on different interfaces, counted once (hours 10 to 16)
+		{ID: 2, NodeID: 1, NodeLabel: "srv01", IPAddress: "10.0.0.1", MonitoredService: icmp, ServiceLostTime: at(10), ServiceRegainedTime: at(14)},
+		{ID: 3, NodeID: 1, NodeLabel: "srv01", IPAddress: "10.0.0.2", MonitoredService: icmp, ServiceLostTime: at(12), ServiceRegainedTime: at(16)},
+		// Current outage
+		{ID: 4, NodeID: 2, NodeLabel: "srv02", MonitoredService: http, ServiceLostTime: at(90)},
+		// Resolved before the period
+		{ID: 5, NodeID: 3, NodeLabel: "srv03", MonitoredService: http, ServiceLostTime: at(-10), ServiceRegainedTime: at(-8)},
+		// Different node with the same label
+		{ID: 6, NodeID: 4, NodeLabel: "srv01", MonitoredService: icmp, ServiceLostTime: at(50), ServiceRegainedTime: at(51)},
+	}
+	stats := ComputeOutageStats(outages, start, end)
+	assert.DeepEqual(t, []OutageStats{
+		{NodeID: 1, NodeLabel: "srv01", ServiceName: "ICMP", Outages: 3, DowntimeSeconds: 8 * 3600, Availability: 92},
+		{NodeID: 4, NodeLabel: "srv01", ServiceName: "ICMP", Outages: 1, DowntimeSeconds: 3600, Availability: 99},
+		{NodeID: 2, NodeLabel: "srv02", ServiceName: "HTTP", Outages: 1, DowntimeSeconds: 10 * 3600, Availability: 90},
+	}, stats)
+}
+
+func TestOutageDuration(t *testing.T) {
+	now := time.Now()
+	outage := OnmsOutage{ServiceLostTime: &Time{Time: now.Add(-time.Hour)}}
+	assert.Equal(t, true, outage.IsCurrent())
+	assert.Equal(t, time.Hour, outage.GetDuration(now))
+
+	outage.ServiceRegainedTime = &Time{Time: now.Add(-30 * time.Minute)}
+	assert.Equal(t, false, outage.IsCurrent())
+	assert.Equal(t, 30*time.Minute, outage.GetDuration(now))
+}
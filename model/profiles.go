@@ -3,13 +3,21 @@ package model
 import "fmt"
 
 // Profile provides information about accessing a given OpenNMS server
+// The retry and circuit breaker settings are optional; when they are not set, the defaults from the ReST client are used.
 type Profile struct {
-	Name     string `yaml:"name"`
-	URL      string `yaml:"url"`
-	Username string `yaml:"username"`
-	Password string `yaml:"password"`
-	Insecure bool   `yaml:"insecure"`
-	Timeout  int    `yaml:"timeout"`
+	Name             string `json:"name" yaml:"name"`
+	URL              string `json:"url" yaml:"url"`
+	Username         string `json:"username" yaml:"username"`
+	Password         string `json:"password" yaml:"password"`
+	Insecure         bool   `json:"insecure" yaml:"insecure"`
+	Timeout          int    `json:"timeout" yaml:"timeout"`
+	MaxRetries       int    `json:"maxRetries,omitempty" yaml:"maxRetries,omitempty"`
+	RetryBackoff     int    `json:"retryBackoff,omitempty" yaml:"retryBackoff,omitempty"`
+	RetryMaxBackoff  int    `json:"retryMaxBackoff,omitempty" yaml:"retryMaxBackoff,omitempty"`
+	RetryStatusCodes []int  `json:"retryStatusCodes,omitempty" yaml:"retryStatusCodes,omitempty"`
+	RetryPost        bool   `json:"retryPost,omitempty" yaml:"retryPost,omitempty"`
+	BreakerThreshold int    `json:"breakerThreshold,omitempty" yaml:"breakerThreshold,omitempty"`
+	BreakerCooldown  int    `json:"breakerCooldown,omitempty" yaml:"breakerCooldown,omitempty"`
 }
 
 // Validate verify required fields
@@ -26,13 +34,21 @@ func (p *Profile) Validate() error {
 	if p.Password == "" {
 		return fmt.Errorf("OpenNMS user password cannot be empty")
 	}
+	if p.MaxRetries < 0 || p.RetryBackoff < 0 || p.RetryMaxBackoff < 0 || p.BreakerThreshold < 0 || p.BreakerCooldown < 0 {
+		return fmt.Errorf("retry and circuit breaker settings cannot be negative")
+	}
+	for _, code := range p.RetryStatusCodes {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("invalid HTTP status code %d", code)
+		}
+	}
 	return nil
 }
 
 // ProfilesConfig provides information about the configured OpenNMS servers
 type ProfilesConfig struct {
-	Default  string    `yaml:"defaultProfile"`
-	Profiles []Profile `yaml:"profiles,omitempty"`
+	Default  string    `json:"defaultProfile" yaml:"defaultProfile"`
+	Profiles []Profile `json:"profiles,omitempty" yaml:"profiles,omitempty"`
 }
 
 // IsEmpty checks if configuration is empty
@@ -0,0 +1,356 @@
+package model
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// RequisitionCSVColumns the fixed columns of a requisition in CSV format
+// Each row represents an IP interface; additional columns named asset:<field> and meta:<key> (or meta:<context>:<key>) hold the node's assets and metadata.
+var RequisitionCSVColumns = []string{"foreignId", "nodeLabel", "location", "ipAddress", "snmpPrimary", "services", "categories"}
+
+// Column aliases used by the classic PRIS spreadsheet layout (after removing underscores and converting to lower case)
+var csvColumnAliases = map[string]string{
+	"foreignid":      "foreignId",
+	"nodelabel":      "nodeLabel",
+	"location":       "location",
+	"minionlocation": "location",
+	"ipaddress":      "ipAddress",
+	"ipmanagement":   "ipAddress",
+	"snmpprimary":    "snmpPrimary",
+	"mgmttype":       "snmpPrimary",
+	"interfacetype":  "snmpPrimary",
+	"services":       "services",
+	"categories":     "categories",
+}
+
+// csvColumn the parsed header of a CSV column
+type csvColumn struct {
+	kind string // One of RequisitionCSVColumns, "asset", "meta", "cat" or "svc"
+	name string // The asset field, or metadata context:key
+}
+
+// csvProblem a problem found while parsing a given row of a CSV file
+type csvProblem struct {
+	row     int
+	message string
+}
+
+// WriteRequisitionCSV writes the content of a requisition in CSV format, one row per IP interface
+func WriteRequisitionCSV(requisition *Requisition, writer io.Writer) error {
+	assetSet := make(map[string]string)
+	metaSet := make(map[string]string)
+	for _, n := range requisition.Nodes {
+		for _, a := range n.Assets {
+			assetSet[a.Name] = ""
+		}
+		for k := range metaDataAsMap(n.MetaData) {
+			metaSet[k] = ""
+		}
+	}
+	assets := sortedKeys(assetSet, nil)
+	metadata := sortedKeys(metaSet, nil)
+	header := append([]string{}, RequisitionCSVColumns...)
+	for _, a := range assets {
+		header = append(header, "asset:"+a)
+	}
+	for _, m := range metadata {
+		header = append(header, "meta:"+strings.TrimPrefix(m, "requisition:"))
+	}
+	w := csv.NewWriter(writer)
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	for _, n := range requisition.Nodes {
+		categories := make([]string, 0, len(n.Categories))
+		for _, c := range n.Categories {
+			categories = append(categories, c.Name)
+		}
+		nodeAssets := make(map[string]string)
+		for _, a := range n.Assets {
+			nodeAssets[a.Name] = a.Value
+		}
+		nodeMeta := metaDataAsMap(n.MetaData)
+		interfaces := n.Interfaces
+		if len(interfaces) == 0 {
+			interfaces = []RequisitionInterface{{}}
+		}
+		for _, intf := range interfaces {
+			services := make([]string, 0, len(intf.Services))
+			for _, s := range intf.Services {
+				services = append(services, s.Name)
+			}
+			row := []string{n.ForeignID, n.NodeLabel, n.Location, intf.IPAddress, intf.SnmpPrimary, strings.Join(services, ","), strings.Join(categories, ",")}
+			for _, a := range assets {
+				row = append(row, nodeAssets[a])
+			}
+			for _, m := range metadata {
+				row = append(row, nodeMeta[m])
+			}
+			if err := w.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// ParseRequisitionCSV builds a requisition from its CSV representation, merging all the rows of a given node
+// The header must contain at least foreignId (or nodeLabel). Errors are reported with the row number, where the header is row 1.
+func ParseRequisitionCSV(name string, data []byte) (*Requisition, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("CSV content cannot be empty")
+	}
+	columns, err := parseCSVHeader(records[0])
+	if err != nil {
+		return nil, fmt.Errorf("row 1: %s", err)
+	}
+	requisition := &Requisition{Name: name}
+	nodeIndex := make(map[string]int)  // foreign ID => position on the requisition
+	nodeRow := make(map[string]int)    // foreign ID => first row where the node was defined
+	intfRow := make(map[string]int)    // foreign ID and IP address => row where the interface was defined
+	primaryRow := make(map[string]int) // foreign ID => row where the primary interface was defined
+	problems := make([]csvProblem, 0)
+	for i, record := range records[1:] {
+		row := i + 2
+		if isEmptyCSVRecord(record) {
+			continue
+		}
+		if len(record) > len(columns) {
+			problems = append(problems, csvProblem{row, fmt.Sprintf("expected %d columns, found %d", len(columns), len(record))})
+			continue
+		}
+		node, intf := parseCSVRecord(columns, record)
+		if node.ForeignID == "" {
+			node.ForeignID = node.NodeLabel
+		}
+		if node.ForeignID == "" {
+			problems = append(problems, csvProblem{row, "foreignId or nodeLabel required"})
+			continue
+		}
+		if intf != nil {
+			node.Interfaces = []RequisitionInterface{*intf}
+		}
+		// Validate a copy, to report the problems of the content of this row without initializing defaults on the node
+		// Validate changes the interfaces and the metadata in place, so their slices are copied too.
+		fragment := *node
+		fragment.Interfaces = append([]RequisitionInterface(nil), node.Interfaces...)
+		fragment.MetaData = append([]RequisitionMetaData(nil), node.MetaData...)
+		if err := fragment.Validate(); err != nil {
+			problems = append(problems, csvProblem{row, fmt.Sprintf("problem on node %s: %s", node.ForeignID, err)})
+			continue
+		}
+		if intf != nil {
+			intf = &fragment.Interfaces[0]
+			if r, ok := intfRow[node.ForeignID+"/"+intf.IPAddress]; ok {
+				problems = append(problems, csvProblem{row, fmt.Sprintf("problem on node %s: IP Address %s is already defined on row %d", node.ForeignID, intf.IPAddress, r)})
+				continue
+			}
+			if r, ok := primaryRow[node.ForeignID]; ok && intf.SnmpPrimary == "P" {
+				problems = append(problems, csvProblem{row, fmt.Sprintf("problem on node %s: the primary interface is already defined on row %d", node.ForeignID, r)})
+				continue
+			}
+		}
+		if idx, ok := nodeIndex[node.ForeignID]; ok {
+			if err := mergeCSVNode(&requisition.Nodes[idx], node); err != nil {
+				problems = append(problems, csvProblem{row, fmt.Sprintf("%s (node first defined on row %d)", err, nodeRow[node.ForeignID])})
+				continue
+			}
+		} else {
+			nodeIndex[node.ForeignID] = len(requisition.Nodes)
+			nodeRow[node.ForeignID] = row
+			requisition.AddNode(node)
+		}
+		if intf != nil {
+			intfRow[node.ForeignID+"/"+intf.IPAddress] = row
+			if intf.SnmpPrimary == "P" {
+				primaryRow[node.ForeignID] = row
+			}
+		}
+	}
+	for i := range requisition.Nodes {
+		n := &requisition.Nodes[i]
+		if err := n.Validate(); err != nil {
+			problems = append(problems, csvProblem{nodeRow[n.ForeignID], fmt.Sprintf("problem on node %s: %s", n.ForeignID, err)})
+		}
+	}
+	if len(problems) > 0 {
+		sort.SliceStable(problems, func(i, j int) bool {
+			return problems[i].row < problems[j].row
+		})
+		messages := make([]string, len(problems))
+		for i, p := range problems {
+			messages[i] = fmt.Sprintf("row %d: %s", p.row, p.message)
+		}
+		return nil, fmt.Errorf("invalid CSV content:\n%s", strings.Join(messages, "\n"))
+	}
+	return requisition, requisition.Validate()
+}
+
+func parseCSVHeader(header []string) ([]csvColumn, error) {
+	columns := make([]csvColumn, len(header))
+	hasID := false
+	for i, h := range header {
+		h = strings.TrimSpace(h)
+		lower := strings.ToLower(h)
+		switch {
+		case strings.HasPrefix(lower, "asset:") || strings.HasPrefix(lower, "asset_"):
+			columns[i] = csvColumn{kind: "asset", name: h[6:]}
+		case strings.HasPrefix(lower, "meta:") || strings.HasPrefix(lower, "meta_"):
+			columns[i] = csvColumn{kind: "meta", name: h[5:]}
+		case csvColumnAliases[strings.ReplaceAll(lower, "_", "")] != "":
+			columns[i] = csvColumn{kind: csvColumnAliases[strings.ReplaceAll(lower, "_", "")]}
+		case lower == "cat" || strings.HasPrefix(lower, "cat_"):
+			columns[i] = csvColumn{kind: "cat"}
+		case lower == "svc" || strings.HasPrefix(lower, "svc_"):
+			columns[i] = csvColumn{kind: "svc"}
+		default:
+			return nil, fmt.Errorf("unknown column %s", h)
+		}
+		if columns[i].kind == "foreignId" || columns[i].kind == "nodeLabel" {
+			hasID = true
+		}
+		if (columns[i].kind == "asset" || columns[i].kind == "meta") && columns[i].name == "" {
+			return nil, fmt.Errorf("column %s requires a name", h)
+		}
+	}
+	if !hasID {
+		return nil, fmt.Errorf("either foreignId or nodeLabel column is required")
+	}
+	return columns, nil
+}
+
+// parseCSVRecord builds a node from a CSV record; the interface is nil when the record doesn't have an IP address
+func parseCSVRecord(columns []csvColumn, record []string) (*RequisitionNode, *RequisitionInterface) {
+	node := &RequisitionNode{}
+	intf := &RequisitionInterface{}
+	for i, value := range record {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		col := columns[i]
+		switch col.kind {
+		case "foreignId":
+			node.ForeignID = value
+		case "nodeLabel":
+			node.NodeLabel = value
+		case "location":
+			node.Location = value
+		case "ipAddress":
+			intf.IPAddress = value
+		case "snmpPrimary":
+			intf.SnmpPrimary = strings.ToUpper(value)
+		case "services", "svc":
+			for _, svc := range splitCSVList(value) {
+				intf.AddService(&RequisitionMonitoredService{Name: svc})
+			}
+		case "categories", "cat":
+			for _, cat := range splitCSVList(value) {
+				node.Categories = append(node.Categories, RequisitionCategory{Name: cat})
+			}
+		case "asset":
+			node.Assets = append(node.Assets, RequisitionAsset{Name: col.name, Value: value})
+		case "meta":
+			meta := RequisitionMetaData{Context: "requisition", Key: col.name, Value: value}
+			if parts := strings.SplitN(col.name, ":", 2); len(parts) == 2 {
+				meta.Context = parts[0]
+				meta.Key = parts[1]
+			}
+			node.MetaData = append(node.MetaData, meta)
+		}
+	}
+	if intf.IPAddress == "" {
+		return node, nil
+	}
+	return node, intf
+}
+
+// mergeCSVNode merges the content of a node defined on a subsequent row into the target node
+func mergeCSVNode(target *RequisitionNode, source *RequisitionNode) error {
+	if source.NodeLabel != "" && target.NodeLabel != "" && source.NodeLabel != target.NodeLabel {
+		return fmt.Errorf("nodeLabel %s doesn't match %s", source.NodeLabel, target.NodeLabel)
+	}
+	if source.Location != "" && target.Location != "" && source.Location != target.Location {
+		return fmt.Errorf("location %s doesn't match %s", source.Location, target.Location)
+	}
+	target.NodeLabel = defaultIfEmpty(target.NodeLabel, source.NodeLabel)
+	target.Location = defaultIfEmpty(target.Location, source.Location)
+	target.Interfaces = append(target.Interfaces, source.Interfaces...)
+	for _, c := range source.Categories {
+		if !hasCategory(target.Categories, c.Name) {
+			target.Categories = append(target.Categories, c)
+		}
+	}
+	for _, a := range source.Assets {
+		for _, existing := range target.Assets {
+			if existing.Name == a.Name && existing.Value != a.Value {
+				return fmt.Errorf("asset %s value %s doesn't match %s", a.Name, a.Value, existing.Value)
+			}
+		}
+		if !hasAsset(target.Assets, a.Name) {
+			target.Assets = append(target.Assets, a)
+		}
+	}
+	current := metaDataAsMap(target.MetaData)
+	for _, m := range source.MetaData {
+		key := m.Context + ":" + m.Key
+		if value, ok := current[key]; ok {
+			if value != m.Value {
+				return fmt.Errorf("metadata %s value %s doesn't match %s", key, m.Value, value)
+			}
+			continue
+		}
+		target.MetaData = append(target.MetaData, m)
+	}
+	return nil
+}
+
+func hasCategory(categories []RequisitionCategory, name string) bool {
+	for _, c := range categories {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAsset(assets []RequisitionAsset, name string) bool {
+	for _, a := range assets {
+		if a.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func splitCSVList(value string) []string {
+	list := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func isEmptyCSVRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
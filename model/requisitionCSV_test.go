@@ -0,0 +1,101 @@
+package model
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+const testRequisitionCSV = `foreignId,nodeLabel,location,ipAddress,snmpPrimary,services,categories,asset:city,meta:owner
+r1,router1,Apex,10.0.0.1,P,"ICMP,SNMP","Routers,Production",Durham,agalue
+r1,,,10.0.0.2,N,ICMP,,,
+s1,server1,,10.0.1.1,,HTTP,Servers,Raleigh,
+`
+
+func TestParseRequisitionCSV(t *testing.T) {
+	req, err := ParseRequisitionCSV("Test", []byte(testRequisitionCSV))
+	assert.NilError(t, err)
+	assert.Equal(t, "Test", req.Name)
+	assert.Equal(t, 2, len(req.Nodes))
+
+	r1 := req.Nodes[0]
+	assert.Equal(t, "router1", r1.NodeLabel)
+	assert.Equal(t, "Apex", r1.Location)
+	assert.Equal(t, 2, len(r1.Interfaces))
+	assert.Equal(t, 2, len(r1.Interfaces[0].Services))
+	assert.Equal(t, "N", r1.Interfaces[1].SnmpPrimary)
+	assert.Equal(t, 2, len(r1.Categories))
+	assert.Equal(t, "Durham", r1.Assets[0].Value)
+	assert.Equal(t, "owner", r1.MetaData[0].Key)
+	assert.Equal(t, "requisition", r1.MetaData[0].Context)
+
+	s1 := req.Nodes[1]
+	assert.Equal(t, "HTTP", s1.Interfaces[0].Services[0].Name)
+	assert.Equal(t, 0, len(s1.MetaData))
+}
+
+func TestParseRequisitionCSVWithPrisLayout(t *testing.T) {
+	data := `Node_Label,IP_Management,MgmtType_,svc_Forced,cat_Environment,Asset_City
+srv01,10.0.0.1,P,ICMP,Production,Durham
+srv01,10.0.0.2,S,SNMP,,
+`
+	req, err := ParseRequisitionCSV("Test", []byte(data))
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(req.Nodes))
+	node := req.Nodes[0]
+	assert.Equal(t, "srv01", node.ForeignID)
+	assert.Equal(t, 2, len(node.Interfaces))
+	assert.Equal(t, "P", node.Interfaces[0].SnmpPrimary)
+	assert.Equal(t, "SNMP", node.Interfaces[1].Services[0].Name)
+	assert.Equal(t, "Production", node.Categories[0].Name)
+	assert.Equal(t, "City", node.Assets[0].Name)
+}
+
+func TestParseRequisitionCSVErrors(t *testing.T) {
+	_, err := ParseRequisitionCSV("Test", []byte("foreignId,color\nr1,red\n"))
+	assert.Error(t, err, "row 1: unknown column color")
+
+	_, err = ParseRequisitionCSV("Test", []byte("ipAddress\n10.0.0.1\n"))
+	assert.Error(t, err, "row 1: either foreignId or nodeLabel column is required")
+
+	data := `foreignId,nodeLabel,ipAddress,snmpPrimary
+r1,router1,10.0.0.1,P
+,,10.0.0.3,
+r1,router2,10.0.0.2,N
+r1,,10.0.0.4,P
+r2,router2,10.0.0.500,
+r1,,10.0.0.1,N
+`
+	_, err = ParseRequisitionCSV("Test", []byte(data))
+	assert.ErrorContains(t, err, "row 3: foreignId or nodeLabel required")
+	assert.ErrorContains(t, err, "row 4: nodeLabel router2 doesn't match router1 (node first defined on row 2)")
+	assert.ErrorContains(t, err, "row 5: problem on node r1: the primary interface is already defined on row 2")
+	assert.ErrorContains(t, err, "row 6: problem on node r2: ")
+	assert.ErrorContains(t, err, "10.0.0.500")
+	assert.ErrorContains(t, err, "row 7: problem on node r1: IP Address 10.0.0.1 is already defined on row 2")
+	assert.Assert(t, !strings.Contains(err.Error(), "row 2:"))
+
+	_, err = ParseRequisitionCSV("Test", []byte("foreignId,category\nr1,Routers\n"))
+	assert.Error(t, err, "row 1: unknown column category")
+}
+
+func TestWriteRequisitionCSV(t *testing.T) {
+	req, err := ParseRequisitionCSV("Test", []byte(testRequisitionCSV))
+	assert.NilError(t, err)
+	buffer := &bytes.Buffer{}
+	err = WriteRequisitionCSV(req, buffer)
+	assert.NilError(t, err)
+	expected := `foreignId,nodeLabel,location,ipAddress,snmpPrimary,services,categories,asset:city,meta:owner
+r1,router1,Apex,10.0.0.1,P,"ICMP,SNMP","Routers,Production",Durham,agalue
+r1,router1,Apex,10.0.0.2,N,ICMP,"Routers,Production",Durham,agalue
+s1,server1,,10.0.1.1,N,HTTP,Servers,Raleigh,
+`
+	assert.Equal(t, expected, buffer.String())
+
+	// Round trip
+	parsed, err := ParseRequisitionCSV("Test", buffer.Bytes())
+	assert.NilError(t, err)
+	assert.Assert(t, !DiffRequisitions(req, parsed).HasChanges())
+}
@@ -0,0 +1,289 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RequisitionChange a difference between the deployed and the desired state of a requisition element
+type RequisitionChange struct {
+	Action    string `json:"action" yaml:"action"`   // added, removed or modified
+	Element   string `json:"element" yaml:"element"` // node, interface, service, category, asset or metadata
+	ForeignID string `json:"foreignId" yaml:"foreignId"`
+	IPAddress string `json:"ipAddress,omitempty" yaml:"ipAddress,omitempty"`
+	Service   string `json:"service,omitempty" yaml:"service,omitempty"`
+	Name      string `json:"name,omitempty" yaml:"name,omitempty"` // category, asset, metadata key, or modified attribute
+	OldValue  string `json:"oldValue,omitempty" yaml:"oldValue,omitempty"`
+	NewValue  string `json:"newValue,omitempty" yaml:"newValue,omitempty"`
+}
+
+// String gets a human-readable representation of the change
+func (c RequisitionChange) String() string {
+	symbol := "~"
+	switch c.Action {
+	case "added":
+		symbol = "+"
+	case "removed":
+		symbol = "-"
+	}
+	path := "node " + c.ForeignID
+	if c.IPAddress != "" {
+		path += " interface " + c.IPAddress
+	}
+	if c.Service != "" {
+		path += " service " + c.Service
+	}
+	switch c.Element {
+	case "node", "interface", "service":
+		if c.Name != "" {
+			path += " " + c.Name
+		}
+	default:
+		path += " " + c.Element + " " + c.Name
+	}
+	switch c.Action {
+	case "modified":
+		return fmt.Sprintf("%s %s: %q => %q", symbol, path, c.OldValue, c.NewValue)
+	case "added":
+		if c.NewValue != "" {
+			return fmt.Sprintf("%s %s = %q", symbol, path, c.NewValue)
+		}
+	case "removed":
+		if c.OldValue != "" {
+			return fmt.Sprintf("%s %s = %q", symbol, path, c.OldValue)
+		}
+	}
+	return symbol + " " + path
+}
+
+// RequisitionDiff the differences between the deployed and the desired state of a requisition
+type RequisitionDiff struct {
+	Name     string              `json:"name" yaml:"name"`
+	Added    int                 `json:"added" yaml:"added"`
+	Removed  int                 `json:"removed" yaml:"removed"`
+	Modified int                 `json:"modified" yaml:"modified"`
+	Changes  []RequisitionChange `json:"changes" yaml:"changes"`
+}
+
+// HasChanges returns true if the requisitions are different
+func (d RequisitionDiff) HasChanges() bool {
+	return len(d.Changes) > 0
+}
+
+func (d *RequisitionDiff) add(change RequisitionChange) {
+	switch change.Action {
+	case "added":
+		d.Added++
+	case "removed":
+		d.Removed++
+	case "modified":
+		d.Modified++
+	}
+	d.Changes = append(d.Changes, change)
+}
+
+// DiffRequisitions compares the deployed requisition (current) against the desired one, keyed by foreign ID and IP address.
+// When a node or an interface is added or removed, its content is not reported individually.
+func DiffRequisitions(current *Requisition, desired *Requisition) RequisitionDiff {
+	diff := RequisitionDiff{Name: desired.Name, Changes: make([]RequisitionChange, 0)}
+	if current == nil {
+		current = &Requisition{Name: desired.Name}
+	}
+	currentNodes := make(map[string]RequisitionNode)
+	for _, n := range current.Nodes {
+		currentNodes[n.ForeignID] = n
+	}
+	desiredNodes := make(map[string]RequisitionNode)
+	for _, n := range desired.Nodes {
+		desiredNodes[n.ForeignID] = n
+	}
+	for _, id := range sortedKeys(currentNodes, desiredNodes) {
+		cur, inCurrent := currentNodes[id]
+		des, inDesired := desiredNodes[id]
+		switch {
+		case !inDesired:
+			diff.add(RequisitionChange{Action: "removed", Element: "node", ForeignID: id})
+		case !inCurrent:
+			diff.add(RequisitionChange{Action: "added", Element: "node", ForeignID: id})
+		default:
+			diffNodes(&diff, cur, des)
+		}
+	}
+	return diff
+}
+
+func diffNodes(diff *RequisitionDiff, current RequisitionNode, desired RequisitionNode) {
+	change := RequisitionChange{Element: "node", ForeignID: desired.ForeignID}
+	diffAttributes(diff, change, map[string][2]string{
+		"nodeLabel":           {defaultIfEmpty(current.NodeLabel, current.ForeignID), defaultIfEmpty(desired.NodeLabel, desired.ForeignID)},
+		"location":            {current.Location, desired.Location},
+		"city":                {current.City, desired.City},
+		"building":            {current.Building, desired.Building},
+		"parentForeignSource": {current.ParentForeignSource, desired.ParentForeignSource},
+		"parentForeignID":     {current.ParentForeignID, desired.ParentForeignID},
+		"parentNodeLabel":     {current.ParentNodeLabel, desired.ParentNodeLabel},
+	})
+	// Interfaces
+	currentIntf := make(map[string]RequisitionInterface)
+	for _, intf := range current.Interfaces {
+		currentIntf[intf.IPAddress] = intf
+	}
+	desiredIntf := make(map[string]RequisitionInterface)
+	for _, intf := range desired.Interfaces {
+		desiredIntf[intf.IPAddress] = intf
+	}
+	for _, ip := range sortedKeys(currentIntf, desiredIntf) {
+		cur, inCurrent := currentIntf[ip]
+		des, inDesired := desiredIntf[ip]
+		switch {
+		case !inDesired:
+			diff.add(RequisitionChange{Action: "removed", Element: "interface", ForeignID: desired.ForeignID, IPAddress: ip})
+		case !inCurrent:
+			diff.add(RequisitionChange{Action: "added", Element: "interface", ForeignID: desired.ForeignID, IPAddress: ip})
+		default:
+			diffInterfaces(diff, desired.ForeignID, cur, des)
+		}
+	}
+	// Categories
+	currentCat := make(map[string]string)
+	for _, c := range current.Categories {
+		currentCat[c.Name] = ""
+	}
+	desiredCat := make(map[string]string)
+	for _, c := range desired.Categories {
+		desiredCat[c.Name] = ""
+	}
+	diffMaps(diff, RequisitionChange{Element: "category", ForeignID: desired.ForeignID}, currentCat, desiredCat)
+	// Assets
+	currentAssets := make(map[string]string)
+	for _, a := range current.Assets {
+		currentAssets[a.Name] = a.Value
+	}
+	desiredAssets := make(map[string]string)
+	for _, a := range desired.Assets {
+		desiredAssets[a.Name] = a.Value
+	}
+	diffMaps(diff, RequisitionChange{Element: "asset", ForeignID: desired.ForeignID}, currentAssets, desiredAssets)
+	// Metadata
+	diffMetaData(diff, RequisitionChange{ForeignID: desired.ForeignID}, current.MetaData, desired.MetaData)
+}
+
+func diffInterfaces(diff *RequisitionDiff, foreignID string, current RequisitionInterface, desired RequisitionInterface) {
+	change := RequisitionChange{Element: "interface", ForeignID: foreignID, IPAddress: desired.IPAddress}
+	diffAttributes(diff, change, map[string][2]string{
+		"description": {current.Description, desired.Description},
+		"snmpPrimary": {defaultIfEmpty(current.SnmpPrimary, "N"), defaultIfEmpty(desired.SnmpPrimary, "N")},
+		"status":      {interfaceStatus(current.Status), interfaceStatus(desired.Status)},
+	})
+	currentSvc := make(map[string]RequisitionMonitoredService)
+	for _, svc := range current.Services {
+		currentSvc[svc.Name] = svc
+	}
+	desiredSvc := make(map[string]RequisitionMonitoredService)
+	for _, svc := range desired.Services {
+		desiredSvc[svc.Name] = svc
+	}
+	for _, name := range sortedKeys(currentSvc, desiredSvc) {
+		cur, inCurrent := currentSvc[name]
+		des, inDesired := desiredSvc[name]
+		svcChange := RequisitionChange{Element: "service", ForeignID: foreignID, IPAddress: desired.IPAddress, Service: name}
+		switch {
+		case !inDesired:
+			svcChange.Action = "removed"
+			diff.add(svcChange)
+		case !inCurrent:
+			svcChange.Action = "added"
+			diff.add(svcChange)
+		default:
+			diffMetaData(diff, svcChange, cur.MetaData, des.MetaData)
+		}
+	}
+	diffMetaData(diff, RequisitionChange{ForeignID: foreignID, IPAddress: desired.IPAddress}, current.MetaData, desired.MetaData)
+}
+
+func diffMetaData(diff *RequisitionDiff, template RequisitionChange, current []RequisitionMetaData, desired []RequisitionMetaData) {
+	template.Element = "metadata"
+	diffMaps(diff, template, metaDataAsMap(current), metaDataAsMap(desired))
+}
+
+func diffAttributes(diff *RequisitionDiff, template RequisitionChange, attributes map[string][2]string) {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		values := attributes[name]
+		if values[0] != values[1] {
+			change := template
+			change.Action = "modified"
+			change.Name = name
+			change.OldValue = values[0]
+			change.NewValue = values[1]
+			diff.add(change)
+		}
+	}
+}
+
+func diffMaps(diff *RequisitionDiff, template RequisitionChange, current map[string]string, desired map[string]string) {
+	for _, key := range sortedKeys(current, desired) {
+		cur, inCurrent := current[key]
+		des, inDesired := desired[key]
+		change := template
+		change.Name = key
+		switch {
+		case !inDesired:
+			change.Action = "removed"
+			change.OldValue = cur
+		case !inCurrent:
+			change.Action = "added"
+			change.NewValue = des
+		case cur != des:
+			change.Action = "modified"
+			change.OldValue = cur
+			change.NewValue = des
+		default:
+			continue
+		}
+		diff.add(change)
+	}
+}
+
+func metaDataAsMap(metadata []RequisitionMetaData) map[string]string {
+	m := make(map[string]string)
+	for _, meta := range metadata {
+		m[defaultIfEmpty(meta.Context, "requisition")+":"+meta.Key] = meta.Value
+	}
+	return m
+}
+
+func interfaceStatus(status int) string {
+	if status == 0 {
+		return "1"
+	}
+	return strconv.Itoa(status)
+}
+
+func defaultIfEmpty(value string, defaultValue string) string {
+	if strings.TrimSpace(value) == "" {
+		return defaultValue
+	}
+	return value
+}
+
+// sortedKeys gets the union of the keys of two maps indexed by string, sorted alphabetically
+func sortedKeys[V any](a map[string]V, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
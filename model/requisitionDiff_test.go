@@ -0,0 +1,81 @@
+package model
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestDiffRequisitions(t *testing.T) {
+	current := &Requisition{
+		Name: "Test",
+		Nodes: []RequisitionNode{
+			{
+				ForeignID: "r1",
+				NodeLabel: "router1",
+				Interfaces: []RequisitionInterface{
+					{
+						IPAddress:   "10.0.0.1",
+						SnmpPrimary: "P",
+						Services:    []RequisitionMonitoredService{{Name: "ICMP"}, {Name: "SNMP"}},
+					},
+					{IPAddress: "10.0.0.2"},
+				},
+				Categories: []RequisitionCategory{{Name: "Routers"}},
+				Assets:     []RequisitionAsset{{Name: "city", Value: "Durham"}},
+				MetaData:   []RequisitionMetaData{{Key: "owner", Value: "agalue"}},
+			},
+			{ForeignID: "r2"},
+		},
+	}
+	desired := &Requisition{
+		Name: "Test",
+		Nodes: []RequisitionNode{
+			{
+				ForeignID: "r1",
+				NodeLabel: "router-1",
+				Interfaces: []RequisitionInterface{
+					{
+						IPAddress:   "10.0.0.1",
+						SnmpPrimary: "P",
+						Services:    []RequisitionMonitoredService{{Name: "ICMP"}, {Name: "HTTP"}},
+					},
+					{IPAddress: "10.0.0.3"},
+				},
+				Categories: []RequisitionCategory{{Name: "Production"}},
+				Assets:     []RequisitionAsset{{Name: "city", Value: "Raleigh"}},
+				MetaData:   []RequisitionMetaData{{Context: "requisition", Key: "owner", Value: "agalue"}},
+			},
+			{ForeignID: "r3"},
+		},
+	}
+
+	diff := DiffRequisitions(current, desired)
+	assert.Assert(t, diff.HasChanges())
+	changes := make([]string, 0)
+	for _, c := range diff.Changes {
+		changes = append(changes, c.String())
+	}
+	assert.DeepEqual(t, []string{
+		`~ node r1 nodeLabel: "router1" => "router-1"`,
+		`+ node r1 interface 10.0.0.1 service HTTP`,
+		`- node r1 interface 10.0.0.1 service SNMP`,
+		`- node r1 interface 10.0.0.2`,
+		`+ node r1 interface 10.0.0.3`,
+		`+ node r1 category Production`,
+		`- node r1 category Routers`,
+		`~ node r1 asset city: "Durham" => "Raleigh"`,
+		`- node r2`,
+		`+ node r3`,
+	}, changes)
+	assert.Equal(t, 4, diff.Added)
+	assert.Equal(t, 4, diff.Removed)
+	assert.Equal(t, 2, diff.Modified)
+
+	diff = DiffRequisitions(desired, desired)
+	assert.Assert(t, !diff.HasChanges())
+
+	diff = DiffRequisitions(nil, desired)
+	assert.Equal(t, 2, diff.Added)
+	assert.Equal(t, 0, diff.Removed)
+}
@@ -1,24 +1,80 @@
 package model
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 )
 
+// ScheduledSpecificTimeFormat the format of the begin and end dates of specific scheduled outages
+const ScheduledSpecificTimeFormat = "02-Jan-2006 15:04:05"
+
 // ScheduledTypes list of valid scheduled outage types
 var ScheduledTypes = EnumValue{
 	Enum: []string{"specific", "daily", "weekly", "monthly"},
 }
 
+// ScheduledOutageTargets list of valid targets for a scheduled outage (i.e. where the outage applies)
+// All targets except notification require a package name.
+var ScheduledOutageTargets = EnumValue{
+	Enum: []string{"poller", "collector", "threshold", "notification"},
+}
+
+// ScheduledOutageDefaultPackages the package used when a target doesn't specify one (the default packages of OpenNMS)
+var ScheduledOutageDefaultPackages = map[string]string{
+	"poller":    "example1",
+	"collector": "example1",
+	"threshold": "mib2",
+}
+
 // WeekDays list of valid week days
 var WeekDays = EnumValue{
 	Enum: []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"},
 }
 
 // ScheduledNode represents a node by its Node ID under a scheduled outage
+// The ID was a string in previous versions, so both numbers and numeric strings are accepted when parsing JSON or YAML.
 type ScheduledNode struct {
-	ID string `json:"id" yaml:"id"`
+	ID int `json:"id" yaml:"id"`
+}
+
+// UnmarshalJSON parses a scheduled node, accepting the ID as a number or as a string
+func (n *ScheduledNode) UnmarshalJSON(data []byte) error {
+	node := struct {
+		ID json.RawMessage `json:"id"`
+	}{}
+	if err := json.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	id, err := parseScheduledNodeID(strings.Trim(string(node.ID), `"`))
+	n.ID = id
+	return err
+}
+
+// UnmarshalYAML parses a scheduled node, accepting the ID as a number or as a string
+func (n *ScheduledNode) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	node := struct {
+		ID string `yaml:"id"`
+	}{}
+	if err := unmarshal(&node); err != nil {
+		return err
+	}
+	id, err := parseScheduledNodeID(node.ID)
+	n.ID = id
+	return err
+}
+
+func parseScheduledNodeID(text string) (int, error) {
+	if text == "" || text == "null" {
+		return 0, nil
+	}
+	id, err := strconv.Atoi(strings.TrimSpace(text))
+	if err != nil {
+		return 0, fmt.Errorf("invalid node ID %s on scheduled outage", text)
+	}
+	return id, nil
 }
 
 // ScheduledInterface represents an IP interface by its Address under a scheduled outage
@@ -37,10 +93,10 @@ type ScheduledTime struct {
 func (sc *ScheduledTime) IsValid(scheduleType string) error {
 	switch scheduleType {
 	case "specific": // { "begins": "01-Jun-2017 00:00:00", "ends": "30-Jun-2017 23:59:59" }
-		if _, err := time.Parse("02-Jan-2006 15:04:05", sc.Begins); err != nil {
+		if _, err := time.Parse(ScheduledSpecificTimeFormat, sc.Begins); err != nil {
 			return fmt.Errorf("invalid specific begin date: %s", err.Error())
 		}
-		if _, err := time.Parse("02-Jan-2006 15:04:05", sc.Ends); err != nil {
+		if _, err := time.Parse(ScheduledSpecificTimeFormat, sc.Ends); err != nil {
 			return fmt.Errorf("invalid specific end date: %s", err.Error())
 		}
 		if sc.Day != "" {
@@ -75,6 +131,38 @@ func (sc *ScheduledTime) IsValid(scheduleType string) error {
 	return fmt.Errorf("invalid type %s", scheduleType)
 }
 
+// NewSpecificScheduledTime creates the time of a specific scheduled outage between two dates (using the local time zone)
+// The dates don't include a time zone, and OpenNMS interprets them using the time zone of the server.
+func NewSpecificScheduledTime(begins time.Time, ends time.Time) ScheduledTime {
+	return NewSpecificScheduledTimeIn(begins, ends, time.Local)
+}
+
+// NewSpecificScheduledTimeIn creates the time of a specific scheduled outage between two dates, expressed in a given time zone
+func NewSpecificScheduledTimeIn(begins time.Time, ends time.Time, location *time.Location) ScheduledTime {
+	return ScheduledTime{
+		Begins: begins.In(location).Format(ScheduledSpecificTimeFormat),
+		Ends:   ends.In(location).Format(ScheduledSpecificTimeFormat),
+	}
+}
+
+// GetSpecificRange parses the begin and end dates of a specific scheduled outage (using the local time zone)
+func (sc *ScheduledTime) GetSpecificRange() (time.Time, time.Time, error) {
+	return sc.GetSpecificRangeIn(time.Local)
+}
+
+// GetSpecificRangeIn parses the begin and end dates of a specific scheduled outage, expressed in a given time zone
+func (sc *ScheduledTime) GetSpecificRangeIn(location *time.Location) (time.Time, time.Time, error) {
+	begins, err := time.ParseInLocation(ScheduledSpecificTimeFormat, sc.Begins, location)
+	if err != nil {
+		return begins, begins, fmt.Errorf("invalid specific begin date: %s", err.Error())
+	}
+	ends, err := time.ParseInLocation(ScheduledSpecificTimeFormat, sc.Ends, location)
+	if err != nil {
+		return begins, ends, fmt.Errorf("invalid specific end date: %s", err.Error())
+	}
+	return begins, ends, nil
+}
+
 func (sc *ScheduledTime) hasValidHourlyRange() error {
 	if sc.Begins == "" {
 		return fmt.Errorf("begin hour cannot be empty")
@@ -121,5 +209,39 @@ func (o *ScheduledOutage) IsValid() error {
 
 // ScheduledOutageList list of scheduled outages
 type ScheduledOutageList struct {
-	Outages []ScheduledOutage
+	Outages []ScheduledOutage `json:"outage" yaml:"outages"`
+}
+
+// GetOutage gets a scheduled outage by name (nil if not found)
+func (list ScheduledOutageList) GetOutage(name string) *ScheduledOutage {
+	for _, o := range list.Outages {
+		if o.Name == name {
+			return &o
+		}
+	}
+	return nil
+}
+
+// ParseScheduledOutagePackage parses a package where a scheduled outage applies, in the form target[:packageName]
+// The plural form of the target is also accepted (e.x. notifications). Targets without a package use ScheduledOutageDefaultPackages.
+func ParseScheduledOutagePackage(text string) (string, string, error) {
+	data := strings.SplitN(strings.TrimSpace(text), ":", 2)
+	target := strings.ToLower(data[0])
+	if !ScheduledOutageTargets.isValid(target) {
+		target = strings.TrimSuffix(target, "s")
+	}
+	if !ScheduledOutageTargets.isValid(target) {
+		return "", "", fmt.Errorf("invalid target %s. Allowed values: %s", data[0], ScheduledOutageTargets.EnumAsString())
+	}
+	packageName := ""
+	if len(data) == 2 {
+		packageName = data[1]
+	}
+	if target == "notification" && packageName != "" {
+		return "", "", fmt.Errorf("notification target doesn't require a package")
+	}
+	if target != "notification" && packageName == "" {
+		packageName = ScheduledOutageDefaultPackages[target]
+	}
+	return target, packageName, nil
 }
@@ -0,0 +1,483 @@
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The date used as reference for the first occurrence of recurring scheduled outages on iCalendar (a Monday)
+var icsReferenceDate = time.Date(2001, time.January, 1, 0, 0, 0, 0, time.Local)
+
+// The iCalendar day codes, indexed like WeekDays
+var icsWeekDays = []string{"MO", "TU", "WE", "TH", "FR", "SA", "SU"}
+
+// The iCalendar custom properties used to preserve the content of a scheduled outage
+const (
+	icsOutageName       = "X-OPENNMS-OUTAGE-NAME"
+	icsOutageType       = "X-OPENNMS-OUTAGE-TYPE"
+	icsOutageNodes      = "X-OPENNMS-NODES"
+	icsOutageInterfaces = "X-OPENNMS-INTERFACES"
+)
+
+const (
+	icsDateTimeFormat = "20060102T150405"
+	icsDateFormat     = "20060102"
+	icsHourFormat     = "15:04:05"
+	icsMaxLineLength  = 75
+)
+
+var icsDurationPattern = regexp.MustCompile(`^P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// icsProperty a content line of an iCalendar file
+type icsProperty struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// WriteScheduledOutagesICS writes scheduled outages as iCalendar (RFC 5545) events, one VEVENT per scheduled time
+// Recurring outages use RRULEs starting on a fixed reference date. The name, type, nodes and interfaces of each outage are preserved through X-OPENNMS properties.
+func WriteScheduledOutagesICS(outages []ScheduledOutage, stamp time.Time, writer io.Writer) error {
+	lines := []string{"BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:-//OpenNMS//onmsctl//EN", "CALSCALE:GREGORIAN"}
+	for _, o := range outages {
+		if err := o.IsValid(); err != nil {
+			return fmt.Errorf("invalid scheduled outage %s: %v", o.Name, err)
+		}
+		nodes := make([]string, len(o.Nodes))
+		for i, n := range o.Nodes {
+			nodes[i] = strconv.Itoa(n.ID)
+		}
+		interfaces := make([]string, len(o.Interfaces))
+		for i, intf := range o.Interfaces {
+			interfaces[i] = intf.Address
+		}
+		for i, t := range o.Times {
+			begins, ends, rrule, err := t.toICS(o.Type)
+			if err != nil {
+				return fmt.Errorf("invalid scheduled outage %s: %v", o.Name, err)
+			}
+			lines = append(lines,
+				"BEGIN:VEVENT",
+				"UID:"+escapeICSText(fmt.Sprintf("%s-%d@onmsctl", o.Name, i+1)),
+				"DTSTAMP:"+stamp.UTC().Format(icsDateTimeFormat)+"Z",
+				"SUMMARY:"+escapeICSText(o.Name),
+				"DTSTART:"+begins.Format(icsDateTimeFormat),
+				"DTEND:"+ends.Format(icsDateTimeFormat),
+			)
+			if rrule != "" {
+				lines = append(lines, "RRULE:"+rrule)
+			}
+			lines = append(lines, icsOutageName+":"+escapeICSText(o.Name), icsOutageType+":"+o.Type)
+			if len(nodes) > 0 {
+				lines = append(lines, icsOutageNodes+":"+strings.Join(nodes, ","))
+			}
+			if len(interfaces) > 0 {
+				lines = append(lines, icsOutageInterfaces+":"+strings.Join(interfaces, ","))
+			}
+			lines = append(lines, "END:VEVENT")
+		}
+	}
+	lines = append(lines, "END:VCALENDAR")
+	for _, line := range lines {
+		if _, err := io.WriteString(writer, foldICSLine(line)+"\r\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toICS gets the first occurrence and the recurrence rule of a scheduled time
+func (sc *ScheduledTime) toICS(scheduleType string) (time.Time, time.Time, string, error) {
+	if scheduleType == "specific" {
+		begins, ends, err := sc.GetSpecificRange()
+		return begins, ends, "", err
+	}
+	date := icsReferenceDate
+	rrule := ""
+	switch scheduleType {
+	case "daily":
+		rrule = "FREQ=DAILY"
+	case "weekly":
+		for i, day := range WeekDays.Enum {
+			if day == sc.Day {
+				date = date.AddDate(0, 0, i)
+				rrule = "FREQ=WEEKLY;BYDAY=" + icsWeekDays[i]
+			}
+		}
+	case "monthly":
+		day, _ := strconv.Atoi(sc.Day)
+		if day < 1 || day > 31 {
+			return date, date, "", fmt.Errorf("invalid monthly day %s", sc.Day)
+		}
+		date = date.AddDate(0, 0, day-1)
+		rrule = fmt.Sprintf("FREQ=MONTHLY;BYMONTHDAY=%d", day)
+	}
+	begins := atHour(date, sc.Begins)
+	ends := atHour(date, sc.Ends)
+	if !ends.After(begins) {
+		ends = ends.AddDate(0, 0, 1) // Overnight
+	}
+	return begins, ends, rrule, nil
+}
+
+// ParseScheduledOutagesICS parses the events of an iCalendar file as scheduled outages
+// Events with the same name (X-OPENNMS-OUTAGE-NAME, or SUMMARY for events from other sources) become a single outage with multiple times.
+// Recurrence rules are limited to what OpenNMS supports: daily, weekly by day, and monthly by day of month, without interval, count, or end date.
+func ParseScheduledOutagesICS(data []byte) ([]ScheduledOutage, error) {
+	properties, err := readICSProperties(data)
+	if err != nil {
+		return nil, err
+	}
+	outages := make([]ScheduledOutage, 0)
+	index := make(map[string]int)
+	var event []icsProperty
+	eventNumber := 0
+	for _, p := range properties {
+		switch {
+		case p.name == "BEGIN" && strings.EqualFold(p.value, "VEVENT"):
+			event = make([]icsProperty, 0)
+			eventNumber++
+		case p.name == "END" && strings.EqualFold(p.value, "VEVENT"):
+			if event == nil {
+				return nil, fmt.Errorf("unexpected END:VEVENT")
+			}
+			outage, err := parseICSEvent(event)
+			if err != nil {
+				return nil, fmt.Errorf("event %d: %v", eventNumber, err)
+			}
+			if i, ok := index[outage.Name]; ok {
+				if err = mergeScheduledOutages(&outages[i], outage); err != nil {
+					return nil, fmt.Errorf("event %d: %v", eventNumber, err)
+				}
+			} else {
+				index[outage.Name] = len(outages)
+				outages = append(outages, *outage)
+			}
+			event = nil
+		case event != nil:
+			event = append(event, p)
+		}
+	}
+	if event != nil {
+		return nil, fmt.Errorf("event %d: missing END:VEVENT", eventNumber)
+	}
+	for i := range outages {
+		if err := outages[i].IsValid(); err != nil {
+			return nil, fmt.Errorf("invalid scheduled outage %s: %v", outages[i].Name, err)
+		}
+	}
+	return outages, nil
+}
+
+func parseICSEvent(event []icsProperty) (*ScheduledOutage, error) {
+	props := make(map[string]icsProperty)
+	for _, p := range event {
+		props[p.name] = p
+	}
+	outage := &ScheduledOutage{Name: unescapeICSText(props[icsOutageName].value)}
+	if outage.Name == "" {
+		outage.Name = unescapeICSText(props["SUMMARY"].value)
+	}
+	if outage.Name == "" {
+		return nil, fmt.Errorf("either SUMMARY or %s is required", icsOutageName)
+	}
+	start, ok := props["DTSTART"]
+	if !ok {
+		return nil, fmt.Errorf("DTSTART is required")
+	}
+	begins, allDay, err := parseICSTime(start)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DTSTART: %v", err)
+	}
+	var ends time.Time
+	if end, ok := props["DTEND"]; ok {
+		if ends, _, err = parseICSTime(end); err != nil {
+			return nil, fmt.Errorf("invalid DTEND: %v", err)
+		}
+	} else if duration, ok := props["DURATION"]; ok {
+		d, err := parseICSDuration(duration.value)
+		if err != nil {
+			return nil, err
+		}
+		ends = begins.Add(d)
+	} else if allDay {
+		ends = begins.AddDate(0, 0, 1)
+	} else {
+		return nil, fmt.Errorf("either DTEND or DURATION is required")
+	}
+	if allDay {
+		ends = ends.Add(-time.Second) // The end date of all day events is exclusive
+	}
+	if !ends.After(begins) {
+		return nil, fmt.Errorf("the end of the event must be after the start")
+	}
+	rrule, hasRule := props["RRULE"]
+	if !hasRule {
+		outage.Type = "specific"
+		outage.Times = []ScheduledTime{NewSpecificScheduledTime(begins, ends)}
+	} else if outage.Type, outage.Times, err = parseICSRecurrence(rrule.value, begins, ends); err != nil {
+		return nil, err
+	}
+	if t, ok := props[icsOutageType]; ok && t.value != outage.Type {
+		return nil, fmt.Errorf("the %s event doesn't match the %s type of outage %s", outage.Type, t.value, outage.Name)
+	}
+	for _, id := range splitICSList(props[icsOutageNodes].value) {
+		nodeID, err := strconv.Atoi(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node ID %s", id)
+		}
+		outage.Nodes = append(outage.Nodes, ScheduledNode{ID: nodeID})
+	}
+	for _, address := range splitICSList(props[icsOutageInterfaces].value) {
+		outage.Interfaces = append(outage.Interfaces, ScheduledInterface{Address: address})
+	}
+	return outage, nil
+}
+
+// parseICSRecurrence converts a recurrence rule into the type and times of a scheduled outage
+func parseICSRecurrence(rrule string, begins time.Time, ends time.Time) (string, []ScheduledTime, error) {
+	if ends.Sub(begins) > 24*time.Hour {
+		return "", nil, fmt.Errorf("recurring events longer than a day are not supported")
+	}
+	parts := make(map[string]string)
+	for _, part := range strings.Split(rrule, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return "", nil, fmt.Errorf("invalid RRULE %s", rrule)
+		}
+		parts[strings.ToUpper(kv[0])] = strings.ToUpper(kv[1])
+	}
+	for key, value := range parts {
+		switch key {
+		case "FREQ", "BYDAY", "BYMONTHDAY", "WKST":
+		case "INTERVAL":
+			if value != "1" {
+				return "", nil, fmt.Errorf("unsupported RRULE %s: only an interval of 1 is supported", rrule)
+			}
+		default:
+			return "", nil, fmt.Errorf("unsupported RRULE %s: %s is not supported", rrule, key)
+		}
+	}
+	base := ScheduledTime{Begins: begins.Format(icsHourFormat), Ends: ends.Format(icsHourFormat)}
+	times := make([]ScheduledTime, 0)
+	switch parts["FREQ"] {
+	case "DAILY":
+		if parts["BYDAY"] != "" || parts["BYMONTHDAY"] != "" {
+			return "", nil, fmt.Errorf("unsupported RRULE %s: daily rules cannot be limited by day", rrule)
+		}
+		return "daily", []ScheduledTime{base}, nil
+	case "WEEKLY":
+		days := splitICSList(parts["BYDAY"])
+		if len(days) == 0 {
+			days = []string{icsWeekDays[(int(begins.Weekday())+6)%7]}
+		}
+		for _, code := range days {
+			i := indexOf(icsWeekDays, code)
+			if i < 0 {
+				return "", nil, fmt.Errorf("unsupported RRULE %s: invalid day %s", rrule, code)
+			}
+			t := base
+			t.Day = WeekDays.Enum[i]
+			times = append(times, t)
+		}
+		return "weekly", times, nil
+	case "MONTHLY":
+		days := splitICSList(parts["BYMONTHDAY"])
+		if len(days) == 0 {
+			days = []string{strconv.Itoa(begins.Day())}
+		}
+		if parts["BYDAY"] != "" {
+			return "", nil, fmt.Errorf("unsupported RRULE %s: monthly rules by week day are not supported", rrule)
+		}
+		for _, day := range days {
+			if d, err := strconv.Atoi(day); err != nil || d < 1 || d > 31 {
+				return "", nil, fmt.Errorf("unsupported RRULE %s: invalid day of month %s", rrule, day)
+			}
+			t := base
+			t.Day = day
+			times = append(times, t)
+		}
+		return "monthly", times, nil
+	}
+	return "", nil, fmt.Errorf("unsupported RRULE %s: only daily, weekly and monthly frequencies are supported", rrule)
+}
+
+// mergeScheduledOutages adds the times, nodes and interfaces of an outage into another one with the same name
+func mergeScheduledOutages(target *ScheduledOutage, source *ScheduledOutage) error {
+	if target.Type != source.Type {
+		return fmt.Errorf("the %s event doesn't match the %s type of outage %s", source.Type, target.Type, target.Name)
+	}
+	target.Times = append(target.Times, source.Times...)
+	for _, n := range source.Nodes {
+		found := false
+		for _, existing := range target.Nodes {
+			found = found || existing.ID == n.ID
+		}
+		if !found {
+			target.Nodes = append(target.Nodes, n)
+		}
+	}
+	for _, intf := range source.Interfaces {
+		found := false
+		for _, existing := range target.Interfaces {
+			found = found || existing.Address == intf.Address
+		}
+		if !found {
+			target.Interfaces = append(target.Interfaces, intf)
+		}
+	}
+	return nil
+}
+
+// readICSProperties unfolds the content lines of an iCalendar file and parses them
+func readICSProperties(data []byte) ([]icsProperty, error) {
+	lines := make([]string, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 || !strings.EqualFold(lines[0], "BEGIN:VCALENDAR") {
+		return nil, fmt.Errorf("invalid iCalendar content, expected BEGIN:VCALENDAR")
+	}
+	properties := make([]icsProperty, 0, len(lines))
+	for i, line := range lines {
+		p, err := parseICSProperty(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", i+1, err)
+		}
+		properties = append(properties, p)
+	}
+	return properties, nil
+}
+
+// parseICSProperty parses a content line in the form NAME;PARAM=VALUE:VALUE, ignoring colons inside quoted parameters
+func parseICSProperty(line string) (icsProperty, error) {
+	p := icsProperty{params: make(map[string]string)}
+	quoted := false
+	colon := -1
+	for i, c := range line {
+		if c == '"' {
+			quoted = !quoted
+		} else if c == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon <= 0 {
+		return p, fmt.Errorf("invalid content line %s", line)
+	}
+	p.value = line[colon+1:]
+	parts := strings.Split(line[:colon], ";")
+	p.name = strings.ToUpper(parts[0])
+	for _, param := range parts[1:] {
+		kv := strings.SplitN(param, "=", 2)
+		if len(kv) == 2 {
+			p.params[strings.ToUpper(kv[0])] = strings.Trim(kv[1], `"`)
+		}
+	}
+	return p, nil
+}
+
+// parseICSTime parses a DATE or DATE-TIME value as local time (or using the TZID parameter, or UTC when ends with Z), returning true for dates
+func parseICSTime(p icsProperty) (time.Time, bool, error) {
+	if p.params["VALUE"] == "DATE" || len(p.value) == len(icsDateFormat) {
+		t, err := time.ParseInLocation(icsDateFormat, p.value, time.Local)
+		return t, true, err
+	}
+	location := time.Local
+	if tzid := p.params["TZID"]; tzid != "" {
+		loc, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("unknown time zone %s", tzid)
+		}
+		location = loc
+	}
+	value := p.value
+	if strings.HasSuffix(value, "Z") {
+		value = strings.TrimSuffix(value, "Z")
+		location = time.UTC
+	}
+	t, err := time.ParseInLocation(icsDateTimeFormat, value, location)
+	if err != nil {
+		return t, false, err
+	}
+	return t.Local(), false, nil
+}
+
+// parseICSDuration parses a positive duration like P1D or PT2H30M
+func parseICSDuration(value string) (time.Duration, error) {
+	m := icsDurationPattern.FindStringSubmatch(strings.TrimPrefix(value, "+"))
+	if m == nil || value == "P" || value == "PT" {
+		return 0, fmt.Errorf("invalid DURATION %s", value)
+	}
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var d time.Duration
+	for i, unit := range units {
+		if m[i+1] != "" {
+			n, _ := strconv.Atoi(m[i+1])
+			d += time.Duration(n) * unit
+		}
+	}
+	return d, nil
+}
+
+// foldICSLine splits lines longer than 75 octets, as required by RFC 5545, without breaking UTF-8 characters
+func foldICSLine(line string) string {
+	if len(line) <= icsMaxLineLength {
+		return line
+	}
+	var builder strings.Builder
+	size := 0
+	limit := icsMaxLineLength
+	for _, r := range line {
+		n := len(string(r))
+		if size+n > limit {
+			builder.WriteString("\r\n ")
+			size = 0
+			limit = icsMaxLineLength - 1
+		}
+		builder.WriteRune(r)
+		size += n
+	}
+	return builder.String()
+}
+
+func escapeICSText(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(text)
+}
+
+func unescapeICSText(text string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n").Replace(text)
+}
+
+func splitICSList(value string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func atHour(date time.Time, hour string) time.Time {
+	t, _ := time.Parse(icsHourFormat, hour)
+	return time.Date(date.Year(), date.Month(), date.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.Local)
+}
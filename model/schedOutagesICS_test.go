@@ -0,0 +1,162 @@
+package model
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+var icsStamp = time.Date(2020, time.May, 1, 12, 0, 0, 0, time.UTC)
+
+func TestScheduledOutagesICSRoundTrip(t *testing.T) {
+	outages := []ScheduledOutage{
+		{
+			Name:  "Upgrade, phase 1",
+			Type:  "specific",
+			Nodes: []ScheduledNode{{ID: 1}, {ID: 2}},
+			Times: []ScheduledTime{{Begins: "01-Jun-2020 22:00:00", Ends: "02-Jun-2020 02:00:00"}},
+		},
+		{
+			Name:       "Nightly Backups",
+			Type:       "daily",
+			Interfaces: []ScheduledInterface{{Address: "10.0.0.1"}, {Address: "10.0.0.2"}},
+			Times:      []ScheduledTime{{Begins: "23:00:00", Ends: "01:30:00"}},
+		},
+		{
+			Name:  "Weekend",
+			Type:  "weekly",
+			Nodes: []ScheduledNode{{ID: 3}},
+			Times: []ScheduledTime{
+				{Day: "saturday", Begins: "00:00:00", Ends: "23:59:59"},
+				{Day: "sunday", Begins: "00:00:00", Ends: "23:59:59"},
+			},
+		},
+		{
+			Name:  "Patching",
+			Type:  "monthly",
+			Nodes: []ScheduledNode{{ID: 4}},
+			Times: []ScheduledTime{{Day: "31", Begins: "03:00:00", Ends: "05:00:00"}},
+		},
+	}
+	buffer := &bytes.Buffer{}
+	assert.NilError(t, WriteScheduledOutagesICS(outages, icsStamp, buffer))
+	ics := buffer.String()
+	assert.Assert(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.Assert(t, strings.Contains(ics, "SUMMARY:Upgrade\\, phase 1\r\n"))
+	assert.Assert(t, strings.Contains(ics, "DTSTART:20200601T220000\r\nDTEND:20200602T020000\r\n"))
+	assert.Assert(t, strings.Contains(ics, "DTSTART:20010101T230000\r\nDTEND:20010102T013000\r\nRRULE:FREQ=DAILY\r\n"))
+	assert.Assert(t, strings.Contains(ics, "DTSTART:20010106T000000\r\nDTEND:20010106T235959\r\nRRULE:FREQ=WEEKLY;BYDAY=SA\r\n"))
+	assert.Assert(t, strings.Contains(ics, "DTSTART:20010131T030000\r\nDTEND:20010131T050000\r\nRRULE:FREQ=MONTHLY;BYMONTHDAY=31\r\n"))
+	assert.Equal(t, 5, strings.Count(ics, "BEGIN:VEVENT"))
+
+	parsed, err := ParseScheduledOutagesICS(buffer.Bytes())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, outages, parsed)
+	for _, o := range parsed {
+		assert.NilError(t, o.IsValid())
+	}
+}
+
+func TestWriteInvalidScheduledOutageICS(t *testing.T) {
+	outages := []ScheduledOutage{
+		{Name: "Broken", Type: "weekly", Times: []ScheduledTime{{Day: "funday", Begins: "00:00:00", Ends: "23:59:59"}}},
+	}
+	err := WriteScheduledOutagesICS(outages, icsStamp, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "invalid scheduled outage Broken: invalid day for weekly schedule")
+}
+
+func TestParseExternalICS(t *testing.T) {
+	ics := "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"PRODID:-//Google Inc//Google Calendar 70.9054//EN\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;VALUE=DATE:20200704\r\n" +
+		"DTEND;VALUE=DATE:20200705\r\n" +
+		"SUMMARY:Independence Day\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;TZID=UTC:20200101T100000\r\n" +
+		"DURATION:PT1H30M\r\n" +
+		"RRULE:FREQ=WEEKLY;WKST=SU;BYDAY=MO,WE\r\n" +
+		"SUMMARY:Change Advisory\r\n" +
+		"  Board\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART:20200101T180000Z\r\n" +
+		"DTEND:20200101T190000Z\r\n" +
+		"SUMMARY:Release\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+	outages, err := ParseScheduledOutagesICS([]byte(ics))
+	assert.NilError(t, err)
+	assert.Equal(t, 3, len(outages))
+
+	assert.DeepEqual(t, ScheduledOutage{
+		Name:  "Independence Day",
+		Type:  "specific",
+		Times: []ScheduledTime{{Begins: "04-Jul-2020 00:00:00", Ends: "04-Jul-2020 23:59:59"}},
+	}, outages[0])
+
+	begins := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC).Local().Format(icsHourFormat)
+	ends := time.Date(2020, 1, 1, 11, 30, 0, 0, time.UTC).Local().Format(icsHourFormat)
+	assert.DeepEqual(t, ScheduledOutage{
+		Name: "Change Advisory Board",
+		Type: "weekly",
+		Times: []ScheduledTime{
+			{Day: "monday", Begins: begins, Ends: ends},
+			{Day: "wednesday", Begins: begins, Ends: ends},
+		},
+	}, outages[1])
+
+	release := NewSpecificScheduledTime(time.Date(2020, 1, 1, 18, 0, 0, 0, time.UTC), time.Date(2020, 1, 1, 19, 0, 0, 0, time.UTC))
+	assert.DeepEqual(t, []ScheduledTime{release}, outages[2].Times)
+}
+
+func TestParseInvalidICS(t *testing.T) {
+	event := func(lines ...string) []byte {
+		return []byte("BEGIN:VCALENDAR\r\nVERSION:2.0\r\nBEGIN:VEVENT\r\n" + strings.Join(lines, "\r\n") + "\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n")
+	}
+	var err error
+
+	_, err = ParseScheduledOutagesICS([]byte("BEGIN:VEVENT\r\n"))
+	assert.Error(t, err, "invalid iCalendar content, expected BEGIN:VCALENDAR")
+
+	_, err = ParseScheduledOutagesICS(event("DTSTART:20200101T100000", "DTEND:20200101T110000"))
+	assert.Error(t, err, "event 1: either SUMMARY or X-OPENNMS-OUTAGE-NAME is required")
+
+	_, err = ParseScheduledOutagesICS(event("SUMMARY:Test", "DTSTART:20200101T100000"))
+	assert.Error(t, err, "event 1: either DTEND or DURATION is required")
+
+	_, err = ParseScheduledOutagesICS(event("SUMMARY:Test", "DTSTART:20200101T100000", "DTEND:20200101T090000"))
+	assert.Error(t, err, "event 1: the end of the event must be after the start")
+
+	_, err = ParseScheduledOutagesICS(event("SUMMARY:Test", "DTSTART:20200101T100000", "DTEND:20200101T110000", "RRULE:FREQ=WEEKLY;INTERVAL=2"))
+	assert.Error(t, err, "event 1: unsupported RRULE FREQ=WEEKLY;INTERVAL=2: only an interval of 1 is supported")
+
+	_, err = ParseScheduledOutagesICS(event("SUMMARY:Test", "DTSTART:20200101T100000", "DTEND:20200101T110000", "RRULE:FREQ=DAILY;COUNT=5"))
+	assert.Error(t, err, "event 1: unsupported RRULE FREQ=DAILY;COUNT=5: COUNT is not supported")
+
+	_, err = ParseScheduledOutagesICS(event("SUMMARY:Test", "DTSTART:20200101T100000", "DTEND:20200101T110000", "RRULE:FREQ=YEARLY"))
+	assert.Error(t, err, "event 1: unsupported RRULE FREQ=YEARLY: only daily, weekly and monthly frequencies are supported")
+
+	_, err = ParseScheduledOutagesICS(event("SUMMARY:Test", "DTSTART:20200101T100000", "DTEND:20200103T110000", "RRULE:FREQ=DAILY"))
+	assert.Error(t, err, "event 1: recurring events longer than a day are not supported")
+
+	_, err = ParseScheduledOutagesICS(event("SUMMARY:Test", "DTSTART:20200101T100000", "DTEND:20200101T110000", "X-OPENNMS-OUTAGE-TYPE:daily"))
+	assert.Error(t, err, "event 1: the specific event doesn't match the daily type of outage Test")
+
+	_, err = ParseScheduledOutagesICS([]byte("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nSUMMARY:Test\r\nEND:VCALENDAR\r\n"))
+	assert.Error(t, err, "event 1: missing END:VEVENT")
+}
+
+func TestFoldICSLine(t *testing.T) {
+	line := "SUMMARY:" + strings.Repeat("á", 50)
+	folded := foldICSLine(line)
+	for _, l := range strings.Split(folded, "\r\n") {
+		assert.Assert(t, len(l) <= icsMaxLineLength)
+	}
+	assert.Equal(t, line, strings.ReplaceAll(folded, "\r\n ", ""))
+}
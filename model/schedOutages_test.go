@@ -1,8 +1,11 @@
 package model
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
+	"gopkg.in/yaml.v2"
 	"gotest.tools/assert"
 )
 
@@ -96,3 +99,58 @@ func TestIsMonthlyValid(t *testing.T) {
 	so.Times[0].Day = "monday"
 	assert.ErrorContains(t, so.IsValid(), "invalid monthly day")
 }
+
+func TestSpecificScheduledTime(t *testing.T) {
+	begins := time.Date(2020, 5, 1, 10, 0, 0, 0, time.Local)
+	st := NewSpecificScheduledTime(begins, begins.Add(2*time.Hour))
+	assert.Equal(t, "01-May-2020 10:00:00", st.Begins)
+	assert.Equal(t, "01-May-2020 12:00:00", st.Ends)
+	assert.NilError(t, st.IsValid("specific"))
+
+	from, to, err := st.GetSpecificRange()
+	assert.NilError(t, err)
+	assert.Assert(t, from.Equal(begins))
+	assert.Equal(t, 2*time.Hour, to.Sub(from))
+
+	utc := NewSpecificScheduledTimeIn(begins, begins.Add(2*time.Hour), time.UTC)
+	assert.Equal(t, begins.UTC().Format(ScheduledSpecificTimeFormat), utc.Begins)
+	from, _, err = utc.GetSpecificRangeIn(time.UTC)
+	assert.NilError(t, err)
+	assert.Assert(t, from.Equal(begins))
+}
+
+func TestParseScheduledOutagePackage(t *testing.T) {
+	target, pkg, err := ParseScheduledOutagePackage("poller:example1")
+	assert.NilError(t, err)
+	assert.Equal(t, "poller", target)
+	assert.Equal(t, "example1", pkg)
+
+	target, pkg, err = ParseScheduledOutagePackage("notifications")
+	assert.NilError(t, err)
+	assert.Equal(t, "notification", target)
+	assert.Equal(t, "", pkg)
+
+	for target, expected := range map[string]string{"poller": "example1", "collectors": "example1", "threshold": "mib2"} {
+		_, pkg, err = ParseScheduledOutagePackage(target)
+		assert.NilError(t, err)
+		assert.Equal(t, expected, pkg)
+	}
+
+	_, _, err = ParseScheduledOutagePackage("notification:mail")
+	assert.Error(t, err, "notification target doesn't require a package")
+
+	_, _, err = ParseScheduledOutagePackage("reporter:main")
+	assert.Error(t, err, "invalid target reporter. Allowed values: poller, collector, threshold, notification")
+}
+
+func TestScheduledNodeID(t *testing.T) {
+	outage := ScheduledOutage{}
+	assert.NilError(t, json.Unmarshal([]byte(`{"name":"test","type":"daily","node":[{"id":1},{"id":"2"}]}`), &outage))
+	assert.DeepEqual(t, []ScheduledNode{{ID: 1}, {ID: 2}}, outage.Nodes)
+
+	outage = ScheduledOutage{}
+	assert.NilError(t, yaml.Unmarshal([]byte("name: test\ntype: daily\nnodes:\n- id: 3\n- id: \"4\"\n"), &outage))
+	assert.DeepEqual(t, []ScheduledNode{{ID: 3}, {ID: 4}}, outage.Nodes)
+
+	assert.ErrorContains(t, json.Unmarshal([]byte(`{"id":"srv01"}`), &ScheduledNode{}), "invalid node ID srv01")
+}
@@ -0,0 +1,189 @@
+package model
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// SearchOrders list of valid sort orders for a search
+var SearchOrders = EnumValue{
+	Enum: []string{"asc", "desc"},
+}
+
+// SearchList a paginated list of entities returned by the ReST API v2
+type SearchList interface {
+	// Merge appends the entities of another page of the same list
+	Merge(page SearchList)
+	// Len gets the amount of entities on the list
+	Len() int
+}
+
+// SearchEntity an entity that can be searched through the ReST API v2
+type SearchEntity struct {
+	Name             string
+	Path             string
+	Filter           string // Always combined with the filter of the search (e.x. situations are alarms)
+	DefaultOrderBy   string // Used when walking all the pages to guarantee a stable order
+	TimeProperty     string // Empty when the entity cannot be filtered by time
+	SeverityProperty string // Empty when the entity cannot be filtered by severity
+	NumericSeverity  bool   // Whether the severity property stores the numeric ID of the severity
+	NodeFilter       bool   // Whether the entity can be filtered by the properties of its node
+	NewList          func() SearchList
+}
+
+// SearchEntities the entities that can be searched through the ReST API v2
+var SearchEntities = []SearchEntity{
+	{
+		Name:           "nodes",
+		Path:           "/api/v2/nodes",
+		DefaultOrderBy: "id",
+		TimeProperty:   "node.createTime",
+		NodeFilter:     true,
+		NewList:        func() SearchList { return &OnmsNodeList{} },
+	},
+	{
+		Name:             "events",
+		Path:             "/api/v2/events",
+		DefaultOrderBy:   "id",
+		TimeProperty:     "eventTime",
+		SeverityProperty: "eventSeverity",
+		NumericSeverity:  true,
+		NodeFilter:       true,
+		NewList:          func() SearchList { return &OnmsEventList{} },
+	},
+	{
+		Name:             "alarms",
+		Path:             "/api/v2/alarms",
+		DefaultOrderBy:   "id",
+		TimeProperty:     "alarm.lastEventTime",
+		SeverityProperty: "alarm.severity",
+		NodeFilter:       true,
+		NewList:          func() SearchList { return &OnmsAlarmList{} },
+	},
+	{
+		Name:           "outages",
+		Path:           "/api/v2/outages",
+		DefaultOrderBy: "id",
+		TimeProperty:   "ifLostService",
+		NodeFilter:     true,
+		NewList:        func() SearchList { return &OnmsOutageList{} },
+	},
+	{
+		Name:           "ipinterfaces",
+		Path:           "/api/v2/ipinterfaces",
+		DefaultOrderBy: "id",
+		NodeFilter:     true,
+		NewList:        func() SearchList { return &OnmsIPInterfaceList{} },
+	},
+	{
+		Name:           "snmpinterfaces",
+		Path:           "/api/v2/snmpinterfaces",
+		DefaultOrderBy: "id",
+		NodeFilter:     true,
+		NewList:        func() SearchList { return &OnmsSnmpInterfaceList{} },
+	},
+	{
+		Name:           "monitoredservices",
+		Path:           "/api/v2/ifservices",
+		DefaultOrderBy: "id",
+		NodeFilter:     true,
+		NewList:        func() SearchList { return &OnmsMonitoredServiceList{} },
+	},
+	{
+		Name:           "applications",
+		Path:           "/api/v2/applications",
+		DefaultOrderBy: "id",
+		NewList:        func() SearchList { return &OnmsApplicationList{} },
+	},
+	{
+		Name:             "situations",
+		Path:             "/api/v2/alarms",
+		Filter:           "isSituation==true",
+		DefaultOrderBy:   "id",
+		TimeProperty:     "alarm.lastEventTime",
+		SeverityProperty: "alarm.severity",
+		NodeFilter:       true,
+		NewList:          func() SearchList { return &OnmsAlarmList{} },
+	},
+	{
+		Name:           "businessservices",
+		Path:           "/rest/business-services",
+		DefaultOrderBy: "id",
+		NewList:        func() SearchList { return &OnmsBusinessServiceList{} },
+	},
+	{
+		Name:           "locations",
+		Path:           "/api/v2/monitoringLocations",
+		DefaultOrderBy: "locationName",
+		NewList:        func() SearchList { return &MonitoringLocationList{} },
+	},
+}
+
+// GetSearchEntity gets a searchable entity by name
+func GetSearchEntity(name string) (*SearchEntity, error) {
+	for _, e := range SearchEntities {
+		if e.Name == name {
+			return &e, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid entity %s", name)
+}
+
+// SearchEntityNames gets the names of the searchable entities
+func SearchEntityNames() []string {
+	names := make([]string, len(SearchEntities))
+	for i, e := range SearchEntities {
+		names[i] = e.Name
+	}
+	return names
+}
+
+// SearchQuery the parameters of a search through the ReST API v2
+type SearchQuery struct {
+	Filter  string // FIQL expression
+	OrderBy string
+	Order   string
+	Limit   int
+	Offset  int
+}
+
+// Params builds the query string of the search, excluding the limit and the offset
+func (q SearchQuery) Params() string {
+	params := make([]string, 0)
+	if q.OrderBy != "" {
+		params = append(params, "orderBy="+url.QueryEscape(q.OrderBy))
+	}
+	if q.Order != "" {
+		params = append(params, "order="+url.QueryEscape(q.Order))
+	}
+	if q.Filter != "" {
+		params = append(params, "_s="+url.QueryEscape(q.Filter))
+	}
+	return strings.Join(params, "&")
+}
+
+// URL builds the URL of a single page of the search for a given entity path
+func (q SearchQuery) URL(path string) string {
+	u := path + "?limit=" + strconv.Itoa(q.Limit) + "&offset=" + strconv.Itoa(q.Offset)
+	if params := q.Params(); params != "" {
+		u += "&" + params
+	}
+	return u
+}
+
+// SearchProperty a property of an entity that can be used on FIQL expressions, as exposed by the server
+type SearchProperty struct {
+	ID      string            `json:"id" yaml:"id"`
+	Name    string            `json:"name" yaml:"name"`
+	Type    string            `json:"type" yaml:"type"`
+	OrderBy bool              `json:"orderBy" yaml:"orderBy"`
+	IPLike  bool              `json:"iplike,omitempty" yaml:"iplike,omitempty"`
+	Values  map[string]string `json:"values,omitempty" yaml:"values,omitempty"`
+}
+
+// SearchPropertyList a list of searchable properties
+type SearchPropertyList struct {
+	Properties []SearchProperty `json:"searchProperty" yaml:"properties"`
+}
@@ -0,0 +1,99 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// SyslogFacilities the syslog facilities, ordered by their numeric code
+var SyslogFacilities = EnumValue{
+	Enum: []string{
+		"kern", "user", "mail", "daemon", "auth", "syslog", "lpr", "news", "uucp", "cron", "authpriv", "ftp",
+		"ntp", "security", "console", "solaris-cron", "local0", "local1", "local2", "local3", "local4", "local5", "local6", "local7",
+	},
+	Default: "user",
+}
+
+// SyslogSeverities the syslog severities, ordered by their numeric code
+var SyslogSeverities = EnumValue{
+	Enum:    []string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"},
+	Default: "notice",
+}
+
+// SyslogFormats the supported syslog message formats
+var SyslogFormats = EnumValue{
+	Enum:    []string{"rfc5424", "rfc3164"},
+	Default: "rfc5424",
+}
+
+// SyslogMessage a syslog message
+type SyslogMessage struct {
+	Facility  string    `json:"facility" yaml:"facility"`
+	Severity  string    `json:"severity" yaml:"severity"`
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
+	Hostname  string    `json:"hostname,omitempty" yaml:"hostname,omitempty"`
+	AppName   string    `json:"appName,omitempty" yaml:"appName,omitempty"`
+	ProcID    string    `json:"procID,omitempty" yaml:"procID,omitempty"`
+	MsgID     string    `json:"msgID,omitempty" yaml:"msgID,omitempty"`
+	Message   string    `json:"message" yaml:"message"`
+}
+
+// Validate returns an error if the syslog message is invalid
+func (m *SyslogMessage) Validate() error {
+	if m.Message == "" {
+		return fmt.Errorf("message cannot be empty")
+	}
+	if m.Facility == "" {
+		m.Facility = SyslogFacilities.Default
+	}
+	if !SyslogFacilities.isValid(m.Facility) {
+		return fmt.Errorf("invalid facility %s; allowed values: %s", m.Facility, SyslogFacilities.EnumAsString())
+	}
+	if m.Severity == "" {
+		m.Severity = SyslogSeverities.Default
+	}
+	if !SyslogSeverities.isValid(m.Severity) {
+		return fmt.Errorf("invalid severity %s; allowed values: %s", m.Severity, SyslogSeverities.EnumAsString())
+	}
+	if m.Timestamp.IsZero() {
+		m.Timestamp = time.Now()
+	}
+	return nil
+}
+
+// Priority gets the PRI value of the message, based on its facility and severity
+func (m SyslogMessage) Priority() int {
+	return indexOf(SyslogFacilities.Enum, m.Facility)*8 + indexOf(SyslogSeverities.Enum, m.Severity)
+}
+
+// Format builds the message using a given format (rfc5424 or rfc3164)
+func (m SyslogMessage) Format(format string) string {
+	if format == "rfc3164" {
+		return m.rfc3164()
+	}
+	return m.rfc5424()
+}
+
+// rfc3164 builds the message using the BSD syslog format: <PRI>TIMESTAMP HOSTNAME TAG[PID]: MSG
+func (m SyslogMessage) rfc3164() string {
+	tag := defaultIfEmpty(m.AppName, "onmsctl")
+	if m.ProcID != "" {
+		tag += "[" + m.ProcID + "]"
+	}
+	return fmt.Sprintf("<%d>%s %s %s: %s", m.Priority(), m.Timestamp.Format(time.Stamp), defaultIfEmpty(m.Hostname, "-"), tag, m.Message)
+}
+
+// rfc5424 builds the message using the IETF syslog format, without structured data: <PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID - MSG
+func (m SyslogMessage) rfc5424() string {
+	return fmt.Sprintf("<%d>1 %s %s %s %s %s - %s", m.Priority(), m.Timestamp.Format(time.RFC3339Nano),
+		defaultIfEmpty(m.Hostname, "-"), defaultIfEmpty(m.AppName, "-"), defaultIfEmpty(m.ProcID, "-"), defaultIfEmpty(m.MsgID, "-"), m.Message)
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
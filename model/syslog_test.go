@@ -0,0 +1,37 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func TestSyslogMessage(t *testing.T) {
+	message := SyslogMessage{Message: "Test"}
+	assert.NilError(t, message.Validate())
+	assert.Equal(t, "user", message.Facility)
+	assert.Equal(t, "notice", message.Severity)
+	assert.Equal(t, 13, message.Priority())
+	assert.Assert(t, !message.Timestamp.IsZero())
+
+	message = SyslogMessage{
+		Facility:  "local0",
+		Severity:  "err",
+		Timestamp: time.Date(2020, time.March, 5, 10, 4, 5, 0, time.UTC),
+		Hostname:  "srv01",
+		AppName:   "sshd",
+		ProcID:    "1234",
+		Message:   "Failed password for root",
+	}
+	assert.NilError(t, message.Validate())
+	assert.Equal(t, 131, message.Priority())
+	assert.Equal(t, "<131>1 2020-03-05T10:04:05Z srv01 sshd 1234 - - Failed password for root", message.Format("rfc5424"))
+	assert.Equal(t, "<131>Mar  5 10:04:05 srv01 sshd[1234]: Failed password for root", message.Format("rfc3164"))
+
+	message = SyslogMessage{Message: "Test", Facility: "unknown"}
+	assert.ErrorContains(t, message.Validate(), "invalid facility unknown")
+
+	message = SyslogMessage{}
+	assert.Error(t, message.Validate(), "message cannot be empty")
+}
@@ -0,0 +1,149 @@
+package model
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// TrapVarbindTypes the supported types for the variable bindings of an SNMP trap
+var TrapVarbindTypes = EnumValue{
+	Enum: []string{"integer", "string", "oid", "ipaddress", "counter32", "gauge32", "timeticks", "counter64"},
+}
+
+// The SNMP security levels
+const (
+	SnmpNoAuthNoPriv = 1
+	SnmpAuthNoPriv   = 2
+	SnmpAuthPriv     = 3
+)
+
+var oidPattern = regexp.MustCompile(`^\.?\d+(\.\d+)+$`)
+
+// TrapVarbind a variable binding of an SNMP trap
+type TrapVarbind struct {
+	OID   string `json:"oid" yaml:"oid"`
+	Type  string `json:"type" yaml:"type"`
+	Value string `json:"value" yaml:"value"`
+}
+
+// ParseTrapVarbind parses a variable binding in the form 'oid=type:value', where the type defaults to string
+func ParseTrapVarbind(text string) (TrapVarbind, error) {
+	data := strings.SplitN(text, "=", 2)
+	if len(data) != 2 {
+		return TrapVarbind{}, fmt.Errorf("invalid varbind %s, expected oid=type:value", text)
+	}
+	varbind := TrapVarbind{OID: data[0], Type: "string", Value: data[1]}
+	if pair := strings.SplitN(data[1], ":", 2); len(pair) == 2 && TrapVarbindTypes.isValid(pair[0]) {
+		varbind.Type = pair[0]
+		varbind.Value = pair[1]
+	}
+	return varbind, varbind.Validate()
+}
+
+// Validate returns an error if the variable binding is invalid
+func (v TrapVarbind) Validate() error {
+	if !oidPattern.MatchString(v.OID) {
+		return fmt.Errorf("invalid OID %s", v.OID)
+	}
+	if !TrapVarbindTypes.isValid(v.Type) {
+		return fmt.Errorf("invalid type %s for %s; allowed values: %s", v.Type, v.OID, TrapVarbindTypes.EnumAsString())
+	}
+	var err error
+	switch v.Type {
+	case "integer":
+		_, err = strconv.ParseInt(v.Value, 10, 32)
+	case "counter32", "gauge32", "timeticks":
+		_, err = strconv.ParseUint(v.Value, 10, 32)
+	case "counter64":
+		_, err = strconv.ParseUint(v.Value, 10, 64)
+	case "oid":
+		if !oidPattern.MatchString(v.Value) {
+			err = fmt.Errorf("invalid OID")
+		}
+	case "ipaddress":
+		if ip := net.ParseIP(v.Value); ip == nil || ip.To4() == nil {
+			err = fmt.Errorf("invalid IPv4 address")
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s value %s for %s", v.Type, v.Value, v.OID)
+	}
+	return nil
+}
+
+// Trap an SNMP trap
+// The SNMP version and credentials are taken from the SNMP configuration object.
+// TrapOID is used for SNMPv2c and SNMPv3, whereas Enterprise, Generic, Specific and AgentAddress are used for SNMPv1.
+type Trap struct {
+	SNMP         SnmpInfo      `json:"snmp" yaml:"snmp"`
+	TrapOID      string        `json:"trapOID,omitempty" yaml:"trapOID,omitempty"`
+	Enterprise   string        `json:"enterprise,omitempty" yaml:"enterprise,omitempty"`
+	Generic      int           `json:"generic,omitempty" yaml:"generic,omitempty"`
+	Specific     int           `json:"specific,omitempty" yaml:"specific,omitempty"`
+	AgentAddress string        `json:"agentAddress,omitempty" yaml:"agentAddress,omitempty"`
+	Uptime       uint32        `json:"uptime,omitempty" yaml:"uptime,omitempty"`
+	Varbinds     []TrapVarbind `json:"varbinds,omitempty" yaml:"varbinds,omitempty"`
+}
+
+// AddVarbind adds a new variable binding to the trap
+func (t *Trap) AddVarbind(oid string, varbindType string, value string) {
+	t.Varbinds = append(t.Varbinds, TrapVarbind{OID: oid, Type: varbindType, Value: value})
+}
+
+// Validate returns an error if the trap is invalid
+func (t *Trap) Validate() error {
+	if t.SNMP.Version == "" {
+		t.SNMP.Version = SNMPVersions.Default
+	}
+	if err := t.SNMP.Validate(); err != nil {
+		return err
+	}
+	switch t.SNMP.Version {
+	case "v1":
+		if !oidPattern.MatchString(t.Enterprise) {
+			return fmt.Errorf("a valid enterprise OID is required for SNMPv1 traps")
+		}
+		if t.Generic < 0 || t.Generic > 6 {
+			return fmt.Errorf("invalid generic trap %d; allowed values: 0 to 6", t.Generic)
+		}
+		if t.AgentAddress != "" {
+			if ip := net.ParseIP(t.AgentAddress); ip == nil || ip.To4() == nil {
+				return fmt.Errorf("invalid agent address %s", t.AgentAddress)
+			}
+		}
+	default:
+		if !oidPattern.MatchString(t.TrapOID) {
+			return fmt.Errorf("a valid trap OID is required for SNMP%s traps", t.SNMP.Version)
+		}
+	}
+	if t.SNMP.Version == "v3" {
+		if err := t.validateV3(); err != nil {
+			return err
+		}
+	}
+	for _, v := range t.Varbinds {
+		if err := v.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *Trap) validateV3() error {
+	if t.SNMP.SecurityName == "" {
+		return fmt.Errorf("security name cannot be null for SNMPv3")
+	}
+	if t.SNMP.SecurityLevel == 0 {
+		t.SNMP.SecurityLevel = SnmpNoAuthNoPriv
+	}
+	if t.SNMP.SecurityLevel >= SnmpAuthNoPriv && t.SNMP.AuthPassPhrase == "" {
+		return fmt.Errorf("authentication pass phrase cannot be null for the chosen security level")
+	}
+	if t.SNMP.SecurityLevel == SnmpAuthPriv && t.SNMP.PrivPassPhrase == "" {
+		return fmt.Errorf("privacy pass phrase cannot be null for the chosen security level")
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestParseTrapVarbind(t *testing.T) {
+	v, err := ParseTrapVarbind(".1.3.6.1.2.1.2.2.1.1.1=integer:1")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, TrapVarbind{OID: ".1.3.6.1.2.1.2.2.1.1.1", Type: "integer", Value: "1"}, v)
+
+	v, err = ParseTrapVarbind(".1.3.6.1.2.1.1.5.0=srv01:eth0")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, TrapVarbind{OID: ".1.3.6.1.2.1.1.5.0", Type: "string", Value: "srv01:eth0"}, v)
+
+	v, err = ParseTrapVarbind("1.3.6.1.4.1.5813.20.1.1=ipaddress:10.0.0.1")
+	assert.NilError(t, err)
+	assert.Equal(t, "ipaddress", v.Type)
+
+	_, err = ParseTrapVarbind(".1.3.6.1.2.1.1.5.0")
+	assert.Error(t, err, "invalid varbind .1.3.6.1.2.1.1.5.0, expected oid=type:value")
+
+	_, err = ParseTrapVarbind("sysName=srv01")
+	assert.Error(t, err, "invalid OID sysName")
+
+	_, err = ParseTrapVarbind(".1.3.6.1.2.1.2.2.1.1.1=counter32:-1")
+	assert.Error(t, err, "invalid counter32 value -1 for .1.3.6.1.2.1.2.2.1.1.1")
+
+	_, err = ParseTrapVarbind(".1.3.6.1.4.1.5813.20.1.1=ipaddress:10.0.0")
+	assert.Error(t, err, "invalid ipaddress value 10.0.0 for .1.3.6.1.4.1.5813.20.1.1")
+}
+
+func TestTrapValidate(t *testing.T) {
+	trap := Trap{SNMP: SnmpInfo{Community: "public"}, TrapOID: ".1.3.6.1.6.3.1.1.5.3"}
+	assert.NilError(t, trap.Validate())
+	assert.Equal(t, "v2c", trap.SNMP.Version)
+
+	trap = Trap{SNMP: SnmpInfo{Version: "v2c", Community: "public"}}
+	assert.Error(t, trap.Validate(), "a valid trap OID is required for SNMPv2c traps")
+
+	trap = Trap{SNMP: SnmpInfo{Version: "v1", Community: "public"}, Enterprise: ".1.3.6.1.4.1.5813", Generic: 6, Specific: 1}
+	assert.NilError(t, trap.Validate())
+	trap.Generic = 7
+	assert.Error(t, trap.Validate(), "invalid generic trap 7; allowed values: 0 to 6")
+
+	trap = Trap{SNMP: SnmpInfo{Version: "v3"}, TrapOID: ".1.3.6.1.6.3.1.1.5.3"}
+	assert.Error(t, trap.Validate(), "security name cannot be null for SNMPv3")
+	trap.SNMP.SecurityName = "opennms"
+	assert.NilError(t, trap.Validate())
+	assert.Equal(t, SnmpNoAuthNoPriv, trap.SNMP.SecurityLevel)
+	trap.SNMP.SecurityLevel = SnmpAuthPriv
+	trap.SNMP.AuthPassPhrase = "0p3nNMS!"
+	assert.Error(t, trap.Validate(), "privacy pass phrase cannot be null for the chosen security level")
+
+	trap = Trap{SNMP: SnmpInfo{Version: "v2c", Community: "public"}, TrapOID: ".1.3.6.1.6.3.1.1.5.3"}
+	trap.AddVarbind(".1.3.6.1.2.1.2.2.1.1.1", "integer", "one")
+	assert.Error(t, trap.Validate(), "invalid integer value one for .1.3.6.1.2.1.2.2.1.1.1")
+}
@@ -3,16 +3,25 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/OpenNMS/onmsctl/cli/alarms"
+	"github.com/OpenNMS/onmsctl/cli/apply"
+	"github.com/OpenNMS/onmsctl/cli/backup"
 	"github.com/OpenNMS/onmsctl/cli/daemon"
 	"github.com/OpenNMS/onmsctl/cli/events"
 	"github.com/OpenNMS/onmsctl/cli/info"
+	"github.com/OpenNMS/onmsctl/cli/locations"
+	"github.com/OpenNMS/onmsctl/cli/maintenance"
+	"github.com/OpenNMS/onmsctl/cli/minions"
 	"github.com/OpenNMS/onmsctl/cli/nodes"
+	"github.com/OpenNMS/onmsctl/cli/outages"
 	"github.com/OpenNMS/onmsctl/cli/profiles"
 	"github.com/OpenNMS/onmsctl/cli/provisioning"
 	"github.com/OpenNMS/onmsctl/cli/resources"
 	"github.com/OpenNMS/onmsctl/cli/search"
 	"github.com/OpenNMS/onmsctl/cli/snmp"
+	"github.com/OpenNMS/onmsctl/common"
 	"github.com/OpenNMS/onmsctl/rest"
 	"github.com/urfave/cli"
 )
@@ -74,6 +83,51 @@ func initCliFlags(app *cli.App) {
 			Destination: &rest.Instance.Insecure,
 			Usage:       "Skips HTTPS certificate validation (e.x. self-signed certificates)",
 		},
+		cli.IntFlag{
+			Name:        "retries",
+			Value:       rest.Instance.MaxRetries,
+			Destination: &rest.Instance.MaxRetries,
+			Usage:       "Maximum number of retries for transient errors (0 disables retries)",
+		},
+		cli.IntFlag{
+			Name:        "retry-backoff",
+			Value:       rest.Instance.RetryBackoff,
+			Destination: &rest.Instance.RetryBackoff,
+			Usage:       "Initial wait between retries in milliseconds, doubled on each retry",
+		},
+		cli.IntFlag{
+			Name:        "retry-max-backoff",
+			Value:       rest.Instance.RetryMaxBackoff,
+			Destination: &rest.Instance.RetryMaxBackoff,
+			Usage:       "Maximum wait between retries in milliseconds",
+		},
+		cli.GenericFlag{
+			Name:  "retry-codes",
+			Value: &rest.Instance.RetryStatusCodes,
+			Usage: "Comma-separated list of HTTP status codes to retry",
+		},
+		cli.BoolFlag{
+			Name:        "retry-post",
+			Destination: &rest.Instance.RetryPost,
+			Usage:       "Retry POST requests on transient errors (only safe when the server handles them idempotently)",
+		},
+		cli.IntFlag{
+			Name:        "breaker-threshold",
+			Value:       rest.Instance.BreakerThreshold,
+			Destination: &rest.Instance.BreakerThreshold,
+			Usage:       "Consecutive failures that open the circuit breaker, failing fast afterwards (0 enables it only with retries, using a threshold of 5)",
+		},
+		cli.IntFlag{
+			Name:        "breaker-cooldown",
+			Value:       rest.Instance.BreakerCooldown,
+			Destination: &rest.Instance.BreakerCooldown,
+			Usage:       "Seconds to wait before sending requests after the circuit breaker opens",
+		},
+		cli.GenericFlag{
+			Name:  "output, o",
+			Value: common.Output,
+			Usage: "Output format: " + strings.Join(common.OutputFormats, ", ") + " (defaults to table for lists and yaml for objects)",
+		},
 		cli.BoolFlag{
 			Name:        "debug, d",
 			Destination: &rest.Instance.Debug,
@@ -88,10 +142,18 @@ func initCliCommands(app *cli.App) {
 		provisioning.CliCommand,
 		nodes.CliCommand,
 		snmp.CliCommand,
+		locations.CliCommand,
+		minions.CliCommand,
 		events.CliCommand,
+		alarms.CliCommand,
+		outages.CliCommand,
+		maintenance.CliCommand,
 		daemon.CliCommand,
 		resources.CliCommand,
 		search.CliCommand,
 		profiles.CliCommand,
+		apply.CliCommand,
+		backup.CliCommand,
+		backup.RestoreCliCommand,
 	}
 }
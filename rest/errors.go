@@ -0,0 +1,82 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// Maximum number of characters from the response body used as the error message
+const maxErrorMessageLength = 512
+
+// HTTPError an unsuccessful response from the OpenNMS ReST API
+// Message contains the reason extracted from the body (either plain text or a JSON object with a message), when available.
+type HTTPError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Status     string
+	Body       string
+	Message    string
+}
+
+func (e *HTTPError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("Invalid Response: %s", e.Status)
+	}
+	return fmt.Sprintf("Invalid Response: %s: %s", e.Status, e.Message)
+}
+
+// HasStatus returns true if the error is an HTTPError with the given status code
+func HasStatus(err error, statusCode int) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == statusCode
+}
+
+// newHTTPError builds an HTTPError from a response, consuming and closing its body
+func newHTTPError(response *http.Response) *HTTPError {
+	httpErr := &HTTPError{
+		StatusCode: response.StatusCode,
+		Status:     response.Status,
+	}
+	if response.Request != nil {
+		httpErr.Method = response.Request.Method
+		httpErr.Path = response.Request.URL.RequestURI()
+	}
+	if response.Body != nil {
+		data, _ := ioutil.ReadAll(response.Body)
+		response.Body.Close()
+		httpErr.Body = strings.TrimSpace(string(data))
+	}
+	httpErr.Message = parseErrorMessage(response.Header.Get("Content-Type"), httpErr.Body)
+	return httpErr
+}
+
+// parseErrorMessage extracts the reason from an error response body
+// HTML content (e.x. the default error pages from Jetty) is ignored.
+func parseErrorMessage(contentType string, body string) string {
+	if body == "" || strings.Contains(contentType, "html") || strings.HasPrefix(body, "<") {
+		return ""
+	}
+	message := body
+	if strings.Contains(contentType, "json") || strings.HasPrefix(body, "{") {
+		fields := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(body), &fields); err == nil {
+			message = ""
+			for _, key := range []string{"message", "error", "reason", "errorMessage"} {
+				if value, ok := fields[key].(string); ok && value != "" {
+					message = value
+					break
+				}
+			}
+		}
+	}
+	message = strings.Join(strings.Fields(message), " ")
+	if len(message) > maxErrorMessageLength {
+		message = message[:maxErrorMessageLength] + "..."
+	}
+	return message
+}
@@ -0,0 +1,64 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func createErrorServer(status int, contentType string, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.Header().Set("Content-Type", contentType)
+		res.WriteHeader(status)
+		res.Write([]byte(body))
+	}))
+}
+
+func TestHTTPErrorWithPlainText(t *testing.T) {
+	server := createErrorServer(http.StatusConflict, "text/plain", "Requisition Test is being imported\n")
+	defer server.Close()
+
+	client := Client{URL: server.URL, Timeout: 5}
+	err := client.Delete("/rest/requisitions/Test/nodes/n1")
+	assert.Error(t, err, "Invalid Response: 409 Conflict: Requisition Test is being imported")
+
+	var httpErr *HTTPError
+	assert.Assert(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.MethodDelete, httpErr.Method)
+	assert.Equal(t, "/rest/requisitions/Test/nodes/n1", httpErr.Path)
+	assert.Equal(t, http.StatusConflict, httpErr.StatusCode)
+	assert.Assert(t, HasStatus(err, http.StatusConflict))
+	assert.Assert(t, !HasStatus(err, http.StatusNotFound))
+}
+
+func TestHTTPErrorWithJSON(t *testing.T) {
+	server := createErrorServer(http.StatusBadRequest, "application/json", `{"message":"Invalid node label"}`)
+	defer server.Close()
+
+	client := Client{URL: server.URL, Timeout: 5}
+	err := client.Post("/api/v2/nodes", []byte("{}"))
+	assert.Error(t, err, "Invalid Response: 400 Bad Request: Invalid node label")
+	assert.Assert(t, HasStatus(err, http.StatusBadRequest))
+}
+
+func TestHTTPErrorWithHTML(t *testing.T) {
+	server := createErrorServer(http.StatusNotFound, "text/html", "<html><body>Not Found</body></html>")
+	defer server.Close()
+
+	client := Client{URL: server.URL, Timeout: 5}
+	_, err := client.Get("/rest/requisitions/Unknown")
+	assert.Error(t, err, "Invalid Response: 404 Not Found")
+	assert.Assert(t, HasStatus(fmt.Errorf("wrapped: %w", err), http.StatusNotFound))
+}
+
+func TestParseErrorMessage(t *testing.T) {
+	assert.Equal(t, "", parseErrorMessage("text/plain", ""))
+	assert.Equal(t, "", parseErrorMessage("", "<html></html>"))
+	assert.Equal(t, "no such node", parseErrorMessage("", `{"error":"no such node"}`))
+	assert.Equal(t, "", parseErrorMessage("application/json", `{"code":10}`))
+	assert.Equal(t, "line one line two", parseErrorMessage("text/plain", "line one\n  line two"))
+}
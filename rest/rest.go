@@ -3,7 +3,6 @@ package rest
 import (
 	"bytes"
 	"crypto/tls"
-	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
@@ -14,20 +13,34 @@ import (
 
 // Instance a global reference to the ReST Client instance
 var Instance = Client{
-	URL:      "http://localhost:8980/opennms",
-	Username: "admin",
-	Password: "admin",
-	Timeout:  5,
+	URL:              "http://localhost:8980/opennms",
+	Username:         "admin",
+	Password:         "admin",
+	Timeout:          5,
+	RetryBackoff:     DefaultRetryBackoff,
+	RetryMaxBackoff:  DefaultRetryMaxBackoff,
+	RetryStatusCodes: append(StatusCodes{}, DefaultRetryStatusCodes...),
+	BreakerCooldown:  DefaultBreakerCooldown,
 }
 
 // Client OpenNMS ReST API configuration
+// MaxRetries is the number of retries after the first attempt for transient errors (0 disables them).
+// RetryBackoff and RetryMaxBackoff are expressed in milliseconds, and BreakerCooldown in seconds.
+// The circuit breaker is enabled when BreakerThreshold is set, or when retries are enabled (using DefaultBreakerThreshold).
 type Client struct {
-	URL      string `yaml:"url"`
-	Username string `yaml:"username"`
-	Password string `yaml:"password"`
-	Insecure bool   `yaml:"insecure"`
-	Timeout  int    `yaml:"timeout"`
-	Debug    bool   `yaml:"debug"`
+	URL              string      `yaml:"url"`
+	Username         string      `yaml:"username"`
+	Password         string      `yaml:"password"`
+	Insecure         bool        `yaml:"insecure"`
+	Timeout          int         `yaml:"timeout"`
+	Debug            bool        `yaml:"debug"`
+	MaxRetries       int         `yaml:"maxRetries"`
+	RetryBackoff     int         `yaml:"retryBackoff"`
+	RetryMaxBackoff  int         `yaml:"retryMaxBackoff"`
+	RetryStatusCodes StatusCodes `yaml:"retryStatusCodes"`
+	RetryPost        bool        `yaml:"retryPost"`
+	BreakerThreshold int         `yaml:"breakerThreshold"`
+	BreakerCooldown  int         `yaml:"breakerCooldown"`
 }
 
 func (cli Client) getHTTPClient() *http.Client {
@@ -43,11 +56,7 @@ func (cli Client) Get(path string) ([]byte, error) {
 	if cli.Debug {
 		log.Printf("GET, Path: %s", cli.URL+path)
 	}
-	request, err := cli.buildRequest(http.MethodGet, cli.URL+path, nil)
-	if err != nil {
-		return nil, err
-	}
-	response, err := cli.getHTTPClient().Do(request)
+	response, err := cli.send(http.MethodGet, path, nil, "")
 	if err != nil {
 		return nil, err
 	}
@@ -69,6 +78,7 @@ func (cli Client) Post(path string, jsonBytes []byte) error {
 	if err != nil {
 		return err
 	}
+	defer response.Body.Close()
 	return cli.IsValid(response)
 }
 
@@ -77,12 +87,7 @@ func (cli Client) PostRaw(path string, dataBytes []byte, contentType string) (*h
 	if cli.Debug {
 		log.Printf("POST, Path: %s, Type: %s, Data: %s", cli.URL+path, contentType, string(dataBytes))
 	}
-	request, err := cli.buildRequest(http.MethodPost, cli.URL+path, bytes.NewBuffer(dataBytes))
-	if err != nil {
-		return nil, err
-	}
-	request.Header.Set("Content-Type", contentType)
-	return cli.getHTTPClient().Do(request)
+	return cli.send(http.MethodPost, path, dataBytes, contentType)
 }
 
 // Delete sends an HTTP DELETE request
@@ -90,14 +95,11 @@ func (cli Client) Delete(path string) error {
 	if cli.Debug {
 		log.Printf("DELETE, Path: %s", cli.URL+path)
 	}
-	request, err := cli.buildRequest(http.MethodDelete, cli.URL+path, nil)
-	if err != nil {
-		return err
-	}
-	response, err := cli.getHTTPClient().Do(request)
+	response, err := cli.send(http.MethodDelete, path, nil, "")
 	if err != nil {
 		return err
 	}
+	defer response.Body.Close()
 	return cli.IsValid(response)
 }
 
@@ -106,19 +108,31 @@ func (cli Client) Put(path string, dataBytes []byte, contentType string) error {
 	if cli.Debug {
 		log.Printf("PUT, Path: %s, Type: %s, Data: %s", cli.URL+path, contentType, string(dataBytes))
 	}
-	request, err := cli.buildRequest(http.MethodPut, cli.URL+path, bytes.NewBuffer(dataBytes))
+	response, err := cli.send(http.MethodPut, path, dataBytes, contentType)
 	if err != nil {
 		return err
 	}
-	request.Header.Set("Content-Type", contentType)
-	response, err := cli.getHTTPClient().Do(request)
+	defer response.Body.Close()
+	return cli.IsValid(response)
+}
+
+// sendOnce sends a single HTTP request; the body is rebuilt on each call, so the request can be retried
+func (cli Client) sendOnce(method string, path string, dataBytes []byte, contentType string) (*http.Response, error) {
+	var body io.Reader
+	if dataBytes != nil {
+		body = bytes.NewBuffer(dataBytes)
+	}
+	request, err := cli.buildRequest(method, cli.URL+path, body)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return cli.IsValid(response)
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+	return cli.getHTTPClient().Do(request)
 }
 
-// IsValid verifies HTTP response, return an error if it is not valid
+// IsValid verifies HTTP response, return an HTTPError if it is not valid (in which case, the body is closed)
 func (cli Client) IsValid(response *http.Response) error {
 	if cli.Debug {
 		log.Printf("Got response: %s", response.Status)
@@ -130,7 +144,7 @@ func (cli Client) IsValid(response *http.Response) error {
 		code == http.StatusCreated {
 		return nil
 	}
-	return fmt.Errorf("Invalid Response: %s", response.Status)
+	return newHTTPError(response)
 }
 
 func (cli Client) buildRequest(method, url string, body io.Reader) (*http.Request, error) {
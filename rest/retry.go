@@ -0,0 +1,240 @@
+package rest
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Default values for the retry policy and the circuit breaker, used when the settings are not initialized
+const (
+	DefaultRetryBackoff     = 500   // milliseconds
+	DefaultRetryMaxBackoff  = 10000 // milliseconds
+	DefaultBreakerThreshold = 5
+	DefaultBreakerCooldown  = 30 // seconds
+)
+
+// DefaultRetryStatusCodes the HTTP status codes considered transient by default (e.x. Jetty restarting behind a load balancer)
+var DefaultRetryStatusCodes = StatusCodes{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+
+// The function used to wait between retries (replaced by tests)
+var sleep = time.Sleep
+
+// StatusCodes a list of HTTP status codes that can be used as a CLI flag
+type StatusCodes []int
+
+// Set parses a comma-separated list of HTTP status codes
+func (codes *StatusCodes) Set(value string) error {
+	list := StatusCodes{}
+	for _, s := range strings.Split(value, ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		code, err := strconv.Atoi(s)
+		if err != nil || code < 100 || code > 599 {
+			return fmt.Errorf("invalid HTTP status code %s", s)
+		}
+		list = append(list, code)
+	}
+	*codes = list
+	return nil
+}
+
+func (codes *StatusCodes) String() string {
+	list := make([]string, len(*codes))
+	for i, code := range *codes {
+		list[i] = strconv.Itoa(code)
+	}
+	return strings.Join(list, ",")
+}
+
+// Contains returns true if the status code is part of the list
+func (codes StatusCodes) Contains(code int) bool {
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// circuitBreaker stops sending requests to a server after a given number of consecutive failures, until the cooldown expires
+// Once the cooldown expires, requests are allowed again, but a single failure opens the breaker until the next success.
+type circuitBreaker struct {
+	mutex     sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+// The breakers are global per server URL, as the Client is always used by value
+var breakers = struct {
+	sync.Mutex
+	byURL map[string]*circuitBreaker
+}{byURL: make(map[string]*circuitBreaker)}
+
+func getCircuitBreaker(url string) *circuitBreaker {
+	breakers.Lock()
+	defer breakers.Unlock()
+	cb, ok := breakers.byURL[url]
+	if !ok {
+		cb = &circuitBreaker{}
+		breakers.byURL[url] = cb
+	}
+	return cb
+}
+
+func (cb *circuitBreaker) allow() bool {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	return time.Now().After(cb.openUntil)
+}
+
+func (cb *circuitBreaker) success() {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	cb.failures = 0
+	cb.openUntil = time.Time{}
+}
+
+func (cb *circuitBreaker) failure(threshold int, cooldown time.Duration) {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	cb.failures++
+	if cb.failures >= threshold {
+		cb.openUntil = time.Now().Add(cooldown)
+	}
+}
+
+func (cli Client) retryStatusCodes() StatusCodes {
+	if cli.RetryStatusCodes == nil {
+		return DefaultRetryStatusCodes
+	}
+	return cli.RetryStatusCodes
+}
+
+// breakerEnabled verifies if the circuit breaker should track failures
+// Without retries, it is only enabled when configured explicitly, as long polling commands expect to survive transient failures.
+func (cli Client) breakerEnabled() bool {
+	return cli.BreakerThreshold > 0 || cli.MaxRetries > 0
+}
+
+func (cli Client) breakerThreshold() int {
+	if cli.BreakerThreshold <= 0 {
+		return DefaultBreakerThreshold
+	}
+	return cli.BreakerThreshold
+}
+
+func (cli Client) breakerCooldown() time.Duration {
+	if cli.BreakerCooldown <= 0 {
+		return DefaultBreakerCooldown * time.Second
+	}
+	return time.Duration(cli.BreakerCooldown) * time.Second
+}
+
+// backoff gets the time to wait before a given retry (starting at 1), using exponential backoff with jitter
+func (cli Client) backoff(retry int) time.Duration {
+	base := cli.RetryBackoff
+	if base <= 0 {
+		base = DefaultRetryBackoff
+	}
+	max := cli.RetryMaxBackoff
+	if max <= 0 {
+		max = DefaultRetryMaxBackoff
+	}
+	wait := base << uint(retry-1)
+	if wait > max || wait <= 0 {
+		wait = max
+	}
+	// Equal jitter: half of the wait is fixed, the other half is random
+	wait = wait/2 + rand.Intn(wait/2+1)
+	return time.Duration(wait) * time.Millisecond
+}
+
+// isRetryable verifies if a request can be sent again after a failed attempt
+// POST requests are not idempotent, so they are retried only when the connection couldn't be established, unless RetryPost is enabled.
+func (cli Client) isRetryable(method string, response *http.Response, err error) bool {
+	if method == http.MethodPost && !cli.RetryPost {
+		return err != nil && isDialError(err)
+	}
+	if err != nil {
+		return isNetworkError(err)
+	}
+	return cli.retryStatusCodes().Contains(response.StatusCode)
+}
+
+// isNetworkError verifies if an error is a transient network failure, like a timeout or a refused or reset connection
+// Certificate problems, invalid URLs, or canceled requests won't succeed when retried.
+func isNetworkError(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var certErr *tls.CertificateVerificationError
+	var recordErr tls.RecordHeaderError
+	var authorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	if errors.As(err, &certErr) || errors.As(err, &recordErr) || errors.As(err, &authorityErr) || errors.As(err, &hostnameErr) || errors.As(err, &invalidErr) {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return opErr.Op != "remote error" // TLS alerts sent by the server
+	}
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET)
+}
+
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// send sends an HTTP request, retrying transient failures according to the retry policy and the circuit breaker
+func (cli Client) send(method string, path string, dataBytes []byte, contentType string) (*http.Response, error) {
+	cb := getCircuitBreaker(cli.URL)
+	for attempt := 0; ; attempt++ {
+		if cli.breakerEnabled() && !cb.allow() {
+			return nil, fmt.Errorf("circuit breaker open: %s seems to be down, try again later", cli.URL)
+		}
+		response, err := cli.sendOnce(method, path, dataBytes, contentType)
+		if err != nil && !isNetworkError(err) {
+			return nil, err
+		}
+		transient := err != nil || cli.retryStatusCodes().Contains(response.StatusCode)
+		if !transient {
+			cb.success()
+			return response, nil
+		}
+		if cli.breakerEnabled() {
+			cb.failure(cli.breakerThreshold(), cli.breakerCooldown())
+		}
+		if attempt >= cli.MaxRetries || !cli.isRetryable(method, response, err) {
+			return response, err
+		}
+		if response != nil {
+			response.Body.Close()
+		}
+		wait := cli.backoff(attempt + 1)
+		if cli.Debug {
+			log.Printf("%s, Path: %s, retrying in %s (attempt %d of %d)", method, cli.URL+path, wait, attempt+2, cli.MaxRetries+1)
+		}
+		sleep(wait)
+	}
+}
@@ -0,0 +1,157 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func createFlakyServer(failures int32, status int) (*httptest.Server, *int32) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= failures {
+			res.WriteHeader(status)
+			return
+		}
+		res.WriteHeader(http.StatusOK)
+	}))
+	return server, &requests
+}
+
+func noSleep(t *testing.T) *[]time.Duration {
+	waits := make([]time.Duration, 0)
+	sleep = func(d time.Duration) {
+		waits = append(waits, d)
+	}
+	t.Cleanup(func() { sleep = time.Sleep })
+	return &waits
+}
+
+func TestRetryOnTransientErrors(t *testing.T) {
+	waits := noSleep(t)
+	server, requests := createFlakyServer(2, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	client := Client{URL: server.URL, Timeout: 5, MaxRetries: 3, RetryBackoff: 100}
+	_, err := client.Get("/test")
+	assert.NilError(t, err)
+	assert.Equal(t, int32(3), *requests)
+	assert.Equal(t, 2, len(*waits))
+	assert.Assert(t, (*waits)[0] >= 50*time.Millisecond && (*waits)[0] <= 100*time.Millisecond)
+	assert.Assert(t, (*waits)[1] >= 100*time.Millisecond && (*waits)[1] <= 200*time.Millisecond)
+}
+
+func TestRetryExhausted(t *testing.T) {
+	noSleep(t)
+	server, requests := createFlakyServer(10, http.StatusBadGateway)
+	defer server.Close()
+
+	client := Client{URL: server.URL, Timeout: 5, MaxRetries: 2, BreakerThreshold: 10}
+	err := client.Put("/test", []byte("data"), "text/plain")
+	assert.Error(t, err, "Invalid Response: 502 Bad Gateway")
+	assert.Equal(t, int32(3), *requests)
+}
+
+func TestNoRetryOnNonRetryableStatus(t *testing.T) {
+	noSleep(t)
+	server, requests := createFlakyServer(10, http.StatusInternalServerError)
+	defer server.Close()
+
+	client := Client{URL: server.URL, Timeout: 5, MaxRetries: 3}
+	err := client.Delete("/test")
+	assert.Error(t, err, "Invalid Response: 500 Internal Server Error")
+	assert.Equal(t, int32(1), *requests)
+}
+
+func TestRetryPost(t *testing.T) {
+	noSleep(t)
+	server, requests := createFlakyServer(1, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	client := Client{URL: server.URL, Timeout: 5, MaxRetries: 3}
+	err := client.Post("/test", []byte("{}"))
+	assert.Error(t, err, "Invalid Response: 503 Service Unavailable")
+	assert.Equal(t, int32(1), *requests)
+
+	client.RetryPost = true
+	err = client.Post("/test", []byte("{}"))
+	assert.NilError(t, err)
+	assert.Equal(t, int32(2), *requests)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	noSleep(t)
+	server, requests := createFlakyServer(100, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	client := Client{URL: server.URL, Timeout: 5, MaxRetries: 10, BreakerThreshold: 3, BreakerCooldown: 60}
+	_, err := client.Get("/test")
+	assert.ErrorContains(t, err, "circuit breaker open")
+	assert.Equal(t, int32(3), *requests)
+
+	_, err = client.Get("/test")
+	assert.ErrorContains(t, err, "circuit breaker open")
+	assert.Equal(t, int32(3), *requests)
+}
+
+func TestCircuitBreakerDisabledWithoutRetries(t *testing.T) {
+	noSleep(t)
+	server, requests := createFlakyServer(100, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	client := Client{URL: server.URL, Timeout: 5}
+	for i := 0; i < DefaultBreakerThreshold+2; i++ {
+		_, err := client.Get("/test")
+		assert.Assert(t, err == nil || !strings.Contains(err.Error(), "circuit breaker open"))
+	}
+	assert.Equal(t, int32(DefaultBreakerThreshold+2), *requests)
+}
+
+func TestRetryOnConnectionRefused(t *testing.T) {
+	waits := noSleep(t)
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {}))
+	server.Close()
+
+	client := Client{URL: server.URL, Timeout: 5, MaxRetries: 2, BreakerThreshold: 10}
+	_, err := client.Get("/test")
+	assert.ErrorContains(t, err, "connection refused")
+	assert.Equal(t, 2, len(*waits))
+}
+
+func TestNoRetryOnPermanentErrors(t *testing.T) {
+	waits := noSleep(t)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {}))
+	defer server.Close()
+
+	// The certificate of the test server is not trusted, and the breaker must not count the failures
+	client := Client{URL: server.URL, Timeout: 5, MaxRetries: 3, BreakerThreshold: 1}
+	for i := 0; i < 2; i++ {
+		_, err := client.Get("/test")
+		assert.ErrorContains(t, err, "certificate")
+	}
+	assert.Equal(t, 0, len(*waits))
+
+	_, err := Client{URL: "http://[::1", Timeout: 5, MaxRetries: 3}.Get("/test")
+	assert.Assert(t, err != nil)
+	assert.Equal(t, 0, len(*waits))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Assert(t, !isNetworkError(&url.Error{Op: "Get", URL: server.URL, Err: ctx.Err()}))
+}
+
+func TestStatusCodes(t *testing.T) {
+	codes := StatusCodes{}
+	assert.NilError(t, codes.Set("502, 503"))
+	assert.Equal(t, "502,503", codes.String())
+	assert.Assert(t, codes.Contains(503))
+	assert.Assert(t, !codes.Contains(500))
+	assert.ErrorContains(t, codes.Set("50x"), "invalid HTTP status code")
+}
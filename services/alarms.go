@@ -0,0 +1,120 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/OpenNMS/onmsctl/api"
+	"github.com/OpenNMS/onmsctl/model"
+)
+
+// The form parameters used by the ReST API for each alarm action
+var alarmActionParams = map[string]url.Values{
+	"ack":          {"ack": {"true"}},
+	"unack":        {"ack": {"false"}},
+	"escalate":     {"escalate": {"true"}},
+	"clear":        {"clear": {"true"}},
+	"ticketCreate": {"ticketCreate": {"true"}},
+	"ticketUpdate": {"ticketUpdate": {"true"}},
+	"ticketClose":  {"ticketClose": {"true"}},
+}
+
+// The ReST API path for each type of memo
+var alarmMemoPaths = map[string]string{
+	"sticky":  "memo",
+	"journal": "journal",
+}
+
+type alarmsAPI struct {
+	rest api.RestAPI
+}
+
+// GetAlarmsAPI Obtain an implementation of the Alarms API
+func GetAlarmsAPI(rest api.RestAPI) api.AlarmsAPI {
+	return &alarmsAPI{rest}
+}
+
+// GetAlarms gets the alarms matching a FIQL filter, sorted by ID in descending order (a limit of 0 means all of them)
+func (api alarmsAPI) GetAlarms(fiqlFilter string, limit int) (*model.OnmsAlarmList, error) {
+	path := fmt.Sprintf("/api/v2/alarms?limit=%d&orderBy=id&order=desc", limit)
+	if fiqlFilter != "" {
+		path += "&_s=" + url.QueryEscape(fiqlFilter)
+	}
+	bytes, err := api.rest.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	list := &model.OnmsAlarmList{}
+	if len(bytes) > 0 {
+		if err = json.Unmarshal(bytes, list); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+func (api alarmsAPI) GetAlarm(id int) (*model.OnmsAlarm, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("valid alarm ID required")
+	}
+	bytes, err := api.rest.Get("/api/v2/alarms/" + strconv.Itoa(id))
+	if err != nil {
+		return nil, describeError(err, alarmErrors(id))
+	}
+	alarm := &model.OnmsAlarm{}
+	if err = json.Unmarshal(bytes, alarm); err != nil {
+		return nil, err
+	}
+	return alarm, nil
+}
+
+func (api alarmsAPI) UpdateAlarm(id int, action string) error {
+	if id <= 0 {
+		return fmt.Errorf("valid alarm ID required")
+	}
+	params, ok := alarmActionParams[action]
+	if !ok {
+		return fmt.Errorf("invalid action %s. Allowed values: %s", action, model.AlarmActions.EnumAsString())
+	}
+	err := api.rest.Put("/api/v2/alarms/"+strconv.Itoa(id), []byte(params.Encode()), "application/x-www-form-urlencoded")
+	return describeError(err, alarmErrors(id))
+}
+
+func (api alarmsAPI) SetMemo(id int, memoType string, body string) error {
+	path, err := api.getMemoPath(id, memoType)
+	if err != nil {
+		return err
+	}
+	if body == "" {
+		return fmt.Errorf("memo body required")
+	}
+	data := url.Values{"body": {body}}
+	err = api.rest.Put(path, []byte(data.Encode()), "application/x-www-form-urlencoded")
+	return describeError(err, alarmErrors(id))
+}
+
+func (api alarmsAPI) DeleteMemo(id int, memoType string) error {
+	path, err := api.getMemoPath(id, memoType)
+	if err != nil {
+		return err
+	}
+	return describeError(api.rest.Delete(path), alarmErrors(id))
+}
+
+func (api alarmsAPI) getMemoPath(id int, memoType string) (string, error) {
+	if id <= 0 {
+		return "", fmt.Errorf("valid alarm ID required")
+	}
+	memo, ok := alarmMemoPaths[memoType]
+	if !ok {
+		return "", fmt.Errorf("invalid memo type %s. Allowed values: %s", memoType, model.AlarmMemoTypes.EnumAsString())
+	}
+	return "/rest/alarms/" + strconv.Itoa(id) + "/" + memo, nil
+}
+
+func alarmErrors(id int) map[int]string {
+	return map[int]string{http.StatusNotFound: fmt.Sprintf("alarm %d not found", id)}
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/OpenNMS/onmsctl/rest"
+	"gotest.tools/assert"
+)
+
+type mockAlarmsRest struct {
+	t *testing.T
+}
+
+func (api mockAlarmsRest) Get(path string) ([]byte, error) {
+	switch path {
+	case "/api/v2/alarms?limit=0&orderBy=id&order=desc&_s=alarm.severity%3D%3DMAJOR":
+		return []byte(`{"count":1,"totalCount":1,"offset":0,"alarm":[{"id":1,"uei":"uei.opennms.org/nodes/nodeDown","severity":"MAJOR","count":2,"stickyMemo":{"body":"Working on it"}}]}`), nil
+	case "/api/v2/alarms?limit=10&orderBy=id&order=desc":
+		return []byte{}, nil
+	case "/api/v2/alarms/1":
+		return []byte(`{"id":1,"uei":"uei.opennms.org/nodes/nodeDown","severity":"MAJOR"}`), nil
+	case "/api/v2/alarms/2":
+		return nil, &rest.HTTPError{StatusCode: http.StatusNotFound, Status: "404 Not Found"}
+	}
+	return nil, fmt.Errorf("GET: should not be called with path %s", path)
+}
+
+func (api mockAlarmsRest) Post(path string, jsonBytes []byte) error {
+	return fmt.Errorf("should not be called")
+}
+
+func (api mockAlarmsRest) PostRaw(path string, dataBytes []byte, contentType string) (*http.Response, error) {
+	return nil, fmt.Errorf("should not be called")
+}
+
+func (api mockAlarmsRest) Delete(path string) error {
+	if path == "/rest/alarms/1/journal" {
+		return nil
+	}
+	return fmt.Errorf("DELETE: should not be called with path %s", path)
+}
+
+func (api mockAlarmsRest) Put(path string, dataBytes []byte, contentType string) error {
+	assert.Equal(api.t, "application/x-www-form-urlencoded", contentType)
+	switch path {
+	case "/api/v2/alarms/1":
+		assert.Equal(api.t, "ack=false", string(dataBytes))
+		return nil
+	case "/rest/alarms/1/memo":
+		assert.Equal(api.t, "body=Working+on+it", string(dataBytes))
+		return nil
+	}
+	return fmt.Errorf("PUT: should not be called with path %s", path)
+}
+
+func (api mockAlarmsRest) IsValid(r *http.Response) error {
+	return nil
+}
+
+func TestGetAlarms(t *testing.T) {
+	api := GetAlarmsAPI(&mockAlarmsRest{t})
+
+	list, err := api.GetAlarms("alarm.severity==MAJOR", 0)
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(list.Alarms))
+	assert.Equal(t, "Working on it", list.Alarms[0].StickyMemo.Body)
+
+	list, err = api.GetAlarms("", 10)
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(list.Alarms))
+}
+
+func TestGetAlarm(t *testing.T) {
+	api := GetAlarmsAPI(&mockAlarmsRest{t})
+
+	_, err := api.GetAlarm(0)
+	assert.Error(t, err, "valid alarm ID required")
+
+	alarm, err := api.GetAlarm(1)
+	assert.NilError(t, err)
+	assert.Equal(t, "MAJOR", alarm.Severity)
+
+	_, err = api.GetAlarm(2)
+	assert.ErrorContains(t, err, "alarm 2 not found")
+}
+
+func TestUpdateAlarm(t *testing.T) {
+	api := GetAlarmsAPI(&mockAlarmsRest{t})
+
+	err := api.UpdateAlarm(1, "purge")
+	assert.ErrorContains(t, err, "invalid action purge")
+
+	err = api.UpdateAlarm(1, "unack")
+	assert.NilError(t, err)
+}
+
+func TestAlarmMemos(t *testing.T) {
+	api := GetAlarmsAPI(&mockAlarmsRest{t})
+
+	err := api.SetMemo(1, "sticky", "")
+	assert.Error(t, err, "memo body required")
+
+	err = api.SetMemo(1, "note", "Working on it")
+	assert.ErrorContains(t, err, "invalid memo type note")
+
+	err = api.SetMemo(1, "sticky", "Working on it")
+	assert.NilError(t, err)
+
+	err = api.DeleteMemo(1, "journal")
+	assert.NilError(t, err)
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/OpenNMS/onmsctl/api"
+	"github.com/OpenNMS/onmsctl/model"
+)
+
+type daemonsAPI struct {
+	rest api.RestAPI
+}
+
+// GetDaemonsAPI Obtain an implementation of the Daemons API
+func GetDaemonsAPI(rest api.RestAPI) api.DaemonsAPI {
+	return &daemonsAPI{rest}
+}
+
+// GetDaemons gets the daemons known by the server; servers without the daemons endpoint return an HTTP 404 error
+func (api daemonsAPI) GetDaemons() ([]model.OnmsDaemon, error) {
+	jsonBytes, err := api.rest.Get("/api/v2/daemons")
+	if err != nil {
+		return nil, err
+	}
+	daemons := make([]model.OnmsDaemon, 0)
+	if len(jsonBytes) == 0 {
+		return daemons, nil
+	}
+	if err := json.Unmarshal(jsonBytes, &daemons); err != nil {
+		return nil, err
+	}
+	return daemons, nil
+}
+
+// GetDaemonStatus gets the runtime status of a daemon; servers without the status endpoint return an HTTP error
+func (api daemonsAPI) GetDaemonStatus(name string) (*model.OnmsDaemonStatus, error) {
+	jsonBytes, err := api.rest.Get("/api/v2/daemons/" + url.PathEscape(name) + "/status")
+	if err != nil {
+		return nil, err
+	}
+	status := &model.OnmsDaemonStatus{}
+	if err := json.Unmarshal(jsonBytes, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// GetHealth gets the result of the health checks of the server
+func (api daemonsAPI) GetHealth() (*model.OnmsHealth, error) {
+	jsonBytes, err := api.rest.Get("/rest/health")
+	if err != nil {
+		return nil, err
+	}
+	health := &model.OnmsHealth{}
+	if err := json.Unmarshal(jsonBytes, health); err != nil {
+		return nil, err
+	}
+	return health, nil
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/OpenNMS/onmsctl/model"
+	"gotest.tools/assert"
+)
+
+type mockDaemonsRest struct{}
+
+func (api mockDaemonsRest) Get(path string) ([]byte, error) {
+	switch path {
+	case "/api/v2/daemons":
+		return json.Marshal([]model.OnmsDaemon{
+			{Name: "Pollerd", Enabled: true, Reloadable: true},
+			{Name: "Eventd", Internal: true, Enabled: true},
+		})
+	case "/api/v2/daemons/DroolsCorrelationEngine:My%20Engine/status":
+		return []byte(`{"name":"DroolsCorrelationEngine:My Engine","status":"RUNNING"}`), nil
+	case "/rest/health":
+		return []byte(`{"healthy":false,"responses":[{"description":"Connecting to ActiveMQ","status":"Failure","message":"timeout"}]}`), nil
+	}
+	return nil, fmt.Errorf("should not be called")
+}
+
+func (api mockDaemonsRest) Post(path string, jsonBytes []byte) error {
+	return fmt.Errorf("should not be called")
+}
+
+func (api mockDaemonsRest) PostRaw(path string, dataBytes []byte, contentType string) (*http.Response, error) {
+	return nil, fmt.Errorf("should not be called")
+}
+
+func (api mockDaemonsRest) Delete(path string) error {
+	return fmt.Errorf("should not be called")
+}
+
+func (api mockDaemonsRest) Put(path string, dataBytes []byte, contentType string) error {
+	return fmt.Errorf("should not be called")
+}
+
+func (api mockDaemonsRest) IsValid(r *http.Response) error {
+	return nil
+}
+
+func TestGetDaemons(t *testing.T) {
+	api := GetDaemonsAPI(&mockDaemonsRest{})
+	daemons, err := api.GetDaemons()
+	assert.NilError(t, err)
+	assert.Equal(t, 2, len(daemons))
+	assert.Equal(t, "Pollerd", daemons[0].Name)
+	assert.Equal(t, true, daemons[0].Reloadable)
+	assert.Equal(t, true, daemons[1].Internal)
+	assert.Equal(t, false, daemons[1].Reloadable)
+}
+
+func TestGetDaemonStatusAndHealth(t *testing.T) {
+	api := GetDaemonsAPI(&mockDaemonsRest{})
+	status, err := api.GetDaemonStatus("DroolsCorrelationEngine:My Engine")
+	assert.NilError(t, err)
+	assert.Equal(t, "RUNNING", status.Status)
+
+	health, err := api.GetHealth()
+	assert.NilError(t, err)
+	assert.Equal(t, false, health.Healthy)
+	assert.Equal(t, "Failure", health.Responses[0].Status)
+}
@@ -0,0 +1,41 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/OpenNMS/onmsctl/rest"
+)
+
+// describeError replaces well known HTTP errors with a targeted message
+// The messages are indexed by HTTP status code; the original error is wrapped, so errors.As still works.
+func describeError(err error, messages map[int]string) error {
+	var httpErr *rest.HTTPError
+	if !errors.As(err, &httpErr) {
+		return err
+	}
+	if msg, ok := messages[httpErr.StatusCode]; ok {
+		return fmt.Errorf("%s (%w)", msg, err)
+	}
+	return err
+}
+
+// requisitionErrors the messages for errors on a requisition or any of its elements
+func requisitionErrors(foreignSource string, element string) map[int]string {
+	messages := map[int]string{
+		http.StatusConflict: fmt.Sprintf("requisition %s is locked by a running import, try again later", foreignSource),
+	}
+	if element != "" {
+		messages[http.StatusNotFound] = fmt.Sprintf("%s not found on requisition %s", element, foreignSource)
+	}
+	return messages
+}
+
+// nodeErrors the messages for errors on an inventory node or any of its elements
+func nodeErrors(nodeCriteria string, element string) map[int]string {
+	if element == "" {
+		return map[int]string{http.StatusNotFound: fmt.Sprintf("node %s not found", nodeCriteria)}
+	}
+	return map[int]string{http.StatusNotFound: fmt.Sprintf("%s not found on node %s", element, nodeCriteria)}
+}
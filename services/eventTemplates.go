@@ -0,0 +1,95 @@
+package services
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/OpenNMS/onmsctl/api"
+	"github.com/OpenNMS/onmsctl/model"
+)
+
+type eventTemplatesAPI struct {
+	directory string
+}
+
+// GetEventTemplatesAPI Obtain an implementation of the Event Templates API, using the templates stored on a given directory
+func GetEventTemplatesAPI(directory string) api.EventTemplatesAPI {
+	return &eventTemplatesAPI{directory}
+}
+
+// GetEventTemplatesDir gets the default directory for the event templates, next to the configuration file
+func GetEventTemplatesDir() string {
+	return filepath.Join(filepath.Dir(getConfigFile()), "event-templates")
+}
+
+// GetTemplates gets the built-in templates and the templates from the directory, sorted by name
+// A template from the directory replaces a built-in template with the same name.
+func (api eventTemplatesAPI) GetTemplates() ([]model.EventTemplate, error) {
+	templates := make(map[string]model.EventTemplate)
+	for name, content := range model.BuiltInEventTemplates {
+		templates[name] = model.NewEventTemplate(name, model.BuiltInEventTemplateSource, content)
+	}
+	files, err := ioutil.ReadDir(api.directory)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, file := range files {
+		name := getTemplateName(file.Name())
+		if file.IsDir() || name == "" {
+			continue
+		}
+		t, err := api.readTemplate(name, filepath.Join(api.directory, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		templates[name] = *t
+	}
+	list := make([]model.EventTemplate, 0, len(templates))
+	for _, t := range templates {
+		list = append(list, t)
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list, nil
+}
+
+// GetTemplate gets a template by name, from the directory or the built-in templates
+func (api eventTemplatesAPI) GetTemplate(name string) (*model.EventTemplate, error) {
+	if name == "" {
+		return nil, fmt.Errorf("template name required")
+	}
+	for _, ext := range []string{".yaml", ".yml"} {
+		path := filepath.Join(api.directory, name+ext)
+		if fileExists(path) {
+			return api.readTemplate(name, path)
+		}
+	}
+	if content, ok := model.BuiltInEventTemplates[name]; ok {
+		t := model.NewEventTemplate(name, model.BuiltInEventTemplateSource, content)
+		return &t, nil
+	}
+	return nil, fmt.Errorf("template %s not found", name)
+}
+
+func (api eventTemplatesAPI) readTemplate(name string, path string) (*model.EventTemplate, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	t := model.NewEventTemplate(name, path, string(data))
+	return &t, nil
+}
+
+// getTemplateName gets the name of a template from its file name, or an empty string when it is not a YAML file
+func getTemplateName(fileName string) string {
+	ext := filepath.Ext(fileName)
+	if ext != ".yaml" && ext != ".yml" {
+		return ""
+	}
+	return strings.TrimSuffix(fileName, ext)
+}
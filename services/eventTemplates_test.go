@@ -0,0 +1,51 @@
+package services
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestGetEventTemplatesDir(t *testing.T) {
+	os.Setenv("ONMSCONFIG", "/opt/opennms/etc/onmsctl.yaml")
+	defer os.Unsetenv("ONMSCONFIG")
+	assert.Equal(t, "/opt/opennms/etc/event-templates", GetEventTemplatesDir())
+}
+
+func TestEventTemplates(t *testing.T) {
+	dir, err := ioutil.TempDir("", "event-templates")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "disk-full.yaml"), []byte("# Disk full\nuei: uei.opennms.org/custom/diskFull\n"), 0644))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "forceRescan.yml"), []byte("uei: uei.opennms.org/custom/rescan\n"), 0644))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte("Not a template"), 0644))
+
+	api := GetEventTemplatesAPI(dir)
+	templates, err := api.GetTemplates()
+	assert.NilError(t, err)
+	names := make([]string, 0)
+	for _, tmpl := range templates {
+		names = append(names, tmpl.Name)
+	}
+	assert.DeepEqual(t, []string{"disk-full", "forceRescan", "nodeAdded", "reloadDaemonConfig"}, names)
+	assert.Equal(t, "Disk full", templates[0].Description)
+	assert.Equal(t, filepath.Join(dir, "forceRescan.yml"), templates[1].Source)
+
+	tmpl, err := api.GetTemplate("forceRescan")
+	assert.NilError(t, err)
+	assert.Equal(t, "uei: uei.opennms.org/custom/rescan\n", tmpl.Content)
+
+	tmpl, err = api.GetTemplate("nodeAdded")
+	assert.NilError(t, err)
+	assert.Equal(t, "built-in", tmpl.Source)
+
+	_, err = api.GetTemplate("unknown")
+	assert.Error(t, err, "template unknown not found")
+
+	templates, err = GetEventTemplatesAPI(filepath.Join(dir, "missing")).GetTemplates()
+	assert.NilError(t, err)
+	assert.Equal(t, 3, len(templates))
+}
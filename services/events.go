@@ -2,6 +2,8 @@ package services
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/url"
 
 	"github.com/OpenNMS/onmsctl/api"
 	"github.com/OpenNMS/onmsctl/model"
@@ -26,3 +28,22 @@ func (api eventsAPI) SendEvent(event model.Event) error {
 	}
 	return api.rest.Post("/rest/events", jsonBytes)
 }
+
+// GetEvents gets the most recent events matching a FIQL filter, sorted by ID in descending order
+func (api eventsAPI) GetEvents(fiqlFilter string, limit int) (*model.OnmsEventList, error) {
+	path := fmt.Sprintf("/api/v2/events?limit=%d&orderBy=id&order=desc", limit)
+	if fiqlFilter != "" {
+		path += "&_s=" + url.QueryEscape(fiqlFilter)
+	}
+	bytes, err := api.rest.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	list := &model.OnmsEventList{}
+	if len(bytes) > 0 {
+		if err = json.Unmarshal(bytes, list); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/OpenNMS/onmsctl/api"
+	"github.com/OpenNMS/onmsctl/model"
+)
+
+// DefaultEventdPort the default port of the Eventd TCP listener
+const DefaultEventdPort = 5817
+
+type eventsTCPAPI struct {
+	address string
+	timeout time.Duration
+}
+
+// GetEventsTCPAPI Obtain an implementation of the Events API that sends events to the Eventd TCP listener (host:port)
+// The timeout applies to the whole exchange (0 means no timeout).
+// Only sending events is supported, as the TCP listener doesn't provide a way to query events.
+func GetEventsTCPAPI(address string, timeout time.Duration) api.EventsAPI {
+	return &eventsTCPAPI{address, timeout}
+}
+
+// SendEvent sends an event as an XML log, and waits for the receipt with the UUID of the event
+func (api eventsTCPAPI) SendEvent(event model.Event) error {
+	if err := event.Validate(); err != nil {
+		return err
+	}
+	uuid, err := newUUID()
+	if err != nil {
+		return err
+	}
+	event.UUID = uuid
+	data, err := xml.Marshal(model.EventLog{Events: []model.Event{event}})
+	if err != nil {
+		return err
+	}
+	conn, err := net.DialTimeout("tcp", api.address, api.timeout)
+	if err != nil {
+		return fmt.Errorf("cannot connect to eventd: %v", err)
+	}
+	defer conn.Close()
+	if api.timeout > 0 {
+		if err = conn.SetDeadline(time.Now().Add(api.timeout)); err != nil {
+			return err
+		}
+	}
+	if _, err = conn.Write(append([]byte(xml.Header), data...)); err != nil {
+		return fmt.Errorf("cannot send event to eventd: %v", err)
+	}
+	receipt := &model.EventReceipt{}
+	if err = xml.NewDecoder(conn).Decode(receipt); err != nil {
+		if err == io.EOF {
+			return fmt.Errorf("eventd closed the connection without sending a receipt")
+		}
+		return fmt.Errorf("cannot read receipt from eventd: %v", err)
+	}
+	for _, id := range receipt.UUIDs {
+		if id == uuid {
+			return nil
+		}
+	}
+	return fmt.Errorf("eventd didn't acknowledge event %s", uuid)
+}
+
+func (api eventsTCPAPI) GetEvents(fiqlFilter string, limit int) (*model.OnmsEventList, error) {
+	return nil, fmt.Errorf("getting events is not supported by the TCP transport")
+}
+
+// newUUID generates a random (version 4) UUID
+func newUUID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"encoding/xml"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/OpenNMS/onmsctl/model"
+
+	"gotest.tools/assert"
+)
+
+// startEventdStandIn starts a TCP listener that mimics Eventd, passing the received events to the reply function
+// The reply function returns the UUIDs to acknowledge, or nil to close the connection without a receipt.
+func startEventdStandIn(t *testing.T, reply func(log *model.EventLog) []string) net.Listener {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			log := &model.EventLog{}
+			if err := xml.NewDecoder(conn).Decode(log); err == nil {
+				if uuids := reply(log); uuids != nil {
+					data, _ := xml.Marshal(model.EventReceipt{UUIDs: uuids})
+					conn.Write(data)
+				}
+			}
+			conn.Close()
+		}
+	}()
+	return listener
+}
+
+func TestSendEventOverTCP(t *testing.T) {
+	listener := startEventdStandIn(t, func(log *model.EventLog) []string {
+		assert.Equal(t, 1, len(log.Events))
+		event := log.Events[0]
+		assert.Assert(t, event.UUID != "")
+		assert.Equal(t, mockEvent.UEI, event.UEI)
+		assert.DeepEqual(t, mockEvent.Parameters, event.Parameters)
+		return []string{event.UUID}
+	})
+	defer listener.Close()
+
+	api := GetEventsTCPAPI(listener.Addr().String(), time.Second)
+	err := api.SendEvent(*mockEvent)
+	assert.NilError(t, err)
+
+	err = api.SendEvent(model.Event{Source: "onmsctl"})
+	assert.Error(t, err, "UEI cannot be null")
+
+	_, err = api.GetEvents("", 10)
+	assert.Error(t, err, "getting events is not supported by the TCP transport")
+}
+
+func TestSendEventOverTCPWithoutReceipt(t *testing.T) {
+	listener := startEventdStandIn(t, func(log *model.EventLog) []string {
+		if log.Events[0].UEI == "uei.opennms.org/unknown" {
+			return []string{"00000000-0000-4000-8000-000000000000"}
+		}
+		return nil
+	})
+	defer listener.Close()
+
+	api := GetEventsTCPAPI(listener.Addr().String(), time.Second)
+	err := api.SendEvent(*mockEvent)
+	assert.Error(t, err, "eventd closed the connection without sending a receipt")
+
+	err = api.SendEvent(model.Event{UEI: "uei.opennms.org/unknown"})
+	assert.ErrorContains(t, err, "eventd didn't acknowledge event")
+}
+
+func TestNewUUID(t *testing.T) {
+	uuid, err := newUUID()
+	assert.NilError(t, err)
+	assert.Equal(t, 36, len(uuid))
+	assert.Equal(t, byte('4'), uuid[14])
+}
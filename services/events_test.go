@@ -29,6 +29,13 @@ type mockEventRest struct {
 }
 
 func (api mockEventRest) Get(path string) ([]byte, error) {
+	if path == "/api/v2/events?limit=1&orderBy=id&order=desc" {
+		return json.Marshal(model.OnmsEventList{Count: 1, Events: []model.OnmsEvent{{ID: 100}}})
+	}
+	if strings.HasPrefix(path, "/api/v2/events?limit=10&orderBy=id&order=desc&_s=") {
+		assert.Equal(api.t, "eventUei%3D%3Duei.opennms.org%2Ftest%3Bid%3Dgt%3D100", strings.TrimPrefix(path, "/api/v2/events?limit=10&orderBy=id&order=desc&_s="))
+		return []byte{}, nil
+	}
 	return nil, fmt.Errorf("should not be called")
 }
 
@@ -65,3 +72,16 @@ func TestSendEvent(t *testing.T) {
 	err = api.SendEvent(model.Event{NodeID: 10})
 	assert.ErrorContains(t, err, "UEI")
 }
+
+func TestGetEvents(t *testing.T) {
+	api := GetEventsAPI(&mockEventRest{t})
+
+	list, err := api.GetEvents("", 1)
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(list.Events))
+	assert.Equal(t, 100, list.Events[0].ID)
+
+	list, err = api.GetEvents("eventUei==uei.opennms.org/test;id=gt=100", 10)
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(list.Events))
+}
@@ -28,7 +28,7 @@ func (api foreignSourcesAPI) GetForeignSourceDef(foreignSource string) (*model.F
 	fsDef := &model.ForeignSourceDef{}
 	jsonBytes, err := api.rest.Get("/rest/foreignSources/" + foreignSource)
 	if err != nil {
-		return nil, fmt.Errorf("cannot retrieve foreign source definition %s", foreignSource)
+		return nil, fmt.Errorf("cannot retrieve foreign source definition %s: %w", foreignSource, err)
 	}
 	if err := json.Unmarshal(jsonBytes, fsDef); err != nil {
 		return nil, err
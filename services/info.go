@@ -0,0 +1,29 @@
+package services
+
+import (
+	"encoding/json"
+
+	"github.com/OpenNMS/onmsctl/api"
+	"github.com/OpenNMS/onmsctl/model"
+)
+
+type infoAPI struct {
+	rest api.RestAPI
+}
+
+// GetInfoAPI Obtain an implementation of the Info API
+func GetInfoAPI(rest api.RestAPI) api.InfoAPI {
+	return &infoAPI{rest}
+}
+
+func (api infoAPI) GetInfo() (*model.OnmsInfo, error) {
+	jsonInfo, err := api.rest.Get("/rest/info")
+	if err != nil {
+		return nil, err
+	}
+	info := &model.OnmsInfo{}
+	if err := json.Unmarshal(jsonInfo, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/OpenNMS/onmsctl/model"
+	"gotest.tools/assert"
+)
+
+type mockInfoRest struct{}
+
+func (api mockInfoRest) Get(path string) ([]byte, error) {
+	if path == "/rest/info" {
+		return json.Marshal(model.OnmsInfo{DisplayVersion: "28.1.1", Version: "28.1.1", PackageName: "opennms"})
+	}
+	return nil, fmt.Errorf("should not be called")
+}
+
+func (api mockInfoRest) Post(path string, jsonBytes []byte) error {
+	return fmt.Errorf("should not be called")
+}
+
+func (api mockInfoRest) PostRaw(path string, dataBytes []byte, contentType string) (*http.Response, error) {
+	return nil, fmt.Errorf("should not be called")
+}
+
+func (api mockInfoRest) Delete(path string) error {
+	return fmt.Errorf("should not be called")
+}
+
+func (api mockInfoRest) Put(path string, dataBytes []byte, contentType string) error {
+	return fmt.Errorf("should not be called")
+}
+
+func (api mockInfoRest) IsValid(r *http.Response) error {
+	return nil
+}
+
+func TestGetInfo(t *testing.T) {
+	api := GetInfoAPI(&mockInfoRest{})
+	info, err := api.GetInfo()
+	assert.NilError(t, err)
+	assert.Equal(t, "28.1.1", info.Version)
+	assert.Equal(t, "opennms", info.PackageName)
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/OpenNMS/onmsctl/api"
+	"github.com/OpenNMS/onmsctl/model"
+	"github.com/OpenNMS/onmsctl/rest"
+)
+
+type minionsAPI struct {
+	rest api.RestAPI
+}
+
+// GetMinionsAPI Obtain an implementation of the Minions API
+func GetMinionsAPI(rest api.RestAPI) api.MinionsAPI {
+	return &minionsAPI{rest}
+}
+
+// GetMinions gets all the Minions, using the v1 ReST API when the server doesn't have the v2 endpoint
+func (api minionsAPI) GetMinions() (*model.OnmsMinionList, error) {
+	bytes, err := api.rest.Get("/api/v2/minions?limit=0")
+	if rest.HasStatus(err, http.StatusNotFound) {
+		bytes, err = api.rest.Get("/rest/minions?limit=0")
+	}
+	if err != nil {
+		return nil, err
+	}
+	list := &model.OnmsMinionList{}
+	if len(bytes) > 0 {
+		if err = json.Unmarshal(bytes, list); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+func (api minionsAPI) GetMinion(id string) (*model.OnmsMinion, error) {
+	if id == "" {
+		return nil, fmt.Errorf("minion ID required")
+	}
+	bytes, err := api.rest.Get("/api/v2/minions/" + url.PathEscape(id))
+	if rest.HasStatus(err, http.StatusNotFound) {
+		bytes, err = api.rest.Get("/rest/minions/" + url.PathEscape(id))
+	}
+	if err != nil {
+		return nil, describeError(err, minionErrors(id))
+	}
+	minion := &model.OnmsMinion{}
+	if err = json.Unmarshal(bytes, minion); err != nil {
+		return nil, err
+	}
+	return minion, nil
+}
+
+func (api minionsAPI) DeleteMinion(id string) error {
+	if id == "" {
+		return fmt.Errorf("minion ID required")
+	}
+	err := api.rest.Delete("/api/v2/minions/" + url.PathEscape(id))
+	if rest.HasStatus(err, http.StatusNotFound) {
+		err = api.rest.Delete("/rest/minions/" + url.PathEscape(id))
+	}
+	return describeError(err, minionErrors(id))
+}
+
+func minionErrors(id string) map[int]string {
+	return map[int]string{http.StatusNotFound: fmt.Sprintf("minion %s not found", id)}
+}
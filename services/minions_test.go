@@ -0,0 +1,87 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/OpenNMS/onmsctl/model"
+	"github.com/OpenNMS/onmsctl/rest"
+	"gotest.tools/assert"
+)
+
+var notFoundError = &rest.HTTPError{StatusCode: http.StatusNotFound, Status: "404 Not Found"}
+
+// mockMinionsRest simulates an old server, where Minions are only available through the v1 ReST API
+type mockMinionsRest struct {
+	paths []string
+}
+
+func (api *mockMinionsRest) Get(path string) ([]byte, error) {
+	api.paths = append(api.paths, path)
+	switch path {
+	case "/rest/minions?limit=0":
+		return json.Marshal(model.OnmsMinionList{Count: 1, TotalCount: 1, Minions: []model.OnmsMinion{{ID: "minion-01", Location: "Apex", Status: "UP"}}})
+	case "/rest/minions/minion-01":
+		return json.Marshal(model.OnmsMinion{ID: "minion-01", Location: "Apex", Status: "UP"})
+	}
+	return nil, notFoundError
+}
+
+func (api *mockMinionsRest) Post(path string, jsonBytes []byte) error {
+	return fmt.Errorf("should not be called")
+}
+
+func (api *mockMinionsRest) PostRaw(path string, dataBytes []byte, contentType string) (*http.Response, error) {
+	return nil, fmt.Errorf("should not be called")
+}
+
+func (api *mockMinionsRest) Delete(path string) error {
+	api.paths = append(api.paths, path)
+	if path == "/api/v2/minions/minion-01" {
+		return nil
+	}
+	return notFoundError
+}
+
+func (api *mockMinionsRest) Put(path string, dataBytes []byte, contentType string) error {
+	return fmt.Errorf("should not be called")
+}
+
+func (api *mockMinionsRest) IsValid(r *http.Response) error {
+	return nil
+}
+
+func TestGetMinions(t *testing.T) {
+	mock := &mockMinionsRest{}
+	api := GetMinionsAPI(mock)
+	list, err := api.GetMinions()
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(list.Minions))
+	assert.Equal(t, "Apex", list.Minions[0].Location)
+	assert.DeepEqual(t, []string{"/api/v2/minions?limit=0", "/rest/minions?limit=0"}, mock.paths)
+}
+
+func TestGetMinion(t *testing.T) {
+	api := GetMinionsAPI(&mockMinionsRest{})
+	_, err := api.GetMinion("")
+	assert.Error(t, err, "minion ID required")
+
+	minion, err := api.GetMinion("minion-01")
+	assert.NilError(t, err)
+	assert.Equal(t, "UP", minion.Status)
+
+	_, err = api.GetMinion("minion-02")
+	assert.Error(t, err, "minion minion-02 not found (Invalid Response: 404 Not Found)")
+}
+
+func TestDeleteMinion(t *testing.T) {
+	mock := &mockMinionsRest{}
+	api := GetMinionsAPI(mock)
+	assert.NilError(t, api.DeleteMinion("minion-01"))
+	assert.DeepEqual(t, []string{"/api/v2/minions/minion-01"}, mock.paths)
+
+	err := api.DeleteMinion("minion-02")
+	assert.Error(t, err, "minion minion-02 not found (Invalid Response: 404 Not Found)")
+}
@@ -2,6 +2,9 @@ package services
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
 
 	"github.com/OpenNMS/onmsctl/api"
 	"github.com/OpenNMS/onmsctl/model"
@@ -17,12 +20,12 @@ func GetMonitoringLocationsAPI(rest api.RestAPI) api.MonitoringLocationsAPI {
 }
 
 func (api monitoringLocationsAPI) GetLocations() (*model.MonitoringLocationList, error) {
-	jsonString, err := api.rest.Get("/api/v2/monitoringLocations")
+	pages, err := getAllPages(api.rest, "/api/v2/monitoringLocations", "orderBy=locationName", defaultLimit, 0)
 	if err != nil {
 		return nil, err
 	}
 	locations := &model.MonitoringLocationList{}
-	if err := json.Unmarshal(jsonString, locations); err != nil {
+	if err = mergePages(pages, locations); err != nil {
 		return nil, err
 	}
 	return locations, nil
@@ -42,9 +45,9 @@ func (api monitoringLocationsAPI) LocationExists(location string) (bool, error)
 }
 
 func (api monitoringLocationsAPI) GetLocation(location string) (*model.MonitoringLocation, error) {
-	jsonString, err := api.rest.Get("/api/v2/monitoringLocations/" + location)
+	jsonString, err := api.rest.Get("/api/v2/monitoringLocations/" + url.PathEscape(location))
 	if err != nil {
-		return nil, err
+		return nil, describeError(err, locationErrors(location))
 	}
 	loc := &model.MonitoringLocation{}
 	if err := json.Unmarshal(jsonString, loc); err != nil {
@@ -60,3 +63,15 @@ func (api monitoringLocationsAPI) SetLocation(location model.MonitoringLocation)
 	}
 	return api.rest.Post("/api/v2/monitoringLocations", jsonBytes)
 }
+
+func (api monitoringLocationsAPI) DeleteLocation(location string) error {
+	if location == "" {
+		return fmt.Errorf("location name required")
+	}
+	return describeError(api.rest.Delete("/api/v2/monitoringLocations/"+url.PathEscape(location)), locationErrors(location))
+}
+
+// locationErrors the messages for errors on a monitoring location
+func locationErrors(location string) map[int]string {
+	return map[int]string{http.StatusNotFound: fmt.Sprintf("location %s not found", location)}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/OpenNMS/onmsctl/model"
@@ -17,15 +18,8 @@ type mockMonitoringLocationRest struct {
 
 func (api *mockMonitoringLocationRest) Get(path string) ([]byte, error) {
 	api.lastPath = path
-	if path == "/api/v2/monitoringLocations" {
-		bytes, _ := json.Marshal(&model.MonitoringLocationList{
-			Count: 1,
-			Locations: []model.MonitoringLocation{
-				{
-					LocationName: "Apex",
-				},
-			},
-		})
+	if strings.HasPrefix(path, "/api/v2/monitoringLocations?") {
+		bytes, _ := json.Marshal(createMockLocationList(25, getOffset(path)))
 		return bytes, nil
 	}
 	if path == "/api/v2/monitoringLocations/Apex" {
@@ -50,6 +44,9 @@ func (api mockMonitoringLocationRest) PostRaw(path string, dataBytes []byte, con
 }
 
 func (api mockMonitoringLocationRest) Delete(path string) error {
+	if path == "/api/v2/monitoringLocations/Apex" {
+		return nil
+	}
 	return fmt.Errorf("should not be called")
 }
 
@@ -61,6 +58,22 @@ func (api mockMonitoringLocationRest) IsValid(r *http.Response) error {
 	return nil
 }
 
+func createMockLocationList(total int, offset int) *model.MonitoringLocationList {
+	list := &model.MonitoringLocationList{
+		TotalCount: total,
+		Offset:     offset,
+	}
+	for i := offset; i < offset+defaultLimit && i < total; i++ {
+		name := fmt.Sprintf("Loc%02d", i)
+		if i == 0 {
+			name = "Apex"
+		}
+		list.Locations = append(list.Locations, model.MonitoringLocation{LocationName: name})
+	}
+	list.Count = len(list.Locations)
+	return list
+}
+
 func TestLocationExists(t *testing.T) {
 	rest := &mockMonitoringLocationRest{test: t}
 	api := GetMonitoringLocationsAPI(rest)
@@ -69,6 +82,10 @@ func TestLocationExists(t *testing.T) {
 	assert.NilError(t, err)
 	assert.Assert(t, exists)
 
+	exists, err = api.LocationExists("Loc24") // Beyond the first page
+	assert.NilError(t, err)
+	assert.Assert(t, exists)
+
 	exists, err = api.LocationExists("Cary")
 	assert.NilError(t, err)
 	assert.Assert(t, !exists)
@@ -80,8 +97,9 @@ func TestGetLocations(t *testing.T) {
 
 	list, err := api.GetLocations()
 	assert.NilError(t, err)
-	assert.Equal(t, 1, list.Count)
+	assert.Equal(t, 25, list.Count)
 	assert.Equal(t, "Apex", list.Locations[0].LocationName)
+	assert.Equal(t, "Loc24", list.Locations[24].LocationName)
 }
 
 func TestGetLocation(t *testing.T) {
@@ -106,3 +124,14 @@ func TestSetLocation(t *testing.T) {
 	})
 	assert.NilError(t, err)
 }
+
+func TestDeleteLocation(t *testing.T) {
+	rest := &mockMonitoringLocationRest{test: t}
+	api := GetMonitoringLocationsAPI(rest)
+
+	err := api.DeleteLocation("")
+	assert.Error(t, err, "location name required")
+
+	err = api.DeleteLocation("Apex")
+	assert.NilError(t, err)
+}
@@ -9,7 +9,6 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
-	"sync"
 
 	"github.com/OpenNMS/onmsctl/api"
 	"github.com/OpenNMS/onmsctl/model"
@@ -27,44 +26,13 @@ func GetNodesAPI(rest api.RestAPI) api.NodesAPI {
 }
 
 func (api nodesAPI) GetNodes() (*model.OnmsNodeList, error) {
-	bytes, err := api.rest.Get(fmt.Sprintf("/api/v2/nodes?limit=%d&offset=0&orderBy=label", defaultLimit))
+	pages, err := getAllPages(api.rest, "/api/v2/nodes", "orderBy=label", defaultLimit, 0)
 	if err != nil {
 		return nil, err
 	}
 	list := &model.OnmsNodeList{}
-	if len(bytes) > 0 {
-		if err = json.Unmarshal(bytes, list); err != nil {
-			return nil, err
-		}
-	}
-	if list.TotalCount > list.Count {
-		pages := list.TotalCount / defaultLimit
-		if list.TotalCount%defaultLimit > 0 {
-			pages++
-		}
-		m := sync.Mutex{}
-		wg := &sync.WaitGroup{}
-		for i := 1; i < pages; i++ {
-			wg.Add(1)
-			go func(page int, wg *sync.WaitGroup) {
-				defer wg.Done()
-				url := fmt.Sprintf("/api/v2/nodes?limit=%d&offset=%d&orderBy=label", defaultLimit, defaultLimit*page)
-				if bytes, err = api.rest.Get(url); err != nil {
-					return
-				}
-				temp := &model.OnmsNodeList{}
-				if len(bytes) > 0 {
-					if err = json.Unmarshal(bytes, temp); err != nil {
-						return
-					}
-				}
-				m.Lock()
-				list.Nodes = append(list.Nodes, temp.Nodes...)
-				m.Unlock()
-			}(i, wg)
-		}
-		wg.Wait()
-		list.Count = len(list.Nodes)
+	if err = mergePages(pages, list); err != nil {
+		return nil, err
 	}
 	return list, nil
 }
@@ -75,7 +43,7 @@ func (api nodesAPI) GetNode(nodeCriteria string) (*model.OnmsNode, error) {
 	}
 	bytes, err := api.rest.Get("/api/v2/nodes/" + nodeCriteria)
 	if err != nil {
-		return nil, err
+		return nil, describeError(err, nodeErrors(nodeCriteria, ""))
 	}
 	node := &model.OnmsNode{}
 	if err = json.Unmarshal(bytes, node); err != nil {
@@ -108,7 +76,7 @@ func (api nodesAPI) AddNode(node *model.OnmsNode) error {
 	}
 	response, err := api.rest.PostRaw("/api/v2/nodes", jsonBytes, "application/json")
 	if err != nil {
-		return nil
+		return err
 	}
 	if err = api.rest.IsValid(response); err != nil {
 		return err
@@ -149,7 +117,7 @@ func (api nodesAPI) DeleteNode(nodeCriteria string) error {
 	if err := api.isCriteriaValid(nodeCriteria); err != nil {
 		return err
 	}
-	return api.rest.Delete("/api/v2/nodes/" + nodeCriteria)
+	return describeError(api.rest.Delete("/api/v2/nodes/"+nodeCriteria), nodeErrors(nodeCriteria, ""))
 }
 
 func (api nodesAPI) GetNodeMetadata(nodeCriteria string) ([]model.MetaData, error) {
@@ -177,45 +145,13 @@ func (api nodesAPI) GetIPInterfaces(nodeCriteria string) (*model.OnmsIPInterface
 	if err := api.isCriteriaValid(nodeCriteria); err != nil {
 		return nil, err
 	}
-	url := fmt.Sprintf("/api/v2/nodes/%s/ipinterfaces?limit=%d&offset=%d&orderBy=ipAddress", nodeCriteria, defaultLimit, 0)
-	bytes, err := api.rest.Get(url)
+	pages, err := getAllPages(api.rest, "/api/v2/nodes/"+nodeCriteria+"/ipinterfaces", "orderBy=ipAddress", defaultLimit, 0)
 	if err != nil {
 		return nil, err
 	}
 	list := &model.OnmsIPInterfaceList{}
-	if len(bytes) > 0 {
-		if err = json.Unmarshal(bytes, &list); err != nil {
-			return nil, err
-		}
-	}
-	if list.TotalCount > list.Count {
-		pages := list.TotalCount / defaultLimit
-		if list.TotalCount%defaultLimit > 0 {
-			pages++
-		}
-		m := sync.Mutex{}
-		wg := &sync.WaitGroup{}
-		for i := 1; i < pages; i++ {
-			wg.Add(1)
-			go func(page int, wg *sync.WaitGroup) {
-				defer wg.Done()
-				url := fmt.Sprintf("/api/v2/nodes/%s/ipinterfaces?limit=%d&offset=%d&orderBy=ipAddress", nodeCriteria, defaultLimit, defaultLimit*page)
-				if bytes, err = api.rest.Get(url); err != nil {
-					return
-				}
-				temp := &model.OnmsIPInterfaceList{}
-				if len(bytes) > 0 {
-					if err = json.Unmarshal(bytes, temp); err != nil {
-						return
-					}
-				}
-				m.Lock()
-				list.Interfaces = append(list.Interfaces, temp.Interfaces...)
-				m.Unlock()
-			}(i, wg)
-		}
-		wg.Wait()
-		list.Count = len(list.Interfaces)
+	if err = mergePages(pages, list); err != nil {
+		return nil, err
 	}
 	return list, nil
 }
@@ -226,7 +162,7 @@ func (api nodesAPI) GetIPInterface(nodeCriteria string, ipAddress string) (*mode
 	}
 	bytes, err := api.rest.Get("/api/v2/nodes/" + nodeCriteria + "/ipinterfaces/" + ipAddress)
 	if err != nil {
-		return nil, err
+		return nil, describeError(err, nodeErrors(nodeCriteria, "IP interface "+ipAddress))
 	}
 	intf := &model.OnmsIPInterface{}
 	if err = json.Unmarshal(bytes, &intf); err != nil {
@@ -278,7 +214,8 @@ func (api nodesAPI) DeleteIPInterface(nodeCriteria string, ipAddress string) err
 	if err := api.isCriteriaValid(nodeCriteria); err != nil {
 		return err
 	}
-	return api.rest.Delete("/api/v2/nodes/" + nodeCriteria + "/ipinterfaces/" + ipAddress)
+	err := api.rest.Delete("/api/v2/nodes/" + nodeCriteria + "/ipinterfaces/" + ipAddress)
+	return describeError(err, nodeErrors(nodeCriteria, "IP interface "+ipAddress))
 }
 
 func (api nodesAPI) GetIPInterfaceMetadata(nodeCriteria string, ipAddress string) ([]model.MetaData, error) {
@@ -306,45 +243,13 @@ func (api nodesAPI) GetSnmpInterfaces(nodeCriteria string) (*model.OnmsSnmpInter
 	if err := api.isCriteriaValid(nodeCriteria); err != nil {
 		return nil, err
 	}
-	url := fmt.Sprintf("/api/v2/nodes/%s/snmpinterfaces?limit=%d&offset=%d&orderBy=ifName", nodeCriteria, defaultLimit, 0)
-	bytes, err := api.rest.Get(url)
+	pages, err := getAllPages(api.rest, "/api/v2/nodes/"+nodeCriteria+"/snmpinterfaces", "orderBy=ifName", defaultLimit, 0)
 	if err != nil {
 		return nil, err
 	}
 	list := &model.OnmsSnmpInterfaceList{}
-	if len(bytes) > 0 {
-		if err = json.Unmarshal(bytes, &list); err != nil {
-			return nil, err
-		}
-	}
-	if list.TotalCount > list.Count {
-		pages := list.TotalCount / defaultLimit
-		if list.TotalCount%defaultLimit > 0 {
-			pages++
-		}
-		m := sync.Mutex{}
-		wg := &sync.WaitGroup{}
-		for i := 1; i < pages; i++ {
-			wg.Add(1)
-			go func(page int, wg *sync.WaitGroup) {
-				defer wg.Done()
-				url := fmt.Sprintf("/api/v2/nodes/%s/snmpinterfaces?limit=%d&offset=%d&orderBy=ifName", nodeCriteria, defaultLimit, defaultLimit*page)
-				if bytes, err = api.rest.Get(url); err != nil {
-					return
-				}
-				temp := &model.OnmsSnmpInterfaceList{}
-				if len(bytes) > 0 {
-					if err = json.Unmarshal(bytes, temp); err != nil {
-						return
-					}
-				}
-				m.Lock()
-				list.Interfaces = append(list.Interfaces, temp.Interfaces...)
-				m.Unlock()
-			}(i, wg)
-		}
-		wg.Wait()
-		list.Count = len(list.Interfaces)
+	if err = mergePages(pages, list); err != nil {
+		return nil, err
 	}
 	return list, nil
 }
@@ -441,7 +346,8 @@ func (api nodesAPI) DeleteMonitoredService(nodeCriteria string, ipAddress string
 	if err := api.isCriteriaValid(nodeCriteria); err != nil {
 		return err
 	}
-	return api.rest.Delete("/api/v2/nodes/" + nodeCriteria + "/ipinterfaces/" + ipAddress + "/services/" + service)
+	err := api.rest.Delete("/api/v2/nodes/" + nodeCriteria + "/ipinterfaces/" + ipAddress + "/services/" + service)
+	return describeError(err, nodeErrors(nodeCriteria, "service "+service+" on IP interface "+ipAddress))
 }
 
 func (api nodesAPI) GetMonitoredServiceMetadata(nodeCriteria string, ipAddress string, service string) ([]model.MetaData, error) {
@@ -497,7 +403,8 @@ func (api nodesAPI) DeleteCategory(nodeCriteria string, category string) error {
 	if err := api.isCriteriaValid(nodeCriteria); err != nil {
 		return err
 	}
-	return api.rest.Delete("/api/v2/nodes/" + nodeCriteria + "/categories/" + category)
+	err := api.rest.Delete("/api/v2/nodes/" + nodeCriteria + "/categories/" + category)
+	return describeError(err, nodeErrors(nodeCriteria, "category "+category))
 }
 
 func (api nodesAPI) GetAssetRecord(nodeCriteria string) (*model.OnmsAssetRecord, error) {
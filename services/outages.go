@@ -0,0 +1,38 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/OpenNMS/onmsctl/api"
+	"github.com/OpenNMS/onmsctl/model"
+)
+
+type outagesAPI struct {
+	rest api.RestAPI
+}
+
+// GetOutagesAPI Obtain an implementation of the Outages API
+func GetOutagesAPI(rest api.RestAPI) api.OutagesAPI {
+	return &outagesAPI{rest}
+}
+
+// GetOutages gets the outages matching a FIQL filter, sorted by ID in descending order (a limit of 0 means all of them)
+func (api outagesAPI) GetOutages(fiqlFilter string, limit int) (*model.OnmsOutageList, error) {
+	path := fmt.Sprintf("/api/v2/outages?limit=%d&orderBy=id&order=desc", limit)
+	if fiqlFilter != "" {
+		path += "&_s=" + url.QueryEscape(fiqlFilter)
+	}
+	bytes, err := api.rest.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	list := &model.OnmsOutageList{}
+	if len(bytes) > 0 {
+		if err = json.Unmarshal(bytes, list); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+type mockOutagesRest struct{}
+
+func (api mockOutagesRest) Get(path string) ([]byte, error) {
+	switch path {
+	case "/api/v2/outages?limit=0&orderBy=id&order=desc&_s=node.label%3D%3Dsrv01":
+		return []byte(`{"count":1,"totalCount":1,"offset":0,"outage":[{"id":1,"nodeId":1,"nodeLabel":"srv01","ipAddress":"10.0.0.1","ifLostService":1589000000000,"monitoredService":{"serviceType":{"name":"ICMP"}}}]}`), nil
+	case "/api/v2/outages?limit=10&orderBy=id&order=desc":
+		return []byte{}, nil
+	}
+	return nil, fmt.Errorf("GET: should not be called with path %s", path)
+}
+
+func (api mockOutagesRest) Post(path string, jsonBytes []byte) error {
+	return fmt.Errorf("should not be called")
+}
+
+func (api mockOutagesRest) PostRaw(path string, dataBytes []byte, contentType string) (*http.Response, error) {
+	return nil, fmt.Errorf("should not be called")
+}
+
+func (api mockOutagesRest) Delete(path string) error {
+	return fmt.Errorf("should not be called")
+}
+
+func (api mockOutagesRest) Put(path string, dataBytes []byte, contentType string) error {
+	return fmt.Errorf("should not be called")
+}
+
+func (api mockOutagesRest) IsValid(r *http.Response) error {
+	return nil
+}
+
+func TestGetOutages(t *testing.T) {
+	api := GetOutagesAPI(&mockOutagesRest{})
+	list, err := api.GetOutages("node.label==srv01", 0)
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(list.Outages))
+	assert.Equal(t, "ICMP", list.Outages[0].GetServiceName())
+	assert.Equal(t, true, list.Outages[0].IsCurrent())
+
+	list, err = api.GetOutages("", 10)
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(list.Outages))
+}
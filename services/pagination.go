@@ -0,0 +1,102 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/OpenNMS/onmsctl/api"
+	"github.com/OpenNMS/onmsctl/model"
+)
+
+// The maximum number of pages requested concurrently
+const maxConcurrentPages = 4
+
+// The counters returned by every paginated list of the ReST API v2
+type pageInfo struct {
+	Count      int `json:"count"`
+	TotalCount int `json:"totalCount"`
+}
+
+// getAllPages gets the content of every page of a list from the ReST API v2, starting at a given offset
+// The first page is used to find out the total count, then the remaining pages are requested concurrently,
+// with at most maxConcurrentPages requests in flight.
+// The params (without limit or offset) are appended to the query string; the pages are returned in order.
+func getAllPages(rest api.RestAPI, path string, params string, limit int, offset int) ([][]byte, error) {
+	pageURL := func(page int) string {
+		url := fmt.Sprintf("%s?limit=%d&offset=%d", path, limit, offset+limit*page)
+		if params != "" {
+			url += "&" + params
+		}
+		return url
+	}
+	bytes, err := rest.Get(pageURL(0))
+	if err != nil {
+		return nil, err
+	}
+	info := &pageInfo{}
+	if len(bytes) > 0 {
+		if err = json.Unmarshal(bytes, info); err != nil {
+			return nil, err
+		}
+	}
+	pages := 1
+	if limit > 0 && info.TotalCount > offset+info.Count {
+		remaining := info.TotalCount - offset
+		pages = remaining / limit
+		if remaining%limit > 0 {
+			pages++
+		}
+	}
+	results := make([][]byte, pages)
+	results[0] = bytes
+	errs := make([]error, pages)
+	queue := make(chan int, pages)
+	for i := 1; i < pages; i++ {
+		queue <- i
+	}
+	close(queue)
+	workers := pages - 1
+	if workers > maxConcurrentPages {
+		workers = maxConcurrentPages
+	}
+	wg := &sync.WaitGroup{}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(wg *sync.WaitGroup) {
+			defer wg.Done()
+			for page := range queue {
+				results[page], errs[page] = rest.Get(pageURL(page))
+			}
+		}(wg)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// mergePages unmarshals the pages into the given list; the total count and the offset are taken from the first page
+func mergePages(pages [][]byte, list model.SearchList) error {
+	for i, data := range pages {
+		if len(data) == 0 {
+			continue
+		}
+		if i == 0 {
+			if err := json.Unmarshal(data, list); err != nil {
+				return err
+			}
+			continue
+		}
+		page := reflect.New(reflect.TypeOf(list).Elem()).Interface().(model.SearchList)
+		if err := json.Unmarshal(data, page); err != nil {
+			return err
+		}
+		list.Merge(page)
+	}
+	return nil
+}
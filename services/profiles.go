@@ -99,6 +99,24 @@ func updateRestInstance(profile *model.Profile) {
 		rest.Instance.Username = profile.Username
 		rest.Instance.Password = profile.Password
 		rest.Instance.Insecure = profile.Insecure
+		rest.Instance.MaxRetries = profile.MaxRetries
+		rest.Instance.RetryPost = profile.RetryPost
+		// Optional settings, the defaults are kept when they are not set
+		if profile.RetryBackoff > 0 {
+			rest.Instance.RetryBackoff = profile.RetryBackoff
+		}
+		if profile.RetryMaxBackoff > 0 {
+			rest.Instance.RetryMaxBackoff = profile.RetryMaxBackoff
+		}
+		if len(profile.RetryStatusCodes) > 0 {
+			rest.Instance.RetryStatusCodes = profile.RetryStatusCodes
+		}
+		if profile.BreakerThreshold > 0 {
+			rest.Instance.BreakerThreshold = profile.BreakerThreshold
+		}
+		if profile.BreakerCooldown > 0 {
+			rest.Instance.BreakerCooldown = profile.BreakerCooldown
+		}
 	}
 }
 
@@ -3,9 +3,11 @@ package services
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 
 	"github.com/OpenNMS/onmsctl/api"
 	"github.com/OpenNMS/onmsctl/model"
+	"github.com/OpenNMS/onmsctl/rest"
 )
 
 type requisitionsAPI struct {
@@ -70,7 +72,8 @@ func (api requisitionsAPI) SetRequisition(req model.Requisition) error {
 	if err != nil {
 		return err
 	}
-	return api.rest.Post("/rest/requisitions", jsonBytes)
+	err = api.rest.Post("/rest/requisitions", jsonBytes)
+	return describeError(err, requisitionErrors(req.Name, ""))
 }
 
 func (api requisitionsAPI) DeleteRequisition(foreignSource string) error {
@@ -115,7 +118,8 @@ func (api requisitionsAPI) ImportRequisition(foreignSource string, rescanExistin
 	if !api.utils.RequisitionExists(foreignSource) {
 		return fmt.Errorf("requisition %s doesn't exist", foreignSource)
 	}
-	return api.rest.Put("/rest/requisitions/"+foreignSource+"/import?rescanExisting="+rescanExisting, nil, "application/json")
+	err := api.rest.Put("/rest/requisitions/"+foreignSource+"/import?rescanExisting="+rescanExisting, nil, "application/json")
+	return describeError(err, requisitionErrors(foreignSource, ""))
 }
 
 func (api requisitionsAPI) GetNode(foreignSource string, foreignID string) (*model.RequisitionNode, error) {
@@ -130,7 +134,10 @@ func (api requisitionsAPI) GetNode(foreignSource string, foreignID string) (*mod
 	}
 	jsonBytes, err := api.rest.Get("/rest/requisitions/" + foreignSource + "/nodes/" + foreignID)
 	if err != nil {
-		return nil, fmt.Errorf("cannot retrieve node %s from requisition %s", foreignID, foreignSource)
+		if rest.HasStatus(err, http.StatusNotFound) {
+			return nil, describeError(err, requisitionErrors(foreignSource, "node "+foreignID))
+		}
+		return nil, fmt.Errorf("cannot retrieve node %s from requisition %s: %w", foreignID, foreignSource, err)
 	}
 	node := &model.RequisitionNode{}
 	if err := json.Unmarshal(jsonBytes, node); err != nil {
@@ -156,7 +163,8 @@ func (api requisitionsAPI) SetNode(foreignSource string, node model.RequisitionN
 	if err != nil {
 		return err
 	}
-	return api.rest.Post("/rest/requisitions/"+foreignSource+"/nodes", jsonBytes)
+	err = api.rest.Post("/rest/requisitions/"+foreignSource+"/nodes", jsonBytes)
+	return describeError(err, requisitionErrors(foreignSource, ""))
 }
 
 func (api requisitionsAPI) DeleteNode(foreignSource string, foreignID string) error {
@@ -169,7 +177,8 @@ func (api requisitionsAPI) DeleteNode(foreignSource string, foreignID string) er
 	if !api.utils.RequisitionExists(foreignSource) {
 		return fmt.Errorf("requisition %s doesn't exist", foreignSource)
 	}
-	return api.rest.Delete("/rest/requisitions/" + foreignSource + "/nodes/" + foreignID)
+	err := api.rest.Delete("/rest/requisitions/" + foreignSource + "/nodes/" + foreignID)
+	return describeError(err, requisitionErrors(foreignSource, "node "+foreignID))
 }
 
 func (api requisitionsAPI) GetInterface(foreignSource string, foreignID string, ipAddress string) (*model.RequisitionInterface, error) {
@@ -187,7 +196,7 @@ func (api requisitionsAPI) GetInterface(foreignSource string, foreignID string,
 	}
 	jsonString, err := api.rest.Get("/rest/requisitions/" + foreignSource + "/nodes/" + foreignID + "/interfaces/" + ipAddress)
 	if err != nil {
-		return nil, err
+		return nil, describeError(err, requisitionErrors(foreignSource, "interface "+ipAddress+" of node "+foreignID))
 	}
 	intf := &model.RequisitionInterface{}
 	if err := json.Unmarshal(jsonString, intf); err != nil {
@@ -213,7 +222,8 @@ func (api requisitionsAPI) SetInterface(foreignSource string, foreignID string,
 	if err != nil {
 		return err
 	}
-	return api.rest.Post("/rest/requisitions/"+foreignSource+"/nodes/"+foreignID+"/interfaces", jsonBytes)
+	err = api.rest.Post("/rest/requisitions/"+foreignSource+"/nodes/"+foreignID+"/interfaces", jsonBytes)
+	return describeError(err, requisitionErrors(foreignSource, "node "+foreignID))
 }
 
 func (api requisitionsAPI) DeleteInterface(foreignSource string, foreignID string, ipAddress string) error {
@@ -229,8 +239,8 @@ func (api requisitionsAPI) DeleteInterface(foreignSource string, foreignID strin
 	if !api.utils.RequisitionExists(foreignSource) {
 		return fmt.Errorf("requisition %s doesn't exist", foreignSource)
 	}
-	return api.rest.Delete("/rest/requisitions/" + foreignSource + "/nodes/" + foreignID + "/interfaces/" + ipAddress)
-
+	err := api.rest.Delete("/rest/requisitions/" + foreignSource + "/nodes/" + foreignID + "/interfaces/" + ipAddress)
+	return describeError(err, requisitionErrors(foreignSource, "interface "+ipAddress+" of node "+foreignID))
 }
 
 func (api requisitionsAPI) SetService(foreignSource string, foreignID string, ipAddress string, svc model.RequisitionMonitoredService) error {
@@ -253,7 +263,8 @@ func (api requisitionsAPI) SetService(foreignSource string, foreignID string, ip
 	if err != nil {
 		return err
 	}
-	return api.rest.Post("/rest/requisitions/"+foreignSource+"/nodes/"+foreignID+"/interfaces/"+ipAddress+"/services", jsonBytes)
+	err = api.rest.Post("/rest/requisitions/"+foreignSource+"/nodes/"+foreignID+"/interfaces/"+ipAddress+"/services", jsonBytes)
+	return describeError(err, requisitionErrors(foreignSource, "interface "+ipAddress+" of node "+foreignID))
 }
 
 func (api requisitionsAPI) DeleteService(foreignSource string, foreignID string, ipAddress string, serviceName string) error {
@@ -272,7 +283,8 @@ func (api requisitionsAPI) DeleteService(foreignSource string, foreignID string,
 	if !api.utils.RequisitionExists(foreignSource) {
 		return fmt.Errorf("requisition %s doesn't exist", foreignSource)
 	}
-	return api.rest.Delete("/rest/requisitions/" + foreignSource + "/nodes/" + foreignID + "/interfaces/" + ipAddress + "/services/" + serviceName)
+	err := api.rest.Delete("/rest/requisitions/" + foreignSource + "/nodes/" + foreignID + "/interfaces/" + ipAddress + "/services/" + serviceName)
+	return describeError(err, requisitionErrors(foreignSource, "service "+serviceName+" on interface "+ipAddress+" of node "+foreignID))
 }
 
 func (api requisitionsAPI) SetCategory(foreignSource string, foreignID string, category model.RequisitionCategory) error {
@@ -292,7 +304,8 @@ func (api requisitionsAPI) SetCategory(foreignSource string, foreignID string, c
 	if err != nil {
 		return err
 	}
-	return api.rest.Post("/rest/requisitions/"+foreignSource+"/nodes/"+foreignID+"/categories", jsonBytes)
+	err = api.rest.Post("/rest/requisitions/"+foreignSource+"/nodes/"+foreignID+"/categories", jsonBytes)
+	return describeError(err, requisitionErrors(foreignSource, "node "+foreignID))
 }
 
 func (api requisitionsAPI) DeleteCategory(foreignSource string, foreignID string, categoryName string) error {
@@ -308,7 +321,8 @@ func (api requisitionsAPI) DeleteCategory(foreignSource string, foreignID string
 	if !api.utils.RequisitionExists(foreignSource) {
 		return fmt.Errorf("requisition %s doesn't exist", foreignSource)
 	}
-	return api.rest.Delete("/rest/requisitions/" + foreignSource + "/nodes/" + foreignID + "/categories/" + categoryName)
+	err := api.rest.Delete("/rest/requisitions/" + foreignSource + "/nodes/" + foreignID + "/categories/" + categoryName)
+	return describeError(err, requisitionErrors(foreignSource, "category "+categoryName+" on node "+foreignID))
 }
 
 func (api requisitionsAPI) SetAsset(foreignSource string, foreignID string, asset model.RequisitionAsset) error {
@@ -342,7 +356,8 @@ func (api requisitionsAPI) SetAsset(foreignSource string, foreignID string, asse
 	if err != nil {
 		return err
 	}
-	return api.rest.Post("/rest/requisitions/"+foreignSource+"/nodes/"+foreignID+"/assets", jsonBytes)
+	err = api.rest.Post("/rest/requisitions/"+foreignSource+"/nodes/"+foreignID+"/assets", jsonBytes)
+	return describeError(err, requisitionErrors(foreignSource, "node "+foreignID))
 }
 
 func (api requisitionsAPI) DeleteAsset(foreignSource string, foreignID string, assetName string) error {
@@ -358,5 +373,6 @@ func (api requisitionsAPI) DeleteAsset(foreignSource string, foreignID string, a
 	if !api.utils.RequisitionExists(foreignSource) {
 		return fmt.Errorf("requisition %s doesn't exist", foreignSource)
 	}
-	return api.rest.Delete("/rest/requisitions/" + foreignSource + "/nodes/" + foreignID + "/assets/" + assetName)
+	err := api.rest.Delete("/rest/requisitions/" + foreignSource + "/nodes/" + foreignID + "/assets/" + assetName)
+	return describeError(err, requisitionErrors(foreignSource, "asset "+assetName+" on node "+foreignID))
 }
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/OpenNMS/onmsctl/model"
+	"github.com/OpenNMS/onmsctl/rest"
 	"github.com/OpenNMS/onmsctl/test"
 	"gotest.tools/assert"
 )
@@ -141,6 +142,8 @@ func (api mockRequisitionsRest) Delete(path string) error {
 		return nil
 	case "/rest/requisitions/Test1/nodes/n1/assets/city":
 		return nil
+	case "/rest/requisitions/Test1/nodes/n2":
+		return &rest.HTTPError{Method: http.MethodDelete, Path: path, StatusCode: http.StatusNotFound, Status: "404 Not Found"}
 	}
 	return fmt.Errorf("DELETE: should not be called with %s", path)
 }
@@ -149,6 +152,8 @@ func (api mockRequisitionsRest) Put(path string, dataBytes []byte, contentType s
 	switch path {
 	case "/rest/requisitions/Test1/import?rescanExisting=false":
 		return nil
+	case "/rest/requisitions/Test1/import?rescanExisting=true":
+		return &rest.HTTPError{Method: http.MethodPut, Path: path, StatusCode: http.StatusConflict, Status: "409 Conflict"}
 	}
 	return fmt.Errorf("PUT: should not be called with %s", path)
 }
@@ -195,6 +200,17 @@ func TestImportRequisition(t *testing.T) {
 	assert.NilError(t, err)
 }
 
+func TestRequisitionHTTPErrors(t *testing.T) {
+	api := GetRequisitionsAPI(&mockRequisitionsRest{t})
+	err := api.DeleteNode(mockRequisition.Name, "n2")
+	assert.Error(t, err, "node n2 not found on requisition Test1 (Invalid Response: 404 Not Found)")
+	assert.Assert(t, rest.HasStatus(err, http.StatusNotFound))
+
+	err = api.ImportRequisition(mockRequisition.Name, "true")
+	assert.ErrorContains(t, err, "requisition Test1 is locked by a running import")
+	assert.Assert(t, rest.HasStatus(err, http.StatusConflict))
+}
+
 func TestGetNode(t *testing.T) {
 	api := GetRequisitionsAPI(&mockRequisitionsRest{t})
 	node, err := api.GetNode(mockRequisition.Name, mockRequisition.Nodes[0].ForeignID)
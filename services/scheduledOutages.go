@@ -0,0 +1,125 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/OpenNMS/onmsctl/api"
+	"github.com/OpenNMS/onmsctl/model"
+)
+
+// The daemons used by the ReST API for each scheduled outage target
+var outageTargetDaemons = map[string]string{
+	"poller":       "pollerd",
+	"collector":    "collectd",
+	"threshold":    "threshd",
+	"notification": "notifd",
+}
+
+type scheduledOutagesAPI struct {
+	rest api.RestAPI
+}
+
+// GetScheduledOutagesAPI Obtain an implementation of the Scheduled Outages API
+func GetScheduledOutagesAPI(rest api.RestAPI) api.ScheduledOutagesAPI {
+	return &scheduledOutagesAPI{rest}
+}
+
+func (api scheduledOutagesAPI) GetScheduledOutages() (*model.ScheduledOutageList, error) {
+	jsonBytes, err := api.rest.Get("/rest/sched-outages")
+	if err != nil {
+		return nil, fmt.Errorf("cannot retrieve scheduled outages: %w", err)
+	}
+	list := &model.ScheduledOutageList{}
+	if len(jsonBytes) > 0 {
+		if err := json.Unmarshal(jsonBytes, list); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+func (api scheduledOutagesAPI) GetScheduledOutage(name string) (*model.ScheduledOutage, error) {
+	if name == "" {
+		return nil, fmt.Errorf("outage name required")
+	}
+	jsonBytes, err := api.rest.Get("/rest/sched-outages/" + url.PathEscape(name))
+	if err != nil {
+		return nil, describeError(err, scheduledOutageErrors(name))
+	}
+	outage := &model.ScheduledOutage{}
+	if err := json.Unmarshal(jsonBytes, outage); err != nil {
+		return nil, err
+	}
+	return outage, nil
+}
+
+func (api scheduledOutagesAPI) SetScheduledOutage(outage model.ScheduledOutage) error {
+	if err := outage.IsValid(); err != nil {
+		return err
+	}
+	jsonBytes, err := json.Marshal(outage)
+	if err != nil {
+		return err
+	}
+	return api.rest.Post("/rest/sched-outages", jsonBytes)
+}
+
+func (api scheduledOutagesAPI) DeleteScheduledOutage(name string) error {
+	if name == "" {
+		return fmt.Errorf("outage name required")
+	}
+	return api.rest.Delete("/rest/sched-outages/" + url.PathEscape(name))
+}
+
+func (api scheduledOutagesAPI) AddToPackage(name string, target string, packageName string) error {
+	path, err := api.getTargetPath(name, target, packageName)
+	if err != nil {
+		return err
+	}
+	err = api.rest.Put(path, nil, "application/json")
+	return describeError(err, outageTargetErrors(name, target, packageName))
+}
+
+func (api scheduledOutagesAPI) RemoveFromPackage(name string, target string, packageName string) error {
+	path, err := api.getTargetPath(name, target, packageName)
+	if err != nil {
+		return err
+	}
+	err = api.rest.Delete(path)
+	return describeError(err, outageTargetErrors(name, target, packageName))
+}
+
+func (api scheduledOutagesAPI) getTargetPath(name string, target string, packageName string) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("outage name required")
+	}
+	daemon, ok := outageTargetDaemons[target]
+	if !ok {
+		return "", fmt.Errorf("invalid target %s. Allowed values: %s", target, model.ScheduledOutageTargets.EnumAsString())
+	}
+	path := "/rest/sched-outages/" + url.PathEscape(name) + "/" + daemon
+	if target == "notification" {
+		if packageName != "" {
+			return "", fmt.Errorf("notification target doesn't require a package")
+		}
+		return path, nil
+	}
+	if packageName == "" {
+		return "", fmt.Errorf("package name required for %s target", target)
+	}
+	return path + "/" + url.PathEscape(packageName), nil
+}
+
+func scheduledOutageErrors(name string) map[int]string {
+	return map[int]string{http.StatusNotFound: fmt.Sprintf("scheduled outage %s not found", name)}
+}
+
+func outageTargetErrors(name string, target string, packageName string) map[int]string {
+	if packageName == "" {
+		return scheduledOutageErrors(name)
+	}
+	return map[int]string{http.StatusNotFound: fmt.Sprintf("either scheduled outage %s or %s package %s not found", name, target, packageName)}
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/OpenNMS/onmsctl/model"
+	"github.com/OpenNMS/onmsctl/rest"
+	"gotest.tools/assert"
+)
+
+var mockScheduledOutage = model.ScheduledOutage{
+	Name: "Test Outage",
+	Type: "daily",
+	Nodes: []model.ScheduledNode{
+		{ID: 1},
+	},
+	Times: []model.ScheduledTime{
+		{Begins: "00:00:00", Ends: "01:00:00"},
+	},
+}
+
+type mockScheduledOutagesRest struct {
+	t *testing.T
+}
+
+func (api mockScheduledOutagesRest) Get(path string) ([]byte, error) {
+	switch path {
+	case "/rest/sched-outages":
+		return []byte(`{"outage":[{"name":"Test Outage","type":"daily","node":[{"id":1}],"time":[{"begins":"00:00:00","ends":"01:00:00"}]}]}`), nil
+	case "/rest/sched-outages/Test%20Outage":
+		return json.Marshal(mockScheduledOutage)
+	case "/rest/sched-outages/Unknown":
+		return nil, &rest.HTTPError{StatusCode: http.StatusNotFound, Status: "404 Not Found"}
+	}
+	return nil, fmt.Errorf("GET: should not be called with path %s", path)
+}
+
+func (api mockScheduledOutagesRest) Post(path string, jsonBytes []byte) error {
+	assert.Equal(api.t, "/rest/sched-outages", path)
+	outage := model.ScheduledOutage{}
+	err := json.Unmarshal(jsonBytes, &outage)
+	assert.NilError(api.t, err)
+	assert.DeepEqual(api.t, mockScheduledOutage, outage)
+	return nil
+}
+
+func (api mockScheduledOutagesRest) PostRaw(path string, dataBytes []byte, contentType string) (*http.Response, error) {
+	return nil, fmt.Errorf("should not be called")
+}
+
+func (api mockScheduledOutagesRest) Delete(path string) error {
+	switch path {
+	case "/rest/sched-outages/Test%20Outage", "/rest/sched-outages/Test%20Outage/notifd":
+		return nil
+	}
+	return fmt.Errorf("DELETE: should not be called with path %s", path)
+}
+
+func (api mockScheduledOutagesRest) Put(path string, dataBytes []byte, contentType string) error {
+	switch path {
+	case "/rest/sched-outages/Test%20Outage/pollerd/example1", "/rest/sched-outages/Test%20Outage/collectd/example1":
+		return nil
+	}
+	return fmt.Errorf("PUT: should not be called with path %s", path)
+}
+
+func (api mockScheduledOutagesRest) IsValid(r *http.Response) error {
+	return nil
+}
+
+func TestGetScheduledOutages(t *testing.T) {
+	api := GetScheduledOutagesAPI(&mockScheduledOutagesRest{t})
+	list, err := api.GetScheduledOutages()
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(list.Outages))
+	assert.DeepEqual(t, mockScheduledOutage, list.Outages[0])
+	assert.Assert(t, list.GetOutage("Test Outage") != nil)
+	assert.Assert(t, list.GetOutage("Unknown") == nil)
+}
+
+func TestGetScheduledOutage(t *testing.T) {
+	api := GetScheduledOutagesAPI(&mockScheduledOutagesRest{t})
+
+	_, err := api.GetScheduledOutage("")
+	assert.Error(t, err, "outage name required")
+
+	outage, err := api.GetScheduledOutage("Test Outage")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, mockScheduledOutage, *outage)
+
+	_, err = api.GetScheduledOutage("Unknown")
+	assert.ErrorContains(t, err, "scheduled outage Unknown not found")
+	assert.Assert(t, rest.HasStatus(err, http.StatusNotFound))
+}
+
+func TestSetScheduledOutage(t *testing.T) {
+	api := GetScheduledOutagesAPI(&mockScheduledOutagesRest{t})
+
+	err := api.SetScheduledOutage(model.ScheduledOutage{Name: "Test"})
+	assert.ErrorContains(t, err, "type cannot be empty")
+
+	err = api.SetScheduledOutage(mockScheduledOutage)
+	assert.NilError(t, err)
+}
+
+func TestDeleteScheduledOutage(t *testing.T) {
+	api := GetScheduledOutagesAPI(&mockScheduledOutagesRest{t})
+
+	err := api.DeleteScheduledOutage("")
+	assert.Error(t, err, "outage name required")
+
+	err = api.DeleteScheduledOutage("Test Outage")
+	assert.NilError(t, err)
+}
+
+func TestAddToPackage(t *testing.T) {
+	api := GetScheduledOutagesAPI(&mockScheduledOutagesRest{t})
+
+	err := api.AddToPackage("Test Outage", "poller", "")
+	assert.Error(t, err, "package name required for poller target")
+
+	err = api.AddToPackage("Test Outage", "provisioning", "example1")
+	assert.ErrorContains(t, err, "invalid target provisioning")
+
+	err = api.AddToPackage("Test Outage", "poller", "example1")
+	assert.NilError(t, err)
+
+	err = api.AddToPackage("Test Outage", "collector", "example1")
+	assert.NilError(t, err)
+}
+
+func TestRemoveFromPackage(t *testing.T) {
+	api := GetScheduledOutagesAPI(&mockScheduledOutagesRest{t})
+
+	err := api.RemoveFromPackage("Test Outage", "notification", "example1")
+	assert.Error(t, err, "notification target doesn't require a package")
+
+	err = api.RemoveFromPackage("Test Outage", "notification", "")
+	assert.NilError(t, err)
+}
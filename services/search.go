@@ -0,0 +1,86 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/OpenNMS/onmsctl/api"
+	"github.com/OpenNMS/onmsctl/model"
+)
+
+type searchAPI struct {
+	rest api.RestAPI
+}
+
+// GetSearchAPI Obtain an implementation of the Search API
+func GetSearchAPI(rest api.RestAPI) api.SearchAPI {
+	return &searchAPI{rest}
+}
+
+// Search gets a single page of entities matching the query
+func (api searchAPI) Search(entity string, query model.SearchQuery) (model.SearchList, error) {
+	e, err := model.GetSearchEntity(entity)
+	if err != nil {
+		return nil, err
+	}
+	query.Filter = model.AndFiql(e.Filter, query.Filter)
+	bytes, err := api.rest.Get(query.URL(e.Path))
+	if err != nil {
+		return nil, err
+	}
+	list := e.NewList()
+	if err = mergePages([][]byte{bytes}, list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// SearchAll gets every entity matching the query, starting at its offset and using its limit as the page size
+// When the query is not sorted, the default order of the entity is used to make sure the pages don't overlap.
+func (api searchAPI) SearchAll(entity string, query model.SearchQuery) (model.SearchList, error) {
+	e, err := model.GetSearchEntity(entity)
+	if err != nil {
+		return nil, err
+	}
+	query.Filter = model.AndFiql(e.Filter, query.Filter)
+	if query.OrderBy == "" {
+		query.OrderBy = e.DefaultOrderBy
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultLimit
+	}
+	pages, err := getAllPages(api.rest, e.Path, query.Params(), query.Limit, query.Offset)
+	if err != nil {
+		return nil, err
+	}
+	list := e.NewList()
+	if err = mergePages(pages, list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// GetProperties gets the properties of an entity that can be used on FIQL expressions
+func (api searchAPI) GetProperties(entity string) (*model.SearchPropertyList, error) {
+	e, err := model.GetSearchEntity(entity)
+	if err != nil {
+		return nil, err
+	}
+	bytes, err := api.rest.Get(e.Path + "/properties")
+	if err != nil {
+		return nil, describeError(err, searchErrors(entity))
+	}
+	list := &model.SearchPropertyList{}
+	if len(bytes) > 0 {
+		if err = json.Unmarshal(bytes, list); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+// searchErrors the messages for errors when obtaining the searchable properties of an entity
+func searchErrors(entity string) map[int]string {
+	return map[int]string{http.StatusNotFound: fmt.Sprintf("the server doesn't expose the searchable properties of %s", entity)}
+}
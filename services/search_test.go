@@ -0,0 +1,135 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/OpenNMS/onmsctl/model"
+	"github.com/OpenNMS/onmsctl/rest"
+	"gotest.tools/assert"
+)
+
+type mockSearchRest struct{}
+
+func (api mockSearchRest) Get(path string) ([]byte, error) {
+	if strings.HasPrefix(path, "/api/v2/alarms?limit=5&offset=") && strings.HasSuffix(path, "&orderBy=id&_s=alarm.severity%3Dge%3DMAJOR") {
+		offset := getOffset(path)
+		list := &model.OnmsAlarmList{TotalCount: 12, Offset: offset}
+		for i := offset; i < offset+5 && i < 12; i++ {
+			list.Alarms = append(list.Alarms, model.OnmsAlarm{ID: i + 1, Severity: "MAJOR"})
+		}
+		list.Count = len(list.Alarms)
+		return json.Marshal(list)
+	}
+	if path == "/api/v2/alarms?limit=10&offset=0&_s=%28isSituation%3D%3Dtrue%29%3B%28alarm.severity%3D%3DMAJOR%29" {
+		return []byte(`{"count":1,"totalCount":1,"offset":0,"alarm":[{"id":10,"severity":"MAJOR","relatedAlarms":[{"id":1},{"id":2}]}]}`), nil
+	}
+	if path == "/api/v2/snmpinterfaces/properties" {
+		return []byte(`{"searchProperty":[{"id":"ifSpeed","name":"Interface Speed","type":"LONG","orderBy":true},{"id":"collect","name":"Collection","type":"STRING","orderBy":true,"values":{"C":"Collect","N":"Don't collect"}}]}`), nil
+	}
+	if path == "/api/v2/monitoringLocations/properties" {
+		return nil, &rest.HTTPError{StatusCode: 404, Status: "404 Not Found"}
+	}
+	if path == "/api/v2/events?limit=10&offset=0&orderBy=id&order=desc" {
+		return []byte(`{"count":1,"totalCount":100,"offset":0,"event":[{"id":100}]}`), nil
+	}
+	return nil, fmt.Errorf("GET: should not be called with path %s", path)
+}
+
+func (api mockSearchRest) Post(path string, jsonBytes []byte) error {
+	return fmt.Errorf("should not be called")
+}
+
+func (api mockSearchRest) PostRaw(path string, dataBytes []byte, contentType string) (*http.Response, error) {
+	return nil, fmt.Errorf("should not be called")
+}
+
+func (api mockSearchRest) Delete(path string) error {
+	return fmt.Errorf("should not be called")
+}
+
+func (api mockSearchRest) Put(path string, dataBytes []byte, contentType string) error {
+	return fmt.Errorf("should not be called")
+}
+
+func (api mockSearchRest) IsValid(r *http.Response) error {
+	return nil
+}
+
+func TestSearch(t *testing.T) {
+	api := GetSearchAPI(&mockSearchRest{})
+	result, err := api.Search("events", model.SearchQuery{OrderBy: "id", Order: "desc", Limit: 10})
+	assert.NilError(t, err)
+	list := result.(*model.OnmsEventList)
+	assert.Equal(t, 1, len(list.Events))
+	assert.Equal(t, 100, list.TotalCount)
+
+	result, err = api.Search("situations", model.SearchQuery{Filter: "alarm.severity==MAJOR", Limit: 10})
+	assert.NilError(t, err)
+	situations := result.(*model.OnmsAlarmList)
+	assert.Equal(t, 2, len(situations.Alarms[0].RelatedAlarms))
+
+	_, err = api.Search("unknown", model.SearchQuery{})
+	assert.ErrorContains(t, err, "invalid entity unknown")
+}
+
+func TestSearchAll(t *testing.T) {
+	api := GetSearchAPI(&mockSearchRest{})
+	result, err := api.SearchAll("alarms", model.SearchQuery{Filter: "alarm.severity=ge=MAJOR", Limit: 5, Offset: 1})
+	assert.NilError(t, err)
+	list := result.(*model.OnmsAlarmList)
+	assert.Equal(t, 11, list.Count)
+	for i, a := range list.Alarms {
+		assert.Equal(t, i+2, a.ID) // Pages must be merged in order
+	}
+}
+
+type mockPagedRest struct {
+	mockSearchRest
+	mutex    *sync.Mutex
+	inFlight *int
+	maxSeen  *int
+}
+
+func (api mockPagedRest) Get(path string) ([]byte, error) {
+	api.mutex.Lock()
+	*api.inFlight++
+	if *api.inFlight > *api.maxSeen {
+		*api.maxSeen = *api.inFlight
+	}
+	api.mutex.Unlock()
+	time.Sleep(5 * time.Millisecond)
+	api.mutex.Lock()
+	*api.inFlight--
+	api.mutex.Unlock()
+	return []byte(fmt.Sprintf(`{"count":1,"totalCount":50,"offset":%d}`, getOffset(path))), nil
+}
+
+func TestGetAllPagesConcurrency(t *testing.T) {
+	inFlight, maxSeen := 0, 0
+	api := mockPagedRest{mutex: &sync.Mutex{}, inFlight: &inFlight, maxSeen: &maxSeen}
+	pages, err := getAllPages(api, "/api/v2/events", "", 1, 0)
+	assert.NilError(t, err)
+	assert.Equal(t, 50, len(pages))
+	for i, page := range pages {
+		assert.Assert(t, strings.Contains(string(page), fmt.Sprintf(`"offset":%d}`, i)))
+	}
+	assert.Assert(t, maxSeen <= maxConcurrentPages, "%d concurrent requests", maxSeen)
+}
+
+func TestGetSearchProperties(t *testing.T) {
+	api := GetSearchAPI(&mockSearchRest{})
+	list, err := api.GetProperties("snmpinterfaces")
+	assert.NilError(t, err)
+	assert.Equal(t, 2, len(list.Properties))
+	assert.Equal(t, "ifSpeed", list.Properties[0].ID)
+	assert.Equal(t, "Collect", list.Properties[1].Values["C"])
+
+	_, err = api.GetProperties("locations")
+	assert.ErrorContains(t, err, "the server doesn't expose the searchable properties of locations")
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/OpenNMS/onmsctl/api"
+	"github.com/OpenNMS/onmsctl/model"
+)
+
+// DefaultSyslogPort the default port of the OpenNMS syslog listener
+const DefaultSyslogPort = 10514
+
+type syslogAPI struct {
+	timeout time.Duration
+}
+
+// GetSyslogAPI Obtain an implementation of the Syslog API
+func GetSyslogAPI(timeout time.Duration) api.SyslogAPI {
+	return &syslogAPI{timeout}
+}
+
+// SendMessage sends a syslog message to a given address (host:port) using UDP or TCP
+// Over TCP, the message is terminated with a new line (non-transparent framing, RFC 6587).
+func (api syslogAPI) SendMessage(address string, protocol string, format string, message model.SyslogMessage) error {
+	if protocol != "udp" && protocol != "tcp" {
+		return fmt.Errorf("invalid protocol %s; allowed values: udp, tcp", protocol)
+	}
+	if err := message.Validate(); err != nil {
+		return err
+	}
+	conn, err := net.DialTimeout(protocol, address, api.timeout)
+	if err != nil {
+		return fmt.Errorf("cannot connect to %s: %v", address, err)
+	}
+	defer conn.Close()
+	if api.timeout > 0 {
+		if err = conn.SetDeadline(time.Now().Add(api.timeout)); err != nil {
+			return err
+		}
+	}
+	content := message.Format(format)
+	if protocol == "tcp" {
+		content = strings.ReplaceAll(content, "\n", " ") + "\n"
+	}
+	if _, err = conn.Write([]byte(content)); err != nil {
+		return fmt.Errorf("cannot send message to %s: %v", address, err)
+	}
+	return nil
+}
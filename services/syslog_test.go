@@ -0,0 +1,62 @@
+package services
+
+import (
+	"bufio"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/OpenNMS/onmsctl/model"
+
+	"gotest.tools/assert"
+)
+
+var mockSyslogMessage = model.SyslogMessage{
+	Facility:  "local0",
+	Severity:  "err",
+	Timestamp: time.Date(2020, time.March, 5, 10, 4, 5, 0, time.UTC),
+	Hostname:  "srv01",
+	AppName:   "sshd",
+	Message:   "Failed password for root",
+}
+
+func TestSendSyslogMessageOverUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	defer conn.Close()
+
+	err = GetSyslogAPI(time.Second).SendMessage(conn.LocalAddr().String(), "udp", "rfc3164", mockSyslogMessage)
+	assert.NilError(t, err)
+
+	buffer := make([]byte, 1024)
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, _, err := conn.ReadFrom(buffer)
+	assert.NilError(t, err)
+	assert.Equal(t, "<131>Mar  5 10:04:05 srv01 sshd: Failed password for root", string(buffer[:n]))
+}
+
+func TestSendSyslogMessageOverTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	defer listener.Close()
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		line, _ := bufio.NewReader(conn).ReadString('\n')
+		received <- line
+	}()
+
+	message := mockSyslogMessage
+	message.Message = "First line\nSecond line"
+	err = GetSyslogAPI(time.Second).SendMessage(listener.Addr().String(), "tcp", "rfc5424", message)
+	assert.NilError(t, err)
+	line := <-received
+	assert.Equal(t, "<131>1 2020-03-05T10:04:05Z srv01 sshd - - - First line Second line\n", line)
+
+	err = GetSyslogAPI(time.Second).SendMessage(listener.Addr().String(), "sctp", "rfc5424", message)
+	assert.Error(t, err, "invalid protocol sctp; allowed values: udp, tcp")
+}
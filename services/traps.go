@@ -0,0 +1,205 @@
+package services
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/OpenNMS/onmsctl/api"
+	"github.com/OpenNMS/onmsctl/model"
+	"github.com/gosnmp/gosnmp"
+)
+
+// DefaultTrapPort the default port of the SNMP trap listener
+const DefaultTrapPort = 162
+
+// DefaultTrapEngineID the SNMPv3 engine ID used to send traps when the SNMP configuration doesn't provide one
+const DefaultTrapEngineID = "0x80001f88046f6e6d7363746c"
+
+// The OID of the snmpTrapOID.0 object, mandatory for SNMPv2c and SNMPv3 traps
+const snmpTrapOID = ".1.3.6.1.6.3.1.1.4.1.0"
+
+// The OID of the sysUpTime.0 object, mandatory for SNMPv2c and SNMPv3 traps
+const sysUpTimeOID = ".1.3.6.1.2.1.1.3.0"
+
+var snmpVersions = map[string]gosnmp.SnmpVersion{
+	"v1":  gosnmp.Version1,
+	"v2c": gosnmp.Version2c,
+	"v3":  gosnmp.Version3,
+}
+
+var snmpAuthProtocols = map[string]gosnmp.SnmpV3AuthProtocol{
+	"MD5": gosnmp.MD5,
+	"SHA": gosnmp.SHA,
+}
+
+var snmpPrivProtocols = map[string]gosnmp.SnmpV3PrivProtocol{
+	"DES":    gosnmp.DES,
+	"AES":    gosnmp.AES,
+	"AES192": gosnmp.AES192,
+	"AES256": gosnmp.AES256,
+}
+
+var snmpMsgFlags = map[int]gosnmp.SnmpV3MsgFlags{
+	model.SnmpNoAuthNoPriv: gosnmp.NoAuthNoPriv,
+	model.SnmpAuthNoPriv:   gosnmp.AuthNoPriv,
+	model.SnmpAuthPriv:     gosnmp.AuthPriv,
+}
+
+var snmpUnsignedTypes = map[string]gosnmp.Asn1BER{
+	"counter32": gosnmp.Counter32,
+	"gauge32":   gosnmp.Gauge32,
+	"timeticks": gosnmp.TimeTicks,
+}
+
+type trapsAPI struct{}
+
+// GetTrapsAPI Obtain an implementation of the Traps API
+func GetTrapsAPI() api.TrapsAPI {
+	return &trapsAPI{}
+}
+
+// SendTrap sends an SNMP trap to a given address (host:port) over UDP
+func (api trapsAPI) SendTrap(address string, trap model.Trap) error {
+	if err := trap.Validate(); err != nil {
+		return err
+	}
+	client, err := buildSNMPClient(address, trap.SNMP)
+	if err != nil {
+		return err
+	}
+	if err = client.Connect(); err != nil {
+		return fmt.Errorf("cannot connect to %s: %v", address, err)
+	}
+	defer client.Conn.Close()
+	snmpTrap, err := buildSNMPTrap(trap)
+	if err != nil {
+		return err
+	}
+	if client.Version == gosnmp.Version1 && snmpTrap.AgentAddress == "" {
+		if addr, ok := client.Conn.LocalAddr().(*net.UDPAddr); ok {
+			snmpTrap.AgentAddress = addr.IP.String()
+		}
+	}
+	if _, err = client.SendTrap(snmpTrap); err != nil {
+		return fmt.Errorf("cannot send trap to %s: %v", address, err)
+	}
+	return nil
+}
+
+func buildSNMPClient(address string, snmp model.SnmpInfo) (*gosnmp.GoSNMP, error) {
+	host, portText, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	port, err := strconv.ParseUint(portText, 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %s", portText)
+	}
+	client := &gosnmp.GoSNMP{
+		Target:    host,
+		Port:      uint16(port),
+		Transport: "udp",
+		Version:   snmpVersions[snmp.Version],
+		Community: snmp.Community,
+		Timeout:   2 * time.Second,
+		MaxOids:   gosnmp.MaxOids,
+	}
+	if snmp.Timeout > 0 {
+		client.Timeout = time.Duration(snmp.Timeout) * time.Millisecond
+	}
+	if client.Version != gosnmp.Version3 {
+		return client, nil
+	}
+	engineID := snmp.EngineID
+	if engineID == "" {
+		engineID = DefaultTrapEngineID
+	}
+	engineIDBytes, err := hex.DecodeString(strings.TrimPrefix(strings.ToLower(engineID), "0x"))
+	if err != nil || len(engineIDBytes) < 5 {
+		return nil, fmt.Errorf("invalid engine ID %s, a hexadecimal string with at least 5 octets is expected", engineID)
+	}
+	params := &gosnmp.UsmSecurityParameters{
+		UserName:                 snmp.SecurityName,
+		AuthoritativeEngineID:    string(engineIDBytes),
+		AuthoritativeEngineBoots: 1,
+		AuthoritativeEngineTime:  uint32(time.Now().Unix()),
+		AuthenticationProtocol:   gosnmp.NoAuth,
+		PrivacyProtocol:          gosnmp.NoPriv,
+	}
+	if snmp.SecurityLevel >= model.SnmpAuthNoPriv {
+		params.AuthenticationProtocol = gosnmp.MD5
+		if p, ok := snmpAuthProtocols[snmp.AuthProtocol]; ok {
+			params.AuthenticationProtocol = p
+		}
+		params.AuthenticationPassphrase = snmp.AuthPassPhrase
+	}
+	if snmp.SecurityLevel == model.SnmpAuthPriv {
+		params.PrivacyProtocol = gosnmp.DES
+		if p, ok := snmpPrivProtocols[snmp.PrivProtocol]; ok {
+			params.PrivacyProtocol = p
+		}
+		params.PrivacyPassphrase = snmp.PrivPassPhrase
+	}
+	client.SecurityModel = gosnmp.UserSecurityModel
+	client.SecurityParameters = params
+	client.MsgFlags = snmpMsgFlags[snmp.SecurityLevel]
+	client.ContextName = snmp.ContextName
+	return client, nil
+}
+
+func buildSNMPTrap(trap model.Trap) (gosnmp.SnmpTrap, error) {
+	snmpTrap := gosnmp.SnmpTrap{}
+	if trap.SNMP.Version == "v1" {
+		snmpTrap.Enterprise = trap.Enterprise
+		snmpTrap.AgentAddress = trap.AgentAddress
+		snmpTrap.GenericTrap = trap.Generic
+		snmpTrap.SpecificTrap = trap.Specific
+		snmpTrap.Timestamp = uint(trap.Uptime)
+	} else {
+		snmpTrap.Variables = []gosnmp.SnmpPDU{
+			{Name: sysUpTimeOID, Type: gosnmp.TimeTicks, Value: trap.Uptime},
+			{Name: snmpTrapOID, Type: gosnmp.ObjectIdentifier, Value: trap.TrapOID},
+		}
+	}
+	for _, v := range trap.Varbinds {
+		pdu, err := buildSNMPPDU(v)
+		if err != nil {
+			return snmpTrap, err
+		}
+		snmpTrap.Variables = append(snmpTrap.Variables, pdu)
+	}
+	return snmpTrap, nil
+}
+
+func buildSNMPPDU(v model.TrapVarbind) (gosnmp.SnmpPDU, error) {
+	pdu := gosnmp.SnmpPDU{Name: v.OID}
+	var err error
+	switch v.Type {
+	case "integer":
+		var value int64
+		value, err = strconv.ParseInt(v.Value, 10, 32)
+		pdu.Type, pdu.Value = gosnmp.Integer, int(value)
+	case "counter32", "gauge32", "timeticks":
+		var value uint64
+		value, err = strconv.ParseUint(v.Value, 10, 32)
+		pdu.Type, pdu.Value = snmpUnsignedTypes[v.Type], uint32(value)
+	case "counter64":
+		var value uint64
+		value, err = strconv.ParseUint(v.Value, 10, 64)
+		pdu.Type, pdu.Value = gosnmp.Counter64, value
+	case "oid":
+		pdu.Type, pdu.Value = gosnmp.ObjectIdentifier, v.Value
+	case "ipaddress":
+		pdu.Type, pdu.Value = gosnmp.IPAddress, v.Value
+	default:
+		pdu.Type, pdu.Value = gosnmp.OctetString, v.Value
+	}
+	if err != nil {
+		return pdu, fmt.Errorf("invalid %s value %s for %s", v.Type, v.Value, v.OID)
+	}
+	return pdu, nil
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/OpenNMS/onmsctl/model"
+	"github.com/gosnmp/gosnmp"
+
+	"gotest.tools/assert"
+)
+
+// receiveTrap sends a trap to a local UDP listener, and decodes it with the given parameters
+func receiveTrap(t *testing.T, trap model.Trap, decoder *gosnmp.GoSNMP) *gosnmp.SnmpPacket {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	defer conn.Close()
+
+	err = GetTrapsAPI().SendTrap(conn.LocalAddr().String(), trap)
+	assert.NilError(t, err)
+
+	buffer := make([]byte, 4096)
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, _, err := conn.ReadFrom(buffer)
+	assert.NilError(t, err)
+	decoder.Logger = gosnmp.NewLogger(nil)
+	packet, err := decoder.UnmarshalTrap(buffer[:n], false)
+	assert.NilError(t, err)
+	return packet
+}
+
+func TestSendTrapV2c(t *testing.T) {
+	trap := model.Trap{
+		SNMP:    model.SnmpInfo{Version: "v2c", Community: "private"},
+		TrapOID: ".1.3.6.1.6.3.1.1.5.3",
+		Uptime:  1000,
+	}
+	trap.AddVarbind(".1.3.6.1.2.1.2.2.1.1.1", "integer", "1")
+	trap.AddVarbind(".1.3.6.1.2.1.2.2.1.2.1", "string", "eth0")
+	packet := receiveTrap(t, trap, &gosnmp.GoSNMP{})
+	assert.Equal(t, gosnmp.Version2c, packet.Version)
+	assert.Equal(t, "private", packet.Community)
+	assert.Equal(t, gosnmp.SNMPv2Trap, packet.PDUType)
+	assert.Equal(t, 4, len(packet.Variables))
+	assert.Equal(t, uint32(1000), packet.Variables[0].Value)
+	assert.Equal(t, ".1.3.6.1.6.3.1.1.5.3", packet.Variables[1].Value)
+	assert.Equal(t, 1, packet.Variables[2].Value)
+	assert.DeepEqual(t, []byte("eth0"), packet.Variables[3].Value)
+}
+
+func TestSendTrapV1(t *testing.T) {
+	trap := model.Trap{
+		SNMP:       model.SnmpInfo{Version: "v1", Community: "public"},
+		Enterprise: ".1.3.6.1.4.1.5813",
+		Generic:    6,
+		Specific:   2,
+	}
+	trap.AddVarbind(".1.3.6.1.4.1.5813.20.1.1", "ipaddress", "10.0.0.1")
+	packet := receiveTrap(t, trap, &gosnmp.GoSNMP{})
+	assert.Equal(t, gosnmp.Version1, packet.Version)
+	assert.Equal(t, ".1.3.6.1.4.1.5813", packet.Enterprise)
+	assert.Equal(t, "127.0.0.1", packet.AgentAddress)
+	assert.Equal(t, 6, packet.GenericTrap)
+	assert.Equal(t, 2, packet.SpecificTrap)
+	assert.Equal(t, "10.0.0.1", packet.Variables[0].Value)
+}
+
+func TestSendTrapV3(t *testing.T) {
+	trap := model.Trap{
+		SNMP: model.SnmpInfo{
+			Version:        "v3",
+			SecurityName:   "opennms",
+			SecurityLevel:  model.SnmpAuthPriv,
+			AuthProtocol:   "SHA",
+			AuthPassPhrase: "0p3nNMS!",
+			PrivProtocol:   "AES",
+			PrivPassPhrase: "0p3nNMS!",
+		},
+		TrapOID: ".1.3.6.1.6.3.1.1.5.4",
+	}
+	decoder := &gosnmp.GoSNMP{
+		Version:       gosnmp.Version3,
+		SecurityModel: gosnmp.UserSecurityModel,
+		MsgFlags:      gosnmp.AuthPriv,
+		SecurityParameters: &gosnmp.UsmSecurityParameters{
+			UserName:                 "opennms",
+			AuthoritativeEngineID:    "\x80\x00\x1f\x88\x04onmsctl",
+			AuthenticationProtocol:   gosnmp.SHA,
+			AuthenticationPassphrase: "0p3nNMS!",
+			PrivacyProtocol:          gosnmp.AES,
+			PrivacyPassphrase:        "0p3nNMS!",
+		},
+	}
+	packet := receiveTrap(t, trap, decoder)
+	assert.Equal(t, gosnmp.Version3, packet.Version)
+	assert.Equal(t, gosnmp.SNMPv2Trap, packet.PDUType)
+	assert.Equal(t, ".1.3.6.1.6.3.1.1.5.4", packet.Variables[1].Value)
+
+	trap.SNMP.EngineID = "onmsctl"
+	err := GetTrapsAPI().SendTrap("127.0.0.1:162", trap)
+	assert.ErrorContains(t, err, "invalid engine ID onmsctl")
+}